@@ -0,0 +1,43 @@
+package main
+
+// SetMaxAttempts limits how many times workers may process a task across
+// requeues (reprocess, release); n <= 0 removes the limit
+// Must be called before the pool is used concurrently
+func (pool *AssignmentWorkerPool) SetMaxAttempts(n int) {
+	pool.maxAttempts = n
+}
+
+// exhausted reports whether the task has used up its attempts, failing it
+// permanently with MAX_ATTEMPTS_EXCEEDED if so
+func (pool *AssignmentWorkerPool) exhausted(taskID string) bool {
+	return pool.maxAttempts > 0 && pool.assigner.store.exhaustAttempts(taskID, pool.maxAttempts)
+}
+
+// recordAttempt counts one worker pass over the task
+func (s *Store) recordAttempt(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if task, exists := s.tasks[id]; exists {
+		task.Attempts++
+	}
+}
+
+// exhaustAttempts permanently fails a task that has used up its attempts
+// Returns false, leaving the task untouched, while attempts remain
+// Idempotent: a task already failed for this reason is not recorded again
+func (s *Store) exhaustAttempts(id string, maxAttempts int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, exists := s.tasks[id]
+	if !exists || task.Attempts < maxAttempts {
+		return false
+	}
+	if n := len(task.History); task.Status == TaskStatusFailed && n > 0 &&
+		task.History[n-1].Reason == ErrMaxAttemptsExceeded.Code {
+		return true
+	}
+	s.transitionTaskLocked(id, TaskStatusFailed, "", ErrMaxAttemptsExceeded.Code)
+	return true
+}
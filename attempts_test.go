@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestMaxAttemptsExceeded tests that a repeatedly failing task is failed permanently
+func TestMaxAttemptsExceeded(t *testing.T) {
+	api := setupTestAPI()
+	api.workerPool.SetMaxAttempts(2)
+	if err := api.workerPool.Start(context.Background()); err != nil {
+		t.Fatalf("Start() unexpected error: %v", err)
+	}
+	defer api.workerPool.Shutdown()
+	router := api.setupRouter()
+
+	// Nobody has the skill, so every attempt fails with NO_ELIGIBLE_EMPLOYEE
+	task := &Task{ID: "task1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "welding"}
+	api.store.AddTask(task)
+
+	waitProcessed := func() {
+		deadline := time.Now().Add(2 * time.Second)
+		for api.workerPool.IsQueued(task.ID) && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+	reprocess := func() ReprocessResponse {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/tasks/reprocess?include_failed=true", nil))
+		var response struct {
+			Data ReprocessResponse `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return response.Data
+	}
+
+	if err := api.workerPool.SubmitTask(task); err != nil {
+		t.Fatalf("SubmitTask() unexpected error: %v", err)
+	}
+	waitProcessed()
+	if got := reprocess(); got.Resubmitted != 1 {
+		t.Fatalf("Expected second attempt to be resubmitted, got %+v", got)
+	}
+	waitProcessed()
+
+	// Both attempts are used up: further requeues fail the task for good
+	for i := 0; i < 2; i++ {
+		if got := reprocess(); got.Resubmitted != 0 || got.AttemptsExceeded != 1 {
+			t.Fatalf("Expected attempts_exceeded on requeue %d, got %+v", i+1, got)
+		}
+	}
+	if err := api.workerPool.SubmitTask(task); err != ErrMaxAttemptsExceeded {
+		t.Errorf("Expected SubmitTask() to return MAX_ATTEMPTS_EXCEEDED, got %v", err)
+	}
+
+	api.store.mu.RLock()
+	defer api.store.mu.RUnlock()
+	got := api.store.tasks["task1"]
+	if got.Status != TaskStatusFailed || got.Attempts != 2 {
+		t.Errorf("Expected failed task with 2 attempts, got %s with %d", got.Status, got.Attempts)
+	}
+	exceeded := 0
+	for _, event := range got.History {
+		if event.Reason == ErrMaxAttemptsExceeded.Code {
+			exceeded++
+		}
+	}
+	if last := got.History[len(got.History)-1]; last.Reason != ErrMaxAttemptsExceeded.Code || exceeded != 1 {
+		t.Errorf("Expected one final MAX_ATTEMPTS_EXCEEDED event, got %+v", got.History)
+	}
+}
+
+// TestAttemptsListedWhileProcessing tests that tasks can be created and listed
+// while workers count their attempts; run with -race to catch shared writes
+func TestAttemptsListedWhileProcessing(t *testing.T) {
+	api := setupTestAPI()
+	if err := api.workerPool.Start(context.Background()); err != nil {
+		t.Fatalf("Start() unexpected error: %v", err)
+	}
+	defer api.workerPool.Shutdown()
+	router := api.setupRouter()
+
+	const n = 20
+	for i := 0; i < n; i++ {
+		w := httptest.NewRecorder()
+		body := `{"location": {"lat": 60.17, "lon": 24.94}, "required_skill": "welding"}`
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/tasks", strings.NewReader(body)))
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d: %s", w.Code, w.Body.String())
+		}
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/tasks", nil))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	for _, task := range api.store.GetAllTasks() {
+		if _, _, err := api.store.WaitForTerminal(ctx, task.ID); err != nil {
+			t.Fatalf("WaitForTerminal(%s) unexpected error: %v", task.ID, err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks", nil))
+	var response struct {
+		Data []Task `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(response.Data) != n {
+		t.Fatalf("Expected %d tasks, got %d", n, len(response.Data))
+	}
+	for _, task := range response.Data {
+		if task.Status != TaskStatusFailed || task.Attempts != 1 {
+			t.Errorf("Task %s: got %s with %d attempts, want failed after 1", task.ID, task.Status, task.Attempts)
+		}
+	}
+}
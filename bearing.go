@@ -0,0 +1,41 @@
+package main
+
+import "math"
+
+// compassPoints are the 8 compass directions clockwise from north
+var compassPoints = [...]string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}
+
+// CalculateBearing returns the initial great-circle bearing from loc1 to loc2
+// in degrees clockwise from north, in [0, 360)
+// Identical points have no direction and return 0; from a pole every way
+// out is south (north pole, 180) or north (south pole, 0)
+func CalculateBearing(loc1, loc2 Location) float64 {
+	if loc1 == loc2 {
+		return 0
+	}
+	switch loc1.Lat {
+	case 90:
+		return 180
+	case -90:
+		return 0
+	}
+
+	lat1Rad := loc1.Lat * math.Pi / 180
+	lat2Rad := loc2.Lat * math.Pi / 180
+	deltaLon := (loc2.Lon - loc1.Lon) * math.Pi / 180
+
+	y := math.Sin(deltaLon) * math.Cos(lat2Rad)
+	x := math.Cos(lat1Rad)*math.Sin(lat2Rad) - math.Sin(lat1Rad)*math.Cos(lat2Rad)*math.Cos(deltaLon)
+	bearing := math.Mod(math.Atan2(y, x)*180/math.Pi+360, 360)
+
+	if math.IsNaN(bearing) {
+		return 0
+	}
+	return bearing
+}
+
+// CompassDirection names the nearest of the 8 compass points for a bearing in degrees
+func CompassDirection(bearing float64) string {
+	bearing = math.Mod(math.Mod(bearing, 360)+360, 360)
+	return compassPoints[int(math.Round(bearing/45))%len(compassPoints)]
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// TestCalculateBearing tests initial bearings for known directions and the edge cases
+func TestCalculateBearing(t *testing.T) {
+	tests := []struct {
+		name      string
+		loc1      Location
+		loc2      Location
+		expected  float64
+		direction string
+	}{
+		{"Due north", Location{Lat: 60, Lon: 25}, Location{Lat: 61, Lon: 25}, 0, "N"},
+		{"Due south", Location{Lat: 60, Lon: 25}, Location{Lat: 59, Lon: 25}, 180, "S"},
+		{"Due east on the equator", Location{Lat: 0, Lon: 10}, Location{Lat: 0, Lon: 11}, 90, "E"},
+		{"Due west on the equator", Location{Lat: 0, Lon: 10}, Location{Lat: 0, Lon: 9}, 270, "W"},
+		{"Across the antimeridian", Location{Lat: 0, Lon: 179.5}, Location{Lat: 0, Lon: -179.5}, 90, "E"},
+		{"London to Paris", Location{Lat: 51.5074, Lon: -0.1278}, Location{Lat: 48.8566, Lon: 2.3522}, 148.1, "SE"},
+		{"Identical points", Location{Lat: 60.1699, Lon: 24.9384}, Location{Lat: 60.1699, Lon: 24.9384}, 0, "N"},
+		{"From the north pole", Location{Lat: 90, Lon: 0}, Location{Lat: 60, Lon: 25}, 180, "S"},
+		{"From the south pole", Location{Lat: -90, Lon: 0}, Location{Lat: 60, Lon: 25}, 0, "N"},
+		{"To the north pole", Location{Lat: 60, Lon: 25}, Location{Lat: 90, Lon: 0}, 0, "N"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bearing := CalculateBearing(tt.loc1, tt.loc2)
+			// Compare on the circle so 359.99 is close to 0
+			diff := math.Abs(math.Mod(bearing-tt.expected+540, 360) - 180)
+			if diff > 0.5 || bearing < 0 || bearing >= 360 {
+				t.Errorf("CalculateBearing() = %.2f°, want %.2f° (±0.5°)", bearing, tt.expected)
+			}
+			if got := CompassDirection(bearing); got != tt.direction {
+				t.Errorf("CompassDirection(%.2f) = %s, want %s", bearing, got, tt.direction)
+			}
+		})
+	}
+}
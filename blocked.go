@@ -0,0 +1,63 @@
+package main
+
+import (
+	"sort"
+	"time"
+)
+
+// BlockedTask is a pending task that would fail assignment right now
+type BlockedTask struct {
+	TaskID        string `json:"task_id"`
+	RequiredSkill string `json:"required_skill"`
+	// Reason is the first filter that eliminated everyone (see EligibilityDiagnostics.Reason)
+	Reason      string                 `json:"reason"`
+	Diagnostics EligibilityDiagnostics `json:"diagnostics"`
+}
+
+// diagnoseLocked runs the assigner's eligibility filters for the task without
+// assigning it, counting the survivors of each like a NO_ELIGIBLE_EMPLOYEE
+// failure would; caller must hold the store lock
+func (ta *TaskAssigner) diagnoseLocked(task *Task, now time.Time) EligibilityDiagnostics {
+	var diag EligibilityDiagnostics
+	for _, emp := range ta.store.skillCandidatesLocked(task.RequiredSkill) {
+		if !ta.matchesLocked(task, emp) {
+			continue
+		}
+		diag.WithSkill++
+		if !emp.IsAvailable || emp.isReserved(now) {
+			continue
+		}
+		diag.Available++
+		if _, ok := ta.reaches(task, emp); ok {
+			diag.WithinRadius++
+		}
+	}
+	return diag
+}
+
+// BlockedTasks returns the pending tasks nobody is eligible for right now,
+// ordered by task ID, with why; nothing is changed
+// The pre-assign hook is not consulted, so vetoes aren't predicted
+func (ta *TaskAssigner) BlockedTasks() []BlockedTask {
+	s := ta.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := s.clock.Now()
+	blocked := []BlockedTask{}
+	for _, task := range s.tasks {
+		if task.Status != TaskStatusPending {
+			continue
+		}
+		if diag := ta.diagnoseLocked(task, now); diag.WithinRadius == 0 {
+			blocked = append(blocked, BlockedTask{
+				TaskID:        task.ID,
+				RequiredSkill: task.RequiredSkill,
+				Reason:        diag.Reason(),
+				Diagnostics:   diag,
+			})
+		}
+	}
+	sort.Slice(blocked, func(i, j int) bool { return blocked[i].TaskID < blocked[j].TaskID })
+	return blocked
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestGetBlockedTasks tests that only pending tasks nobody can take are listed,
+// each with the right reason, and that listing them changes nothing
+func TestGetBlockedTasks(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	helsinki := Location{Lat: 60.1699, Lon: 24.9384}
+	turku := Location{Lat: 60.4518, Lon: 22.2666}
+	api.store.AddEmployee(&Employee{ID: "courier", Name: "Alice", Location: helsinki, Skills: []string{"delivery"}, IsAvailable: true})
+	api.store.AddEmployee(&Employee{ID: "welder", Name: "Bob", Location: helsinki, Skills: []string{"welding"}})
+
+	for _, task := range []*Task{
+		{ID: "assignable", Location: helsinki, RequiredSkill: "delivery"},
+		{ID: "anywhere", Location: turku, RequiredSkill: "delivery", IgnoreDistance: true},
+		{ID: "no-skill", Location: helsinki, RequiredSkill: "plumbing"},
+		{ID: "busy", Location: helsinki, RequiredSkill: "welding"},
+		{ID: "too-far", Location: turku, RequiredSkill: "delivery", MaxDistanceKm: 5},
+		{ID: "held", Location: helsinki, RequiredSkill: "plumbing"},
+	} {
+		api.store.AddTask(task)
+	}
+	api.store.HoldTask("held")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/blocked", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response struct {
+		Data []BlockedTask `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	want := []BlockedTask{
+		{TaskID: "busy", RequiredSkill: "welding", Reason: ReasonAllBusy, Diagnostics: EligibilityDiagnostics{WithSkill: 1}},
+		{TaskID: "no-skill", RequiredSkill: "plumbing", Reason: ReasonNoSkilledEmployee},
+		{TaskID: "too-far", RequiredSkill: "delivery", Reason: ReasonNoneInRange, Diagnostics: EligibilityDiagnostics{WithSkill: 1, Available: 1}},
+	}
+	if len(response.Data) != len(want) {
+		t.Fatalf("Expected %d blocked tasks, got %+v", len(want), response.Data)
+	}
+	for i, got := range response.Data {
+		if got != want[i] {
+			t.Errorf("Blocked task %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+
+	for _, id := range []string{"assignable", "anywhere", "no-skill", "busy", "too-far"} {
+		if status, _ := api.store.taskStatus(id); status != TaskStatusPending {
+			t.Errorf("%s is %s after listing, want still pending", id, status)
+		}
+	}
+	if emp, _ := api.store.GetEmployee("courier"); emp.ActiveTasks != 0 || !emp.IsAvailable {
+		t.Errorf("Listing touched the courier: %+v", emp)
+	}
+}
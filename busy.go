@@ -0,0 +1,41 @@
+package main
+
+import "sort"
+
+// BusyEmployee is an employee who cannot take more work, with the tasks they hold
+type BusyEmployee struct {
+	Employee
+	Tasks []Task `json:"tasks"`
+}
+
+// BusyEmployees returns snapshots of employees who are unavailable or at
+// capacity, each with their assigned tasks, ordered by employee ID
+func (s *Store) BusyEmployees() []BusyEmployee {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	assigned := make(map[string][]Task)
+	for _, task := range s.tasks {
+		if task.Status == TaskStatusAssigned && task.AssignedEmployeeID != "" {
+			assigned[task.AssignedEmployeeID] = append(assigned[task.AssignedEmployeeID], task.snapshot())
+		}
+	}
+
+	busy := []BusyEmployee{}
+	for id, emp := range s.employees {
+		if emp.IsAvailable && emp.ActiveTasks < emp.maxActiveTasks() {
+			continue
+		}
+		snapshot := *emp
+		snapshot.Skills = append([]string(nil), emp.Skills...)
+		tasks := assigned[id]
+		if tasks == nil {
+			tasks = []Task{}
+		}
+		sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+		busy = append(busy, BusyEmployee{Employee: snapshot, Tasks: tasks})
+	}
+
+	sort.Slice(busy, func(i, j int) bool { return busy[i].ID < busy[j].ID })
+	return busy
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestGetBusyEmployees tests that only unavailable or full employees are listed
+func TestGetBusyEmployees(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	helsinki := Location{Lat: 60.1699, Lon: 24.9384}
+	api.store.AddEmployee(&Employee{ID: "full", Name: "Full", Location: helsinki, Skills: []string{"delivery"}, IsAvailable: true})
+	api.store.AddEmployee(&Employee{ID: "spare", Name: "Spare", Location: Location{Lat: 60.4518, Lon: 22.2666}, Skills: []string{"delivery"}, IsAvailable: true, Capacity: 3})
+	api.store.AddEmployee(&Employee{ID: "off", Name: "Off", Location: helsinki, Skills: []string{"delivery"}, IsAvailable: false})
+
+	// Nearest fills "full" (capacity 1); the second task goes to "spare" which still has room
+	for _, id := range []string{"task1", "task2"} {
+		task := &Task{ID: id, Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"}
+		api.store.AddTask(task)
+		if _, err := api.assigner.AssignTask(context.Background(), task); err != nil {
+			t.Fatalf("AssignTask(%s) unexpected error: %v", id, err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/employees/busy", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var response struct {
+		Data []BusyEmployee `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+
+	if len(response.Data) != 2 {
+		t.Fatalf("Expected 2 busy employees, got %d", len(response.Data))
+	}
+	full, off := response.Data[0], response.Data[1]
+	if full.ID != "full" || off.ID != "off" {
+		t.Fatalf("Expected [full off], got [%s %s]", full.ID, off.ID)
+	}
+	if len(full.Tasks) != 1 || full.Tasks[0].ID != "task1" {
+		t.Errorf("Expected full to hold task1, got %+v", full.Tasks)
+	}
+	if len(off.Tasks) != 0 {
+		t.Errorf("Expected off to hold no tasks, got %d", len(off.Tasks))
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import "sort"
+
+// CancelFilter selects tasks for CancelTasks; set filters must all match
+type CancelFilter struct {
+	// Skill matches the task's required skill (empty = any)
+	Skill string
+	// Box matches tasks located inside it (nil = anywhere)
+	Box *BoundingBox
+	// IncludeAssigned also cancels assigned tasks, freeing their employees
+	IncludeAssigned bool
+}
+
+// CancelTasks cancels every pending task matching filter, and assigned ones
+// too when filter.IncludeAssigned is set, under a single lock
+// Returns the cancelled task IDs in order; tasks still queued are skipped by
+// workers since they are no longer pending
+func (s *Store) CancelTasks(filter CancelFilter) []string {
+	skill := normalizeSkill(filter.Skill)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cancelled := []string{}
+	for id, task := range s.tasks {
+		switch {
+		case task.Status == TaskStatusPending:
+		case task.Status == TaskStatusAssigned && filter.IncludeAssigned:
+		default:
+			continue
+		}
+		if skill != "" && task.RequiredSkill != skill {
+			continue
+		}
+		if filter.Box != nil && !filter.Box.Contains(task.Location) {
+			continue
+		}
+
+		if task.Status == TaskStatusAssigned {
+			if emp, ok := s.employees[task.AssignedEmployeeID]; ok {
+				if emp.ActiveTasks > 0 {
+					emp.ActiveTasks--
+				}
+				emp.IsAvailable = emp.ActiveTasks < emp.maxActiveTasks()
+			}
+		}
+		s.transitionTaskLocked(id, TaskStatusCancelled, "", "cancelled in bulk")
+		cancelled = append(cancelled, id)
+	}
+	sort.Strings(cancelled)
+	return cancelled
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestCancelTasks tests that a skill filter cancels only matching pending
+// tasks, and that assigned ones need include_assigned
+func TestCancelTasks(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	helsinki := Location{Lat: 60.1699, Lon: 24.9384}
+	api.store.AddEmployee(&Employee{ID: "emp1", Name: "Alice", Location: helsinki, Skills: []string{"delivery"}, IsAvailable: true})
+	for id, skill := range map[string]string{"d1": "delivery", "d2": "delivery", "w1": "welding", "w2": "welding"} {
+		api.store.AddTask(&Task{ID: id, Location: helsinki, RequiredSkill: skill})
+	}
+	assigned := &Task{ID: "d3", Location: helsinki, RequiredSkill: "delivery"}
+	api.store.AddTask(assigned)
+	if _, err := api.assigner.AssignTask(context.Background(), assigned); err != nil {
+		t.Fatalf("AssignTask() unexpected error: %v", err)
+	}
+
+	post := func(query string) (int, []string) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/tasks/cancel"+query, nil))
+		var response struct {
+			Data struct {
+				Cancelled int      `json:"cancelled"`
+				TaskIDs   []string `json:"task_ids"`
+			} `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		if response.Data.Cancelled != len(response.Data.TaskIDs) {
+			t.Errorf("Count %d does not match IDs %v", response.Data.Cancelled, response.Data.TaskIDs)
+		}
+		return w.Code, response.Data.TaskIDs
+	}
+	status := func(id string) TaskStatus {
+		s, _ := api.store.taskStatus(id)
+		return s
+	}
+
+	if code, _ := post(""); code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 without a filter, got %d", code)
+	}
+
+	code, ids := post("?skill=Delivery")
+	if code != http.StatusOK || len(ids) != 2 || ids[0] != "d1" || ids[1] != "d2" {
+		t.Fatalf("Expected d1 and d2 cancelled, got %d %v", code, ids)
+	}
+	for id, want := range map[string]TaskStatus{"d1": TaskStatusCancelled, "d2": TaskStatusCancelled, "d3": TaskStatusAssigned, "w1": TaskStatusPending, "w2": TaskStatusPending} {
+		if got := status(id); got != want {
+			t.Errorf("%s status = %s, want %s", id, got, want)
+		}
+	}
+
+	// The assigned task goes too once asked, and its employee gets the slot back
+	if _, ids = post("?skill=delivery&include_assigned=true"); len(ids) != 1 || ids[0] != "d3" {
+		t.Errorf("Expected d3 cancelled with include_assigned, got %v", ids)
+	}
+	if emp, _ := api.store.GetEmployee("emp1"); emp.ActiveTasks != 0 || !emp.IsAvailable {
+		t.Errorf("Expected emp1 freed, got %+v", emp)
+	}
+
+	// Box filters work alone; tasks outside it stay pending
+	if _, ids = post("?min_lat=0&min_lon=0&max_lat=1&max_lon=1"); len(ids) != 0 {
+		t.Errorf("Expected nothing inside an empty box, got %v", ids)
+	}
+	if _, ids = post("?all_pending=true"); len(ids) != 2 {
+		t.Errorf("Expected w1 and w2 cancelled by all_pending, got %v", ids)
+	}
+	if _, err := api.store.FailTask("w1", ""); err != ErrTaskCancelled {
+		t.Errorf("Expected failing a cancelled task to return ErrTaskCancelled, got %v", err)
+	}
+}
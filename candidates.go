@@ -0,0 +1,82 @@
+package main
+
+import (
+	"container/heap"
+	"math"
+)
+
+// approxDistanceSq is a cheap equirectangular distance proxy (squared degrees)
+// It preserves nearest-first ordering well at city scale; cosLat is cos(origin lat)
+func approxDistanceSq(origin, loc Location, cosLat float64) float64 {
+	dLat := loc.Lat - origin.Lat
+	dLon := math.Mod(loc.Lon-origin.Lon+540, 360) - 180
+	x := dLon * cosLat
+	return x*x + dLat*dLat
+}
+
+// approxCandidate pairs a candidate with its approximate distance
+type approxCandidate struct {
+	candidate Candidate
+	approx    float64
+}
+
+// candidateMaxHeap keeps the K nearest seen so far with the farthest on top
+type candidateMaxHeap []approxCandidate
+
+func (h candidateMaxHeap) Len() int           { return len(h) }
+func (h candidateMaxHeap) Less(i, j int) bool { return h[i].approx > h[j].approx }
+func (h candidateMaxHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *candidateMaxHeap) Push(x any)        { *h = append(*h, x.(approxCandidate)) }
+func (h *candidateMaxHeap) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+// candidateCollector gathers eligible candidates during the Phase 1 snapshot
+// With k > 0 it keeps only the k nearest by approximate distance, so huge
+// fleets never materialize a full candidate slice
+type candidateCollector struct {
+	origin Location
+	cosLat float64
+	k      int
+	all    []Candidate
+	heap   candidateMaxHeap
+}
+
+// newCandidateCollector creates a collector keeping the k nearest candidates (k <= 0 keeps all)
+func newCandidateCollector(origin Location, k int) *candidateCollector {
+	return &candidateCollector{
+		origin: origin,
+		cosLat: math.Cos(origin.Lat * math.Pi / 180),
+		k:      k,
+	}
+}
+
+// Add offers a candidate to the collector
+func (cc *candidateCollector) Add(c Candidate) {
+	if cc.k <= 0 {
+		cc.all = append(cc.all, c)
+		return
+	}
+	d := approxDistanceSq(cc.origin, c.Location, cc.cosLat)
+	if cc.heap.Len() < cc.k {
+		heap.Push(&cc.heap, approxCandidate{candidate: c, approx: d})
+	} else if d < cc.heap[0].approx {
+		cc.heap[0] = approxCandidate{candidate: c, approx: d}
+		heap.Fix(&cc.heap, 0)
+	}
+}
+
+// Candidates returns the collected candidates, in no particular order
+func (cc *candidateCollector) Candidates() []Candidate {
+	if cc.k <= 0 {
+		return cc.all
+	}
+	nearest := make([]Candidate, len(cc.heap))
+	for i, item := range cc.heap {
+		nearest[i] = item.candidate
+	}
+	return nearest
+}
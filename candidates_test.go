@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+)
+
+// TestCandidateCollector tests that the cap keeps the K nearest candidates
+func TestCandidateCollector(t *testing.T) {
+	origin := Location{Lat: 60.1700, Lon: 24.9400}
+	var candidates []Candidate
+	for i := 0; i < 50; i++ {
+		candidates = append(candidates, Candidate{
+			EmployeeID: fmt.Sprintf("emp%02d", i),
+			Location:   Location{Lat: origin.Lat + float64(i)*0.01, Lon: origin.Lon},
+		})
+	}
+
+	collect := func(candidates []Candidate, k int) []Candidate {
+		cc := newCandidateCollector(origin, k)
+		for _, c := range candidates {
+			cc.Add(c)
+		}
+		return cc.Candidates()
+	}
+
+	nearest := collect(candidates, 5)
+	if len(nearest) != 5 {
+		t.Fatalf("Expected 5 candidates, got %d", len(nearest))
+	}
+	ids := make([]string, len(nearest))
+	for i, c := range nearest {
+		ids[i] = c.EmployeeID
+	}
+	sort.Strings(ids)
+	for i, id := range ids {
+		if want := fmt.Sprintf("emp%02d", i); id != want {
+			t.Errorf("Expected %s among nearest, got %v", want, ids)
+			break
+		}
+	}
+
+	if got := collect(candidates[:3], 5); len(got) != 3 {
+		t.Errorf("Expected all 3 candidates when under the cap, got %d", len(got))
+	}
+	if got := collect(candidates, 0); len(got) != 50 {
+		t.Errorf("Expected all candidates when uncapped, got %d", len(got))
+	}
+}
+
+// TestAssignmentMaxCandidates tests that a capped assigner still picks the nearest employee
+func TestAssignmentMaxCandidates(t *testing.T) {
+	store := newBenchmarkStore(1000)
+	assigner := NewTaskAssigner(store)
+	assigner.SetMaxCandidates(10)
+
+	task := &Task{ID: "task1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"}
+	store.AddTask(task)
+
+	result, err := assigner.AssignTask(context.Background(), task)
+	if err != nil {
+		t.Fatalf("AssignTask() unexpected error: %v", err)
+	}
+	if result.EmployeeID != "emp-0" {
+		t.Errorf("Expected nearest employee emp-0, got %s", result.EmployeeID)
+	}
+}
+
+// newBenchmarkStore creates n available employees spiralling out from central Helsinki
+func newBenchmarkStore(n int) *Store {
+	store := NewStore()
+	for i := 0; i < n; i++ {
+		store.AddEmployee(&Employee{
+			ID:          fmt.Sprintf("emp-%d", i),
+			Name:        "Courier",
+			Location:    Location{Lat: 60.1700 + float64(i%250)*0.0004*float64(i/250+1), Lon: 24.9400 + float64(i/250)*0.0004},
+			Skills:      []string{"delivery"},
+			Capacity:    1 << 30,
+			IsAvailable: true,
+		})
+	}
+	return store
+}
+
+// benchmarkAssignment runs assignments against a 50k employee fleet
+func benchmarkAssignment(b *testing.B, maxCandidates int) {
+	store := newBenchmarkStore(50000)
+	assigner := NewTaskAssigner(store)
+	assigner.SetMaxCandidates(maxCandidates)
+	task := &Task{ID: "bench", Location: Location{Lat: 60.1800, Lon: 24.9500}, RequiredSkill: "delivery"}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := assigner.AssignTask(context.Background(), task); err != nil {
+			b.Fatalf("AssignTask() unexpected error: %v", err)
+		}
+	}
+}
+
+func BenchmarkAssignment50kUncapped(b *testing.B) { benchmarkAssignment(b, 0) }
+func BenchmarkAssignment50kCapped(b *testing.B)   { benchmarkAssignment(b, 20) }
+
+// TestAssignmentMaxCandidatesFiltersFirst tests that employees who can't take
+// the task don't use up the cap: with K=1 the co-located employee (inside
+// min_distance_km) and the nearer one out of their own range are skipped
+func TestAssignmentMaxCandidatesFiltersFirst(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+	assigner.SetMaxCandidates(1)
+
+	loc := Location{Lat: 60.1700, Lon: 24.9400}
+	store.AddEmployee(&Employee{ID: "colocated", Name: "Alice", Location: loc, Skills: []string{"delivery"}, IsAvailable: true})
+	store.AddEmployee(&Employee{ID: "short-range", Name: "Bob", Location: Location{Lat: 60.1800, Lon: 24.9400}, Skills: []string{"delivery"}, IsAvailable: true, MaxRangeKm: 0.5})
+	store.AddEmployee(&Employee{ID: "farther", Name: "Carol", Location: Location{Lat: 60.2000, Lon: 24.9400}, Skills: []string{"delivery"}, IsAvailable: true})
+
+	task := &Task{ID: "task1", Location: loc, RequiredSkill: "delivery", MinDistanceKm: 0.5}
+	store.AddTask(task)
+
+	result, err := assigner.AssignTask(context.Background(), task)
+	if err != nil {
+		t.Fatalf("AssignTask() unexpected error: %v", err)
+	}
+	if result.EmployeeID != "farther" {
+		t.Errorf("Expected farther, the only employee who can reach the task, got %s", result.EmployeeID)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CASRaceAction is what the pool does with a task whose chosen employee was
+// taken by a concurrent assignment
+type CASRaceAction string
+
+const (
+	// CASRaceFail fails the task with EMPLOYEE_UNAVAILABLE (the default)
+	CASRaceFail CASRaceAction = "fail"
+	// CASRaceRequeue puts the task back on the queue to be matched again
+	CASRaceRequeue CASRaceAction = "requeue"
+)
+
+// DefaultMaxRaceRequeues bounds how often one task is requeued after losing races
+const DefaultMaxRaceRequeues = 3
+
+// ParseCASRaceAction parses an action name (case-insensitive)
+func ParseCASRaceAction(name string) (CASRaceAction, error) {
+	switch action := CASRaceAction(strings.ToLower(strings.TrimSpace(name))); action {
+	case CASRaceFail, CASRaceRequeue:
+		return action, nil
+	default:
+		return "", fmt.Errorf("unknown CAS race action %q (available: fail, requeue)", name)
+	}
+}
+
+// SetCASRaceAction chooses what happens to a task that loses a CAS race
+// With CASRaceRequeue the task stays pending and goes back through the full
+// queue, up to maxRequeues times (<= 0 uses DefaultMaxRaceRequeues), after
+// which it fails as with CASRaceFail
+// Must be called before the pool is started
+func (pool *AssignmentWorkerPool) SetCASRaceAction(action CASRaceAction, maxRequeues int) {
+	if maxRequeues <= 0 {
+		maxRequeues = DefaultMaxRaceRequeues
+	}
+	pool.casRaceAction = action
+	pool.maxRaceRequeues = maxRequeues
+	pool.assigner.racesLeavePending = action == CASRaceRequeue
+}
+
+// requeueAfterRace resubmits a task that lost a CAS race
+// Returns an error, having failed the task, once its requeues are used up or
+// the queue refuses it; the caller then reports the failure as usual
+func (pool *AssignmentWorkerPool) requeueAfterRace(name string, task *Task) error {
+	pool.queuedMu.Lock()
+	n := pool.raceRequeues[task.ID]
+	if n >= pool.maxRaceRequeues {
+		pool.queuedMu.Unlock()
+		pool.failAfterRace(task.ID)
+		return ErrEmployeeNoLongerAvailable
+	}
+	// Keep the count, but let SubmitTask see the task as no longer queued
+	pool.raceRequeues[task.ID] = n + 1
+	delete(pool.queued, task.ID)
+	pool.queuedMu.Unlock()
+
+	if err := pool.SubmitTask(task); err != nil {
+		fmt.Printf("%s: Could not requeue task %s after a CAS race: %v\n", name, task.ID, err)
+		pool.failAfterRace(task.ID)
+		return ErrEmployeeNoLongerAvailable
+	}
+	fmt.Printf("%s: Task %s lost a CAS race, requeued (%d/%d)\n", name, task.ID, n+1, pool.maxRaceRequeues)
+	return nil
+}
+
+// failAfterRace fails a race-losing task left pending, unless something else
+// (e.g. the attempts limit) already resolved it
+func (pool *AssignmentWorkerPool) failAfterRace(taskID string) {
+	store := pool.assigner.store
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if task, exists := store.tasks[taskID]; exists && task.Status == TaskStatusPending {
+		store.setTaskStatusLocked(taskID, TaskStatusFailed, "")
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// raceOnceStrategy stalls the first Select until released, so the test can
+// take the chosen employee away meanwhile; later calls pick immediately
+type raceOnceStrategy struct {
+	once    *sync.Once
+	picked  chan struct{}
+	release chan struct{}
+}
+
+func (s raceOnceStrategy) Name() string { return "race_once" }
+
+func (s raceOnceStrategy) Select(task *Task, candidates []Candidate) int {
+	s.once.Do(func() {
+		close(s.picked)
+		<-s.release
+	})
+	return 0
+}
+
+// TestCASRaceRequeue tests that in requeue mode a task losing the CAS race
+// goes back through the queue and is assigned on its next pass instead of failing
+func TestCASRaceRequeue(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+	strategy := raceOnceStrategy{once: &sync.Once{}, picked: make(chan struct{}), release: make(chan struct{})}
+	assigner.SetStrategy(strategy)
+	pool := NewAssignmentWorkerPool(assigner, 1, time.Minute)
+	pool.SetCASRaceAction(CASRaceRequeue, 2)
+
+	loc := Location{Lat: 60.17, Lon: 24.94}
+	store.AddEmployee(&Employee{ID: "emp1", Name: "Alice", Location: loc, Skills: []string{"delivery"}, IsAvailable: true})
+	task := &Task{ID: "task1", Location: loc, RequiredSkill: "delivery"}
+	store.AddTask(task)
+	if err := pool.Start(context.Background()); err != nil {
+		t.Fatalf("Start() unexpected error: %v", err)
+	}
+	defer pool.Shutdown()
+	if err := pool.SubmitTask(task); err != nil {
+		t.Fatalf("SubmitTask() unexpected error: %v", err)
+	}
+
+	// emp1 is taken by "someone else" while the worker is deciding; emp2 appears
+	<-strategy.picked
+	store.mu.Lock()
+	store.employees["emp1"].IsAvailable = false
+	store.mu.Unlock()
+	store.AddEmployee(&Employee{ID: "emp2", Name: "Bob", Location: loc, Skills: []string{"delivery"}, IsAvailable: true})
+	close(strategy.release)
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if status, _ := store.taskStatus("task1"); status == TaskStatusAssigned {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	got, _ := store.GetTask("task1")
+	if got.Status != TaskStatusAssigned || got.AssignedEmployeeID != "emp2" || got.Attempts != 2 {
+		t.Fatalf("Expected task1 assigned to emp2 on a second pass, got %+v", got)
+	}
+	for _, event := range got.History {
+		if event.Status == TaskStatusFailed {
+			t.Errorf("Task was failed before being requeued: %+v", got.History)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"math"
+	"sort"
+)
+
+// PendingCentroid is the geographic center and extent of pending tasks
+// Bounds.MinLon > Bounds.MaxLon means the extent crosses the antimeridian
+type PendingCentroid struct {
+	Count    int         `json:"count"`
+	Centroid Location    `json:"centroid"`
+	Bounds   BoundingBox `json:"bounds"`
+}
+
+// PendingCentroid computes the centroid and bounds of all pending tasks
+// Returns false when there are no pending tasks
+func (s *Store) PendingCentroid() (PendingCentroid, bool) {
+	s.mu.RLock()
+	locations := make([]Location, 0)
+	for _, task := range s.tasks {
+		if task.Status == TaskStatusPending {
+			locations = append(locations, task.Location)
+		}
+	}
+	s.mu.RUnlock()
+
+	if len(locations) == 0 {
+		return PendingCentroid{}, false
+	}
+
+	result := PendingCentroid{Count: len(locations)}
+	result.Bounds.MinLat, result.Bounds.MaxLat = 90, -90
+
+	var latSum, sinSum, cosSum float64
+	lons := make([]float64, len(locations))
+	for i, loc := range locations {
+		latSum += loc.Lat
+		rad := loc.Lon * math.Pi / 180
+		sinSum += math.Sin(rad)
+		cosSum += math.Cos(rad)
+		lons[i] = loc.Lon
+		result.Bounds.MinLat = math.Min(result.Bounds.MinLat, loc.Lat)
+		result.Bounds.MaxLat = math.Max(result.Bounds.MaxLat, loc.Lat)
+	}
+
+	// Circular mean so points either side of ±180 average near the antimeridian
+	result.Centroid = Location{
+		Lat: latSum / float64(len(locations)),
+		Lon: math.Atan2(sinSum, cosSum) * 180 / math.Pi,
+	}
+	result.Bounds.MinLon, result.Bounds.MaxLon = lonExtent(lons)
+	return result, true
+}
+
+// lonExtent returns the narrowest longitude range covering all lons
+// The range starts after the widest gap between neighbouring longitudes,
+// so it may wrap (min > max) when that gap is not the one spanning ±180
+func lonExtent(lons []float64) (float64, float64) {
+	sort.Float64s(lons)
+	n := len(lons)
+
+	// Gap between the last and first longitude going east across the antimeridian
+	bestGap := lons[0] + 360 - lons[n-1]
+	minLon, maxLon := lons[0], lons[n-1]
+	for i := 1; i < n; i++ {
+		if gap := lons[i] - lons[i-1]; gap > bestGap {
+			bestGap = gap
+			minLon, maxLon = lons[i], lons[i-1]
+		}
+	}
+	return minLon, maxLon
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestPendingCentroid tests the centroid and bounds of pending tasks only
+func TestPendingCentroid(t *testing.T) {
+	store := NewStore()
+
+	if _, ok := store.PendingCentroid(); ok {
+		t.Fatal("Expected no centroid for an empty store")
+	}
+
+	store.AddTask(&Task{ID: "t1", Location: Location{Lat: 60.0, Lon: 24.0}, RequiredSkill: "delivery"})
+	store.AddTask(&Task{ID: "t2", Location: Location{Lat: 62.0, Lon: 26.0}, RequiredSkill: "delivery"})
+	store.AddTask(&Task{ID: "t3", Location: Location{Lat: 10.0, Lon: 10.0}, RequiredSkill: "delivery"})
+	store.UpdateTask("t3", TaskStatusAssigned, "emp1")
+
+	got, ok := store.PendingCentroid()
+	if !ok {
+		t.Fatal("Expected a centroid")
+	}
+	if got.Count != 2 {
+		t.Errorf("Count = %d, want 2", got.Count)
+	}
+	if math.Abs(got.Centroid.Lat-61.0) > 1e-9 || math.Abs(got.Centroid.Lon-25.0) > 1e-9 {
+		t.Errorf("Centroid = %+v, want {61 25}", got.Centroid)
+	}
+	want := BoundingBox{MinLat: 60, MinLon: 24, MaxLat: 62, MaxLon: 26}
+	if got.Bounds != want {
+		t.Errorf("Bounds = %+v, want %+v", got.Bounds, want)
+	}
+}
+
+// TestPendingCentroidAntimeridian tests longitude wraparound near ±180
+func TestPendingCentroidAntimeridian(t *testing.T) {
+	store := NewStore()
+	store.AddTask(&Task{ID: "t1", Location: Location{Lat: -17.0, Lon: 179.0}, RequiredSkill: "delivery"})
+	store.AddTask(&Task{ID: "t2", Location: Location{Lat: -18.0, Lon: -179.0}, RequiredSkill: "delivery"})
+
+	got, _ := store.PendingCentroid()
+	if math.Abs(math.Abs(got.Centroid.Lon)-180) > 1e-9 {
+		t.Errorf("Expected centroid on the antimeridian, got lon %f", got.Centroid.Lon)
+	}
+	if got.Bounds.MinLon != 179 || got.Bounds.MaxLon != -179 {
+		t.Errorf("Expected wrapped bounds 179..-179, got %f..%f", got.Bounds.MinLon, got.Bounds.MaxLon)
+	}
+}
+
+// TestPendingCentroidHandlerEmpty tests the 204 response with no pending tasks
+func TestPendingCentroidHandlerEmpty(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	req := httptest.NewRequest("GET", "/stats/pending-centroid", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected status 204, got %d", w.Code)
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of a CircuitBreaker
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"
+	CircuitOpen     CircuitState = "open"
+	CircuitHalfOpen CircuitState = "half_open"
+)
+
+// CircuitBreakerStats is a point-in-time view of a breaker, exposed via /stats
+type CircuitBreakerStats struct {
+	State               CircuitState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	Threshold           int          `json:"threshold"`
+	CooldownSeconds     float64      `json:"cooldown_seconds"`
+	OpenedAt            *time.Time   `json:"opened_at,omitempty"`
+	Trips               int          `json:"trips"`
+	ShortCircuited      int          `json:"short_circuited"`
+}
+
+// CircuitBreaker stops assignment attempts after a run of consecutive failures
+// Once open it rejects work for the cooldown period, then half-opens and lets a
+// single probe through: success closes the breaker, failure re-opens it
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	clock     Clock
+
+	state          CircuitState
+	failures       int
+	openedAt       time.Time
+	probeInFlight  bool
+	trips          int
+	shortCircuited int
+}
+
+// NewCircuitBreaker creates a closed breaker that opens after threshold consecutive failures
+func NewCircuitBreaker(threshold int, cooldown time.Duration, clock Clock) *CircuitBreaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		clock:     clock,
+		state:     CircuitClosed,
+	}
+}
+
+// Allow reports whether an attempt may proceed
+// An open breaker moves to half-open once the cooldown has elapsed
+func (cb *CircuitBreaker) Allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == CircuitOpen && cb.clock.Now().Sub(cb.openedAt) >= cb.cooldown {
+		cb.state = CircuitHalfOpen
+		cb.probeInFlight = false
+	}
+
+	switch cb.state {
+	case CircuitClosed:
+		return true
+	case CircuitHalfOpen:
+		if !cb.probeInFlight {
+			cb.probeInFlight = true
+			return true
+		}
+	}
+	cb.shortCircuited++
+	return false
+}
+
+// RecordSuccess closes the breaker and resets the failure count
+func (cb *CircuitBreaker) RecordSuccess() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.state = CircuitClosed
+	cb.failures = 0
+	cb.probeInFlight = false
+}
+
+// RecordFailure counts a failure, opening the breaker at the threshold
+// A failed half-open probe re-opens it immediately
+func (cb *CircuitBreaker) RecordFailure() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.failures++
+	if cb.state == CircuitHalfOpen || (cb.state == CircuitClosed && cb.failures >= cb.threshold) {
+		cb.state = CircuitOpen
+		cb.openedAt = cb.clock.Now()
+		cb.probeInFlight = false
+		cb.trips++
+	}
+}
+
+// Stats returns the current breaker state
+func (cb *CircuitBreaker) Stats() CircuitBreakerStats {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	stats := CircuitBreakerStats{
+		State:               cb.state,
+		ConsecutiveFailures: cb.failures,
+		Threshold:           cb.threshold,
+		CooldownSeconds:     cb.cooldown.Seconds(),
+		Trips:               cb.trips,
+		ShortCircuited:      cb.shortCircuited,
+	}
+	if cb.state != CircuitClosed {
+		openedAt := cb.openedAt
+		stats.OpenedAt = &openedAt
+	}
+	return stats
+}
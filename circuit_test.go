@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestCircuitBreakerStateTransitions tests open, half-open probe, and recovery
+func TestCircuitBreakerStateTransitions(t *testing.T) {
+	clock := newFakeClock()
+	cb := NewCircuitBreaker(3, 10*time.Second, clock)
+
+	for i := 0; i < 2; i++ {
+		cb.RecordFailure()
+	}
+	if !cb.Allow() {
+		t.Fatal("Breaker should stay closed below the threshold")
+	}
+	cb.RecordFailure()
+	if cb.Allow() {
+		t.Fatal("Breaker should be open after 3 consecutive failures")
+	}
+
+	clock.Advance(10 * time.Second)
+	if !cb.Allow() {
+		t.Fatal("Breaker should let a probe through after the cooldown")
+	}
+	if cb.Allow() {
+		t.Fatal("Only one probe should be allowed while half-open")
+	}
+
+	// Failed probe re-opens immediately
+	cb.RecordFailure()
+	if stats := cb.Stats(); stats.State != CircuitOpen || stats.Trips != 2 {
+		t.Fatalf("Expected open with 2 trips after failed probe, got %+v", stats)
+	}
+
+	clock.Advance(10 * time.Second)
+	if !cb.Allow() {
+		t.Fatal("Breaker should let a second probe through")
+	}
+	cb.RecordSuccess()
+	if stats := cb.Stats(); stats.State != CircuitClosed || stats.ConsecutiveFailures != 0 {
+		t.Fatalf("Expected closed breaker after successful probe, got %+v", stats)
+	}
+}
+
+// TestWorkerPoolCircuitBreaker tests that the pool short-circuits tasks and later recovers
+func TestWorkerPoolCircuitBreaker(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+	pool := NewAssignmentWorkerPool(assigner, 1, 5*time.Second)
+	clock := newFakeClock()
+	pool.breaker = NewCircuitBreaker(2, time.Minute, clock)
+	pool.Start(context.Background())
+	defer pool.Shutdown()
+
+	submitAndWait := func(id string) TaskStatus {
+		t.Helper()
+		task := &Task{ID: id, Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"}
+		store.AddTask(task)
+		if err := pool.SubmitTask(task); err != nil {
+			t.Fatalf("SubmitTask(%s) unexpected error: %v", id, err)
+		}
+		deadline := time.Now().Add(time.Second)
+		for time.Now().Before(deadline) && pool.IsQueued(id) {
+			time.Sleep(time.Millisecond)
+		}
+		store.mu.RLock()
+		defer store.mu.RUnlock()
+		return store.tasks[id].Status
+	}
+
+	// No employees: two real failures trip the breaker
+	submitAndWait("task1")
+	submitAndWait("task2")
+	if state := pool.breaker.Stats().State; state != CircuitOpen {
+		t.Fatalf("Expected open breaker, got %s", state)
+	}
+
+	store.AddEmployee(&Employee{
+		ID:          "emp1",
+		Name:        "John",
+		Location:    Location{Lat: 60.1699, Lon: 24.9384},
+		Skills:      []string{"delivery"},
+		Capacity:    5,
+		IsAvailable: true,
+	})
+
+	// Still open: short-circuited even though an employee now exists
+	if status := submitAndWait("task3"); status != TaskStatusFailed {
+		t.Fatalf("Expected short-circuited task to fail, got %s", status)
+	}
+	if stats := pool.breaker.Stats(); stats.ShortCircuited != 1 {
+		t.Errorf("Expected 1 short-circuited task, got %d", stats.ShortCircuited)
+	}
+	store.mu.RLock()
+	history := store.tasks["task3"].snapshot().History
+	store.mu.RUnlock()
+	if last := history[len(history)-1]; last.Status != TaskStatusFailed || last.Reason != ErrCircuitOpen.Code {
+		t.Errorf("Expected the failure recorded as %s, got %+v", ErrCircuitOpen.Code, history)
+	}
+
+	// After the cooldown the probe succeeds and the breaker closes
+	clock.Advance(time.Minute)
+	if status := submitAndWait("task4"); status != TaskStatusAssigned {
+		t.Fatalf("Expected probe task to be assigned, got %s", status)
+	}
+	if state := pool.breaker.Stats().State; state != CircuitClosed {
+		t.Errorf("Expected closed breaker after recovery, got %s", state)
+	}
+}
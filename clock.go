@@ -0,0 +1,13 @@
+package main
+
+import "time"
+
+// Clock abstracts the current time so time-dependent logic can be tested
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock is the wall clock
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
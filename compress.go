@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+)
+
+// CompressionAlgorithm selects how responses are compressed
+type CompressionAlgorithm string
+
+const (
+	CompressionNone CompressionAlgorithm = "none"
+	CompressionGzip CompressionAlgorithm = "gzip"
+	// CompressionBrotli prefers brotli and falls back to gzip for clients without it
+	CompressionBrotli CompressionAlgorithm = "brotli"
+)
+
+// DefaultCompressionMinBytes is the smallest response body worth compressing
+const DefaultCompressionMinBytes = 1024
+
+// ParseCompressionAlgorithm parses an algorithm name (case-insensitive)
+func ParseCompressionAlgorithm(name string) (CompressionAlgorithm, error) {
+	switch algorithm := CompressionAlgorithm(strings.ToLower(strings.TrimSpace(name))); algorithm {
+	case CompressionNone, CompressionGzip, CompressionBrotli:
+		return algorithm, nil
+	default:
+		return "", fmt.Errorf("unknown compression algorithm %q (available: none, gzip, brotli)", name)
+	}
+}
+
+// encodings returns the content codings offered, in server preference order
+func (a CompressionAlgorithm) encodings() []string {
+	switch a {
+	case CompressionGzip:
+		return []string{"gzip"}
+	case CompressionBrotli:
+		return []string{"br", "gzip"}
+	default:
+		return nil
+	}
+}
+
+// negotiateEncoding picks the offered coding the client rates highest in
+// Accept-Encoding; ties go to the server's order and "" means send identity
+func negotiateEncoding(acceptEncoding string, offered []string) string {
+	quality := make(map[string]float64)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding == "" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		quality[coding] = q
+	}
+
+	best, bestQ := "", 0.0
+	for _, coding := range offered {
+		q, ok := quality[coding]
+		if !ok {
+			q = quality["*"]
+		}
+		if q > bestQ {
+			best, bestQ = coding, q
+		}
+	}
+	return best
+}
+
+// compressWriter holds the body back until it reaches minBytes, then
+// compresses the rest of the response with encoding; smaller bodies go out
+// unchanged when the handler finishes
+type compressWriter struct {
+	gin.ResponseWriter
+
+	encoding string
+	minBytes int
+	buf      bytes.Buffer
+	enc      io.WriteCloser
+	decided  bool
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if w.decided {
+		if w.enc != nil {
+			return w.enc.Write(data)
+		}
+		return w.ResponseWriter.Write(data)
+	}
+	n, _ := w.buf.Write(data)
+	if w.buf.Len() >= w.minBytes {
+		if err := w.start(true); err != nil {
+			return 0, err
+		}
+	}
+	return n, nil
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// start commits to compressing or not and writes out the buffered body
+// Responses that are already encoded or can't carry a body stay as they are
+func (w *compressWriter) start(compress bool) error {
+	w.decided = true
+	status := w.ResponseWriter.Status()
+	header := w.Header()
+	if compress && header.Get("Content-Encoding") == "" && status != http.StatusNoContent && status != http.StatusNotModified {
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+		if w.encoding == "br" {
+			w.enc = brotli.NewWriter(w.ResponseWriter)
+		} else {
+			w.enc = gzip.NewWriter(w.ResponseWriter)
+		}
+	}
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	var err error
+	if w.enc != nil {
+		_, err = w.enc.Write(w.buf.Bytes())
+	} else {
+		_, err = w.ResponseWriter.Write(w.buf.Bytes())
+	}
+	w.buf.Reset()
+	return err
+}
+
+// Flush sends what is buffered, uncompressed if it is still under minBytes
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		w.start(false)
+	}
+	if flusher, ok := w.enc.(interface{ Flush() error }); ok {
+		flusher.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Close finishes the response once the handler is done
+func (w *compressWriter) Close() error {
+	if !w.decided {
+		return w.start(false)
+	}
+	if w.enc != nil {
+		return w.enc.Close()
+	}
+	return nil
+}
+
+// compress encodes response bodies of at least minBytes with the best coding
+// the client accepts from algorithm; CompressionNone disables it
+// Routes in exempt (by route pattern) stream and are never held back
+func compress(algorithm CompressionAlgorithm, minBytes int, exempt ...string) gin.HandlerFunc {
+	offered := algorithm.encodings()
+	skip := make(map[string]bool, len(exempt))
+	for _, path := range exempt {
+		skip[path] = true
+	}
+
+	return func(c *gin.Context) {
+		if len(offered) == 0 || skip[c.FullPath()] || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		// The body depends on Accept-Encoding whether or not this one is compressed
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"), offered)
+		if encoding == "" {
+			c.Next()
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: c.Writer, encoding: encoding, minBytes: minBytes}
+		c.Writer = cw
+		// On a panic the buffered body is dropped so recovery can still send a 500
+		defer func() { c.Writer = cw.ResponseWriter }()
+		c.Next()
+		cw.Close()
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+)
+
+// TestCompression tests that large responses use the coding the client
+// prefers (brotli when asked, gzip otherwise) and small ones stay plain
+func TestCompression(t *testing.T) {
+	api := setupTestAPI()
+	api.config.Compression = CompressionBrotli
+	api.config.CompressionMinBytes = 512
+	router := api.setupRouter()
+
+	for i := 0; i < 20; i++ {
+		api.store.AddEmployee(&Employee{ID: fmt.Sprintf("emp%02d", i), Name: "Courier", Location: Location{Lat: 60.1699, Lon: 24.9384}, Skills: []string{"delivery"}, IsAvailable: true})
+	}
+
+	get := func(path, acceptEncoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("GET %s: expected status 200, got %d", path, w.Code)
+		}
+		return w
+	}
+
+	cases := []struct {
+		acceptEncoding string
+		want           string
+	}{
+		{"gzip, deflate, br", "br"},
+		{"gzip;q=1.0, br;q=0.5", "gzip"},
+		{"gzip", "gzip"},
+		{"identity", ""},
+		{"", ""},
+	}
+	for _, tc := range cases {
+		w := get("/employees", tc.acceptEncoding)
+		if got := w.Header().Get("Content-Encoding"); got != tc.want {
+			t.Errorf("Accept-Encoding %q: Content-Encoding = %q, want %q", tc.acceptEncoding, got, tc.want)
+			continue
+		}
+
+		var body io.Reader = w.Body
+		switch tc.want {
+		case "br":
+			body = brotli.NewReader(w.Body)
+		case "gzip":
+			gz, err := gzip.NewReader(w.Body)
+			if err != nil {
+				t.Fatalf("Accept-Encoding %q: invalid gzip body: %v", tc.acceptEncoding, err)
+			}
+			body = gz
+		}
+		var response struct {
+			Data []Employee `json:"data"`
+		}
+		if err := json.NewDecoder(body).Decode(&response); err != nil || len(response.Data) != 20 {
+			t.Errorf("Accept-Encoding %q: decoded %d employees (err %v), want 20", tc.acceptEncoding, len(response.Data), err)
+		}
+	}
+
+	// /health is well under the threshold
+	w := get("/health", "br, gzip")
+	if got := w.Header().Get("Content-Encoding"); got != "" {
+		t.Errorf("Small response was compressed with %q", got)
+	}
+	var health map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &health); err != nil || health["status"] != "healthy" {
+		t.Errorf("Expected a plain health body, got %q", w.Body.String())
+	}
+
+	// With gzip chosen, brotli is never offered
+	api.config.Compression = CompressionGzip
+	router = api.setupRouter()
+	if got := get("/employees", "br").Header().Get("Content-Encoding"); got != "" {
+		t.Errorf("gzip mode: Content-Encoding = %q for a brotli-only client, want none", got)
+	}
+}
@@ -0,0 +1,413 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds every startup tunable; zero values of optional limits mean
+// "disabled" or "unlimited" as documented per field
+type Config struct {
+	Port string
+
+	// Skill matching
+	SkillMatchMode      SkillMatchMode
+	SkillFuzzyThreshold int
+	// SkillSeparator is what whitespace, underscores and hyphens in skills collapse to
+	SkillSeparator SkillSeparator
+
+	// Assignment
+	Strategy string
+	// DistanceBandKm applies to the nearest strategy (0 = exact ordering)
+	DistanceBandKm float64
+	// SoftmaxTemperatureKm applies to the softmax strategy (0 = default)
+	SoftmaxTemperatureKm float64
+	// TopRatedK applies to the top_rated strategy (0 = default)
+	TopRatedK                int
+	MaxConcurrentAssignments int // 0 = unlimited
+	MaxCandidates            int // 0 = score everyone
+	IgnoreDistance           bool
+	PartialResultMinFraction float64 // 0 = partial results disabled
+	RadiusTiersKm            []float64
+	// StrictLocation treats employees at {0,0} as having no location fix
+	StrictLocation bool
+
+	// Worker pool and queues
+	// WorkerCount is passed through unchecked so Start rejects non-positive counts loudly
+	WorkerCount       int
+	AssignmentTimeout time.Duration
+	// SkillAssignmentTimeouts override AssignmentTimeout for the mapped skills
+	SkillAssignmentTimeouts map[string]time.Duration
+	QueueSize               int
+	DrainTimeout            time.Duration // 0 waits for workers indefinitely
+	QueueAgingInterval      time.Duration // 0 disables aging
+	SpilloverQueueSize      int           // 0 disables the spillover queue
+	StrictFIFO              bool
+	MaxAssignmentAttempts   int // 0 = unlimited
+	CASRaceAction           CASRaceAction
+	CASRaceMaxRequeues      int
+	CircuitBreakerThreshold int // 0 disables the breaker
+	CircuitBreakerCooldown  time.Duration
+
+	// Task intake
+	MaxEmployees           int // 0 = unlimited; soft-deleted employees don't count
+	WebhookURL             string
+	ServiceAreasFile       string
+	SkillZonesFile         string
+	RejectWithoutWorkforce bool
+	TaskDedupWindow        time.Duration // 0 disables dedup
+
+	// HTTP
+	// RequestTimeout bounds each non-streaming request (0 disables)
+	RequestTimeout time.Duration
+	// Server timeouts (0 = none); streaming routes lift read and write deadlines
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	// Compression picks the response coding; bodies under CompressionMinBytes are sent as is
+	Compression         CompressionAlgorithm
+	CompressionMinBytes int
+	// MaxPageSize caps the items in one GET /tasks or GET /employees response (0 = no cap)
+	MaxPageSize int
+
+	// Utilities and housekeeping
+	DistanceUnit      DistanceUnit
+	ReaperInterval    time.Duration
+	EnableAdminStress bool
+	SnapshotDir       string
+	SnapshotInterval  time.Duration // 0 disables periodic snapshots
+	// SnapshotMaxAge degrades GET /health/snapshot past this age (0 = never)
+	// LoadConfig defaults it to twice SnapshotInterval
+	SnapshotMaxAge time.Duration
+}
+
+// DefaultConfig returns the configuration used when no environment is set
+func DefaultConfig() Config {
+	return Config{
+		Port:                    "8080",
+		SkillMatchMode:          SkillMatchExact,
+		SkillFuzzyThreshold:     defaultFuzzyThreshold,
+		SkillSeparator:          SkillSeparatorUnderscore,
+		Strategy:                NearestStrategy{}.Name(),
+		WorkerCount:             5,
+		AssignmentTimeout:       30 * time.Second,
+		QueueSize:               DefaultQueueSize,
+		DrainTimeout:            DefaultDrainTimeout,
+		QueueAgingInterval:      DefaultQueueAgingInterval,
+		CASRaceAction:           CASRaceFail,
+		CASRaceMaxRequeues:      DefaultMaxRaceRequeues,
+		CircuitBreakerThreshold: 20,
+		CircuitBreakerCooldown:  30 * time.Second,
+		RequestTimeout:          DefaultRequestTimeout,
+		ReadTimeout:             DefaultReadTimeout,
+		WriteTimeout:            DefaultWriteTimeout,
+		IdleTimeout:             DefaultIdleTimeout,
+		Compression:             CompressionGzip,
+		CompressionMinBytes:     DefaultCompressionMinBytes,
+		MaxPageSize:             DefaultMaxPageSize,
+		DistanceUnit:            UnitKilometers,
+		ReaperInterval:          DefaultReaperInterval,
+	}
+}
+
+// LoadConfig reads the configuration from the process environment
+func LoadConfig() (Config, error) {
+	return LoadConfigFrom(os.Getenv)
+}
+
+// LoadConfigFrom reads the configuration through getenv, starting from
+// DefaultConfig; unset variables keep their defaults
+// Invalid values also keep their defaults and are reported together in the
+// returned error, so the caller decides whether they are fatal
+func LoadConfigFrom(getenv func(string) string) (Config, error) {
+	cfg := DefaultConfig()
+	r := envReader{getenv: getenv}
+
+	r.read("PORT", stringValue(&cfg.Port))
+	r.read("SKILL_MATCH_MODE", func(v string) error {
+		mode, err := ParseSkillMatchMode(v)
+		if err == nil {
+			cfg.SkillMatchMode = mode
+		}
+		return err
+	})
+	r.read("SKILL_FUZZY_THRESHOLD", positiveInt(&cfg.SkillFuzzyThreshold))
+	r.read("SKILL_SEPARATOR", func(v string) error {
+		sep, err := ParseSkillSeparator(v)
+		if err == nil {
+			cfg.SkillSeparator = sep
+		}
+		return err
+	})
+
+	r.read("ASSIGNMENT_STRATEGY", func(v string) error {
+		strategy, err := StrategyByName(v)
+		if err == nil {
+			cfg.Strategy = strategy.Name()
+		}
+		return err
+	})
+	r.read("DISTANCE_BAND_KM", nonNegativeFloat(&cfg.DistanceBandKm))
+	r.read("SOFTMAX_TEMPERATURE_KM", positiveFloat(&cfg.SoftmaxTemperatureKm))
+	r.read("TOP_RATED_K", positiveInt(&cfg.TopRatedK))
+	r.read("MAX_CONCURRENT_ASSIGNMENTS", nonNegativeInt(&cfg.MaxConcurrentAssignments))
+	r.read("MAX_CANDIDATES", nonNegativeInt(&cfg.MaxCandidates))
+	r.read("IGNORE_DISTANCE", boolValue(&cfg.IgnoreDistance))
+	r.read("STRICT_LOCATION", boolValue(&cfg.StrictLocation))
+	r.read("PARTIAL_RESULT_MIN_FRACTION", func(v string) error {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f < 0 || f > 1 {
+			return errors.New("must be a number between 0 and 1")
+		}
+		cfg.PartialResultMinFraction = f
+		return nil
+	})
+	r.read("RADIUS_TIERS_KM", func(v string) error {
+		tiers, err := ParseRadiusTiers(v)
+		if err == nil {
+			cfg.RadiusTiersKm = tiers
+		}
+		return err
+	})
+
+	r.read("WORKER_COUNT", func(v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return errors.New("must be an integer")
+		}
+		cfg.WorkerCount = n
+		return nil
+	})
+	r.read("ASSIGNMENT_TIMEOUT", positiveDuration(&cfg.AssignmentTimeout))
+	r.read("SKILL_ASSIGNMENT_TIMEOUTS", func(v string) error {
+		timeouts, err := ParseSkillTimeouts(v)
+		if err == nil {
+			cfg.SkillAssignmentTimeouts = timeouts
+		}
+		return err
+	})
+	r.read("QUEUE_SIZE", positiveInt(&cfg.QueueSize))
+	r.read("DRAIN_TIMEOUT", nonNegativeDuration(&cfg.DrainTimeout))
+	r.read("QUEUE_AGING_INTERVAL", nonNegativeDuration(&cfg.QueueAgingInterval))
+	r.read("SPILLOVER_QUEUE_SIZE", nonNegativeInt(&cfg.SpilloverQueueSize))
+	r.read("STRICT_FIFO", boolValue(&cfg.StrictFIFO))
+	r.read("MAX_ASSIGNMENT_ATTEMPTS", nonNegativeInt(&cfg.MaxAssignmentAttempts))
+	r.read("CAS_RACE_ACTION", func(v string) error {
+		action, err := ParseCASRaceAction(v)
+		if err == nil {
+			cfg.CASRaceAction = action
+		}
+		return err
+	})
+	r.read("CAS_RACE_MAX_REQUEUES", positiveInt(&cfg.CASRaceMaxRequeues))
+	r.read("CIRCUIT_BREAKER_THRESHOLD", nonNegativeInt(&cfg.CircuitBreakerThreshold))
+	r.read("CIRCUIT_BREAKER_COOLDOWN", positiveDuration(&cfg.CircuitBreakerCooldown))
+
+	r.read("MAX_EMPLOYEES", nonNegativeInt(&cfg.MaxEmployees))
+	r.read("WEBHOOK_URL", stringValue(&cfg.WebhookURL))
+	r.read("SERVICE_AREAS_FILE", stringValue(&cfg.ServiceAreasFile))
+	r.read("SKILL_ZONES_FILE", stringValue(&cfg.SkillZonesFile))
+	r.read("REJECT_WITHOUT_WORKFORCE", boolValue(&cfg.RejectWithoutWorkforce))
+	r.read("TASK_DEDUP_WINDOW", nonNegativeDuration(&cfg.TaskDedupWindow))
+
+	r.read("REQUEST_TIMEOUT", nonNegativeDuration(&cfg.RequestTimeout))
+	r.read("READ_TIMEOUT", nonNegativeDuration(&cfg.ReadTimeout))
+	r.read("WRITE_TIMEOUT", nonNegativeDuration(&cfg.WriteTimeout))
+	r.read("IDLE_TIMEOUT", nonNegativeDuration(&cfg.IdleTimeout))
+	r.read("COMPRESSION", func(v string) error {
+		algorithm, err := ParseCompressionAlgorithm(v)
+		if err == nil {
+			cfg.Compression = algorithm
+		}
+		return err
+	})
+	r.read("COMPRESSION_MIN_BYTES", nonNegativeInt(&cfg.CompressionMinBytes))
+	r.read("MAX_PAGE_SIZE", nonNegativeInt(&cfg.MaxPageSize))
+
+	r.read("DISTANCE_UNIT", func(v string) error {
+		unit, err := ParseDistanceUnit(v)
+		if err == nil {
+			cfg.DistanceUnit = unit
+		}
+		return err
+	})
+	r.read("REAPER_INTERVAL", positiveDuration(&cfg.ReaperInterval))
+	r.read("ENABLE_ADMIN_STRESS", boolValue(&cfg.EnableAdminStress))
+	r.read("SNAPSHOT_DIR", stringValue(&cfg.SnapshotDir))
+	r.read("SNAPSHOT_INTERVAL", positiveDuration(&cfg.SnapshotInterval))
+	cfg.SnapshotMaxAge = 2 * cfg.SnapshotInterval
+	r.read("SNAPSHOT_MAX_AGE", nonNegativeDuration(&cfg.SnapshotMaxAge))
+
+	return cfg, errors.Join(r.errs...)
+}
+
+// Validate checks a configuration built in code (LoadConfigFrom only ever
+// produces valid ones)
+func (c Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	if _, err := ParseSkillMatchMode(string(c.SkillMatchMode)); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := ParseSkillSeparator(string(c.SkillSeparator)); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := StrategyByName(c.Strategy); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := ParseDistanceUnit(string(c.DistanceUnit)); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := ParseCompressionAlgorithm(string(c.Compression)); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := ParseCASRaceAction(string(c.CASRaceAction)); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateRadiusTiers(c.RadiusTiersKm); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateSkillTimeouts(c.SkillAssignmentTimeouts); err != nil {
+		errs = append(errs, err)
+	}
+	check(c.SkillFuzzyThreshold >= 0, "skill fuzzy threshold cannot be negative, got %d", c.SkillFuzzyThreshold)
+	check(c.DistanceBandKm >= 0, "distance band cannot be negative, got %.2f", c.DistanceBandKm)
+	check(c.SoftmaxTemperatureKm >= 0, "softmax temperature cannot be negative, got %.2f", c.SoftmaxTemperatureKm)
+	check(c.TopRatedK >= 0, "top rated K cannot be negative, got %d", c.TopRatedK)
+	check(c.MaxConcurrentAssignments >= 0, "max concurrent assignments cannot be negative, got %d", c.MaxConcurrentAssignments)
+	check(c.MaxCandidates >= 0, "max candidates cannot be negative, got %d", c.MaxCandidates)
+	check(c.PartialResultMinFraction >= 0 && c.PartialResultMinFraction <= 1,
+		"partial result fraction must be between 0 and 1, got %.2f", c.PartialResultMinFraction)
+	check(c.AssignmentTimeout > 0, "assignment timeout must be positive, got %v", c.AssignmentTimeout)
+	check(c.QueueSize > 0, "queue size must be positive, got %d", c.QueueSize)
+	check(c.DrainTimeout >= 0, "drain timeout cannot be negative, got %v", c.DrainTimeout)
+	check(c.QueueAgingInterval >= 0, "queue aging interval cannot be negative, got %v", c.QueueAgingInterval)
+	check(c.SpilloverQueueSize >= 0, "spillover queue size cannot be negative, got %d", c.SpilloverQueueSize)
+	check(c.MaxAssignmentAttempts >= 0, "max assignment attempts cannot be negative, got %d", c.MaxAssignmentAttempts)
+	check(c.CASRaceMaxRequeues >= 0, "CAS race max requeues cannot be negative, got %d", c.CASRaceMaxRequeues)
+	check(c.CircuitBreakerThreshold >= 0, "circuit breaker threshold cannot be negative, got %d", c.CircuitBreakerThreshold)
+	check(c.CircuitBreakerThreshold == 0 || c.CircuitBreakerCooldown > 0,
+		"circuit breaker cooldown must be positive, got %v", c.CircuitBreakerCooldown)
+	check(c.MaxEmployees >= 0, "max employees cannot be negative, got %d", c.MaxEmployees)
+	check(c.TaskDedupWindow >= 0, "task dedup window cannot be negative, got %v", c.TaskDedupWindow)
+	check(c.RequestTimeout >= 0, "request timeout cannot be negative, got %v", c.RequestTimeout)
+	check(c.ReadTimeout >= 0, "read timeout cannot be negative, got %v", c.ReadTimeout)
+	check(c.WriteTimeout >= 0, "write timeout cannot be negative, got %v", c.WriteTimeout)
+	check(c.IdleTimeout >= 0, "idle timeout cannot be negative, got %v", c.IdleTimeout)
+	check(c.CompressionMinBytes >= 0, "compression min bytes cannot be negative, got %d", c.CompressionMinBytes)
+	check(c.MaxPageSize >= 0, "max page size cannot be negative, got %d", c.MaxPageSize)
+	check(c.ReaperInterval > 0, "reaper interval must be positive, got %v", c.ReaperInterval)
+	check(c.SnapshotInterval >= 0, "snapshot interval cannot be negative, got %v", c.SnapshotInterval)
+	check(c.SnapshotMaxAge >= 0, "snapshot max age cannot be negative, got %v", c.SnapshotMaxAge)
+	return errors.Join(errs...)
+}
+
+// envReader parses environment variables, collecting errors for invalid ones
+type envReader struct {
+	getenv func(string) string
+	errs   []error
+}
+
+// read passes a set variable to parse; unset or blank variables are skipped
+func (r *envReader) read(name string, parse func(string) error) {
+	v := strings.TrimSpace(r.getenv(name))
+	if v == "" {
+		return
+	}
+	if err := parse(v); err != nil {
+		r.errs = append(r.errs, fmt.Errorf("invalid %s %q: %w", name, v, err))
+	}
+}
+
+func stringValue(dst *string) func(string) error {
+	return func(v string) error {
+		*dst = v
+		return nil
+	}
+}
+
+func boolValue(dst *bool) func(string) error {
+	return func(v string) error {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return errors.New("must be true or false")
+		}
+		*dst = b
+		return nil
+	}
+}
+
+func nonNegativeInt(dst *int) func(string) error {
+	return func(v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return errors.New("must be a non-negative integer")
+		}
+		*dst = n
+		return nil
+	}
+}
+
+func positiveInt(dst *int) func(string) error {
+	return func(v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return errors.New("must be a positive integer")
+		}
+		*dst = n
+		return nil
+	}
+}
+
+func nonNegativeFloat(dst *float64) func(string) error {
+	return func(v string) error {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f < 0 {
+			return errors.New("must be a non-negative number")
+		}
+		*dst = f
+		return nil
+	}
+}
+
+func positiveFloat(dst *float64) func(string) error {
+	return func(v string) error {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f <= 0 {
+			return errors.New("must be a positive number")
+		}
+		*dst = f
+		return nil
+	}
+}
+
+func nonNegativeDuration(dst *time.Duration) func(string) error {
+	return func(v string) error {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return errors.New("must be a non-negative duration such as 30s")
+		}
+		*dst = d
+		return nil
+	}
+}
+
+func positiveDuration(dst *time.Duration) func(string) error {
+	return func(v string) error {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return errors.New("must be a positive duration such as 30s")
+		}
+		*dst = d
+		return nil
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestLoadConfigFrom tests parsing a fake environment, default fallbacks and
+// reporting of invalid values
+func TestLoadConfigFrom(t *testing.T) {
+	env := map[string]string{
+		"PORT":                      "9090",
+		"ASSIGNMENT_STRATEGY":       "Softmax",
+		"WORKER_COUNT":              "12",
+		"QUEUE_SIZE":                "250",
+		"ASSIGNMENT_TIMEOUT":        "5s",
+		"DISTANCE_UNIT":             "mi",
+		"RADIUS_TIERS_KM":           "2,10",
+		"SKILL_ASSIGNMENT_TIMEOUTS": "Hazmat=2m",
+		"STRICT_FIFO":               "true",
+		"SNAPSHOT_INTERVAL":         "1m",
+		"COMPRESSION":               "Brotli",
+		"SKILL_SEPARATOR":           "Hyphen",
+		// Invalid values keep their defaults
+		"MAX_CANDIDATES":            "-3",
+		"CIRCUIT_BREAKER_COOLDOWN":  "soon",
+		"SKILL_MATCH_MODE":          "telepathic",
+		"ENABLE_ADMIN_STRESS":       "maybe",
+		"CIRCUIT_BREAKER_THRESHOLD": " ",
+	}
+	cfg, err := LoadConfigFrom(func(name string) string { return env[name] })
+
+	if cfg.Port != "9090" || cfg.Strategy != "softmax" || cfg.WorkerCount != 12 || cfg.QueueSize != 250 {
+		t.Errorf("Unexpected parsed values %+v", cfg)
+	}
+	if cfg.AssignmentTimeout != 5*time.Second || cfg.DistanceUnit != UnitMiles || !cfg.StrictFIFO || cfg.Compression != CompressionBrotli ||
+		cfg.SkillSeparator != SkillSeparatorHyphen {
+		t.Errorf("Unexpected parsed values %+v", cfg)
+	}
+	if !reflect.DeepEqual(cfg.RadiusTiersKm, []float64{2, 10}) {
+		t.Errorf("RadiusTiersKm = %v, want [2 10]", cfg.RadiusTiersKm)
+	}
+	if cfg.SkillAssignmentTimeouts["hazmat"] != 2*time.Minute {
+		t.Errorf("SkillAssignmentTimeouts = %v, want hazmat=2m", cfg.SkillAssignmentTimeouts)
+	}
+	if cfg.SnapshotMaxAge != 2*time.Minute {
+		t.Errorf("SnapshotMaxAge = %v, want twice the interval", cfg.SnapshotMaxAge)
+	}
+
+	defaults := DefaultConfig()
+	if cfg.MaxCandidates != defaults.MaxCandidates || cfg.CircuitBreakerCooldown != defaults.CircuitBreakerCooldown ||
+		cfg.SkillMatchMode != defaults.SkillMatchMode || cfg.EnableAdminStress || cfg.CircuitBreakerThreshold != defaults.CircuitBreakerThreshold {
+		t.Errorf("Expected invalid and blank values to fall back to defaults, got %+v", cfg)
+	}
+	if cfg.ReaperInterval != DefaultReaperInterval || cfg.QueueAgingInterval != DefaultQueueAgingInterval {
+		t.Errorf("Expected unset values to keep defaults, got %+v", cfg)
+	}
+
+	if err == nil {
+		t.Fatal("Expected an error listing the invalid settings")
+	}
+	for _, name := range []string{"MAX_CANDIDATES", "CIRCUIT_BREAKER_COOLDOWN", "SKILL_MATCH_MODE", "ENABLE_ADMIN_STRESS"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("Error %q does not mention %s", err, name)
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Loaded config should be valid, got %v", err)
+	}
+
+	empty, err := LoadConfigFrom(func(string) string { return "" })
+	if err != nil || !reflect.DeepEqual(empty, defaults) {
+		t.Errorf("Empty environment = %+v, %v; want defaults", empty, err)
+	}
+}
+
+// TestNewAPIWithConfig tests that explicit configuration reaches the API and
+// that invalid configuration is rejected
+func TestNewAPIWithConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.QueueSize = 3
+	cfg.Strategy = "reverse_distance"
+	cfg.RejectWithoutWorkforce = true
+
+	api, err := NewAPIWithConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewAPIWithConfig() unexpected error: %v", err)
+	}
+	if api.workerPool.taskQueue.capacity != 3 || api.assigner.Strategy().Name() != "reverse_distance" || !api.rejectNoSkill {
+		t.Errorf("Configuration was not applied")
+	}
+
+	cfg.QueueSize = 0
+	cfg.Strategy = "random"
+	if _, err := NewAPIWithConfig(cfg); err == nil {
+		t.Error("Expected invalid configuration to be rejected")
+	}
+
+	cfg = DefaultConfig()
+	cfg.ServiceAreasFile = "/nonexistent/areas.json"
+	if _, err := NewAPIWithConfig(cfg); err == nil {
+		t.Error("Expected a missing service areas file to be reported")
+	}
+}
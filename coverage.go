@@ -0,0 +1,86 @@
+package main
+
+import "math"
+
+// MaxCoverageGrid caps the cells per side of a coverage grid
+const MaxCoverageGrid = 50
+
+// DefaultCoverageGrid is the grid size used when none is requested
+const DefaultCoverageGrid = 10
+
+// CoverageCell reports demand and supply for one grid cell
+type CoverageCell struct {
+	Row                int         `json:"row"`
+	Col                int         `json:"col"`
+	BoundingBox        BoundingBox `json:"bounding_box"`
+	PendingTasks       int         `json:"pending_tasks"`
+	AvailableEmployees int         `json:"available_employees"`
+	// AvailableSlots is the free capacity of the available employees
+	AvailableSlots int `json:"available_slots"`
+	// Deficit is how many pending tasks exceed the free slots
+	Deficit int `json:"deficit"`
+}
+
+// CoverageReport lists the under-served cells of a grid over a bounding box
+type CoverageReport struct {
+	Skill       string         `json:"skill"`
+	BoundingBox BoundingBox    `json:"bounding_box"`
+	Grid        int            `json:"grid"`
+	Gaps        []CoverageCell `json:"gaps"`
+}
+
+// CoverageGaps splits box into a grid×grid mesh and reports the cells where
+// pending tasks needing skill outnumber the free slots of available employees
+// with it, ordered by row (south to north) then column (west to east)
+// Points on the box's north or east edge fall into the last row or column
+func (s *Store) CoverageGaps(skill string, box BoundingBox, grid int) CoverageReport {
+	skill = normalizeSkill(skill)
+	latStep := (box.MaxLat - box.MinLat) / float64(grid)
+	lonStep := (box.MaxLon - box.MinLon) / float64(grid)
+	// A zero-height or zero-width box puts everything in the first row or column
+	index := func(v, lo, step float64) int {
+		if step == 0 {
+			return 0
+		}
+		return min(int(math.Floor((v-lo)/step)), grid-1)
+	}
+	cellOf := func(l Location) int {
+		return index(l.Lat, box.MinLat, latStep)*grid + index(l.Lon, box.MinLon, lonStep)
+	}
+
+	cells := make([]CoverageCell, grid*grid)
+	s.mu.RLock()
+	now := s.clock.Now()
+	for _, task := range s.tasks {
+		if task.Status == TaskStatusPending && task.RequiredSkill == skill && box.Contains(task.Location) {
+			cells[cellOf(task.Location)].PendingTasks++
+		}
+	}
+	for _, emp := range s.employees {
+		if !emp.IsAvailable || emp.isReserved(now) || !box.Contains(emp.Location) ||
+			!s.skillMatcher.Matches(emp.Skills, skill) {
+			continue
+		}
+		cell := &cells[cellOf(emp.Location)]
+		cell.AvailableEmployees++
+		cell.AvailableSlots += max(emp.maxActiveTasks()-emp.ActiveTasks, 0)
+	}
+	s.mu.RUnlock()
+
+	report := CoverageReport{Skill: skill, BoundingBox: box, Grid: grid, Gaps: []CoverageCell{}}
+	for i, cell := range cells {
+		if cell.PendingTasks <= cell.AvailableSlots {
+			continue
+		}
+		cell.Row, cell.Col = i/grid, i%grid
+		cell.BoundingBox = BoundingBox{
+			MinLat: box.MinLat + float64(cell.Row)*latStep,
+			MinLon: box.MinLon + float64(cell.Col)*lonStep,
+			MaxLat: box.MinLat + float64(cell.Row+1)*latStep,
+			MaxLon: box.MinLon + float64(cell.Col+1)*lonStep,
+		}
+		cell.Deficit = cell.PendingTasks - cell.AvailableSlots
+		report.Gaps = append(report.Gaps, cell)
+	}
+	return report
+}
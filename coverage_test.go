@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestCoverageGaps tests that only cells with more pending demand than free
+// supply for the skill are flagged
+func TestCoverageGaps(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	// 2x2 grid over lat 60-61, lon 24-26: cells are 0.5° tall and 1° wide
+	addTask := func(id string, lat, lon float64, skill string) {
+		api.store.AddTask(&Task{ID: id, Location: Location{Lat: lat, Lon: lon}, RequiredSkill: skill, Status: TaskStatusPending})
+	}
+	// Cell (0,0): three tasks against one slot
+	addTask("sw1", 60.1, 24.1, "delivery")
+	addTask("sw2", 60.2, 24.2, "delivery")
+	addTask("sw3", 60.3, 24.3, "delivery")
+	api.store.AddEmployee(&Employee{ID: "sw", Name: "SW", Location: Location{Lat: 60.2, Lon: 24.5}, Skills: []string{"delivery"}, IsAvailable: true, Capacity: 1})
+	// Cell (0,1): one task, two slots
+	addTask("se1", 60.1, 25.5, "delivery")
+	api.store.AddEmployee(&Employee{ID: "se", Name: "SE", Location: Location{Lat: 60.2, Lon: 25.5}, Skills: []string{"delivery"}, IsAvailable: true, Capacity: 2})
+	// Cell (1,0): demand for another skill only
+	addTask("nw1", 60.7, 24.5, "welding")
+	// Cell (1,1): a task on the box's north-east corner; nobody usable nearby
+	addTask("ne1", 61.0, 26.0, "delivery")
+	api.store.AddEmployee(&Employee{ID: "offline", Name: "Offline", Location: Location{Lat: 60.7, Lon: 25.5}, Skills: []string{"delivery"}, IsAvailable: false})
+	api.store.AddEmployee(&Employee{ID: "welder", Name: "Welder", Location: Location{Lat: 60.7, Lon: 25.5}, Skills: []string{"welding"}, IsAvailable: true})
+	// Outside the box
+	addTask("out", 62.0, 25.0, "delivery")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/stats/coverage?skill=Delivery&grid=2&min_lat=60&min_lon=24&max_lat=61&max_lon=26", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response struct {
+		Data CoverageReport `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	gaps := response.Data.Gaps
+
+	if len(gaps) != 2 {
+		t.Fatalf("Expected 2 deficit cells, got %d: %+v", len(gaps), gaps)
+	}
+	if g := gaps[0]; g.Row != 0 || g.Col != 0 || g.PendingTasks != 3 || g.AvailableSlots != 1 || g.Deficit != 2 {
+		t.Errorf("Unexpected south-west cell %+v", g)
+	}
+	if g := gaps[1]; g.Row != 1 || g.Col != 1 || g.PendingTasks != 1 || g.AvailableEmployees != 0 || g.Deficit != 1 {
+		t.Errorf("Unexpected north-east cell %+v", g)
+	}
+	if box := gaps[1].BoundingBox; box.MinLat != 60.5 || box.MinLon != 25 || box.MaxLat != 61 || box.MaxLon != 26 {
+		t.Errorf("Unexpected north-east cell bounds %+v", box)
+	}
+
+	for _, query := range []string{
+		"grid=2&min_lat=60&min_lon=24&max_lat=61&max_lon=26",
+		"skill=delivery&grid=0&min_lat=60&min_lon=24&max_lat=61&max_lon=26",
+		"skill=delivery&grid=51&min_lat=60&min_lon=24&max_lat=61&max_lon=26",
+		"skill=delivery&grid=big&min_lat=60&min_lon=24&max_lat=61&max_lon=26",
+		"skill=delivery&grid=2&min_lat=61&min_lon=24&max_lat=60&max_lon=26",
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/stats/coverage?"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %q, got %d", query, w.Code)
+		}
+	}
+}
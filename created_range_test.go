@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestGetTasksCreatedRange tests that created_after/created_before select tasks
+// by their store-clock creation time and reject bad or inverted ranges
+func TestGetTasksCreatedRange(t *testing.T) {
+	api := setupTestAPI()
+	clock := newFakeClock()
+	api.store.SetClock(clock)
+	router := api.setupRouter()
+
+	start := clock.Now()
+	for _, id := range []string{"t0", "t1", "t2", "t3"} {
+		api.store.AddTask(&Task{ID: id, Location: Location{Lat: 60.1699, Lon: 24.9384}, RequiredSkill: "delivery"})
+		clock.Advance(time.Hour)
+	}
+
+	get := func(query string) (int, []string) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks"+query, nil))
+		var response struct {
+			Data []Task `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		var ids []string
+		for _, task := range response.Data {
+			ids = append(ids, task.ID)
+		}
+		return w.Code, ids
+	}
+	at := func(hours int) string { return start.Add(time.Duration(hours) * time.Hour).Format(time.RFC3339) }
+
+	cases := []struct {
+		query string
+		want  []string
+	}{
+		{"?created_after=" + at(1) + "&created_before=" + at(3), []string{"t1", "t2"}},
+		{"?created_after=" + at(2), []string{"t2", "t3"}},
+		{"?created_before=" + at(1), []string{"t0"}},
+	}
+	for _, tc := range cases {
+		code, ids := get(tc.query)
+		if code != http.StatusOK {
+			t.Fatalf("GET /tasks%s: expected status 200, got %d", tc.query, code)
+		}
+		if len(ids) != len(tc.want) {
+			t.Errorf("GET /tasks%s = %v, want %v", tc.query, ids, tc.want)
+			continue
+		}
+		for i := range ids {
+			if ids[i] != tc.want[i] {
+				t.Errorf("GET /tasks%s = %v, want %v", tc.query, ids, tc.want)
+				break
+			}
+		}
+	}
+
+	for _, query := range []string{"?created_after=yesterday", "?created_after=" + at(3) + "&created_before=" + at(1), "?created_after=" + at(1) + "&created_before=" + at(1)} {
+		if code, _ := get(query); code != http.StatusBadRequest {
+			t.Errorf("GET /tasks%s: expected status 400, got %d", query, code)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// dedupPrecision rounds coordinates to 4 decimals (~11m) when building dedup keys
+const dedupPrecision = 1e4
+
+// dedupEntry is a recently created task in the dedup index
+type dedupEntry struct {
+	taskID    string
+	createdAt time.Time
+	// inFlight is set until Confirm reports the task was stored
+	inFlight bool
+}
+
+// TaskDedupIndex remembers recently created tasks by skill and rounded location
+// so accidental double-submits can be rejected within a time window
+type TaskDedupIndex struct {
+	mu      sync.Mutex
+	window  time.Duration
+	clock   Clock
+	store   *Store
+	entries map[string][]dedupEntry
+	// lastSweep is when expired keys were last dropped from entries
+	lastSweep time.Time
+}
+
+// NewTaskDedupIndex creates an index rejecting duplicates created within window
+func NewTaskDedupIndex(store *Store, window time.Duration, clock Clock) *TaskDedupIndex {
+	return &TaskDedupIndex{
+		window:  window,
+		clock:   clock,
+		store:   store,
+		entries: make(map[string][]dedupEntry),
+	}
+}
+
+// dedupKey builds the index key from the normalized skill and rounded location
+func dedupKey(task *Task) string {
+	return fmt.Sprintf("%s|%.0f|%.0f", task.RequiredSkill,
+		math.Round(task.Location.Lat*dedupPrecision),
+		math.Round(task.Location.Lon*dedupPrecision))
+}
+
+// Reserve records the task unless an identical task created within the window
+// is still pending, in which case it returns that task's ID and false
+// Reserved tasks not yet confirmed are in flight and count as pending
+func (d *TaskDedupIndex) Reserve(task *Task) (string, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.clock.Now()
+	cutoff := now.Add(-d.window)
+	// Other keys only shed expired entries here, so sweep them once per window
+	if now.Sub(d.lastSweep) >= d.window {
+		for key := range d.entries {
+			d.pruneLocked(key, cutoff)
+		}
+		d.lastSweep = now
+	}
+
+	key := dedupKey(task)
+	d.pruneLocked(key, cutoff)
+	for _, entry := range d.entries[key] {
+		if d.pendingOrInFlight(entry) {
+			return entry.taskID, false
+		}
+	}
+
+	d.entries[key] = append(d.entries[key], dedupEntry{taskID: task.ID, createdAt: now, inFlight: true})
+	return "", true
+}
+
+// Confirm marks a reserved task as stored, so from then on only its status
+// decides whether it blocks duplicates
+func (d *TaskDedupIndex) Confirm(task *Task) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entries := d.entries[dedupKey(task)]
+	for i := range entries {
+		if entries[i].taskID == task.ID {
+			entries[i].inFlight = false
+			break
+		}
+	}
+}
+
+// pruneLocked drops the key's entries created at or before cutoff, and the key
+// once none are left; caller must hold d.mu
+func (d *TaskDedupIndex) pruneLocked(key string, cutoff time.Time) {
+	live := d.entries[key][:0]
+	for _, entry := range d.entries[key] {
+		if entry.createdAt.After(cutoff) {
+			live = append(live, entry)
+		}
+	}
+	if len(live) == 0 {
+		delete(d.entries, key)
+		return
+	}
+	d.entries[key] = live
+}
+
+// Release forgets a reservation whose task was never created
+func (d *TaskDedupIndex) Release(task *Task) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key := dedupKey(task)
+	entries := d.entries[key]
+	for i, entry := range entries {
+		if entry.taskID == task.ID {
+			d.entries[key] = append(entries[:i], entries[i+1:]...)
+			break
+		}
+	}
+	if len(d.entries[key]) == 0 {
+		delete(d.entries, key)
+	}
+}
+
+// Reset forgets every reservation, e.g. after the store was restored
+func (d *TaskDedupIndex) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.entries = make(map[string][]dedupEntry)
+}
+
+// pendingOrInFlight reports whether a reserved task is still being created or
+// is pending; a stored task that has since disappeared no longer blocks
+func (d *TaskDedupIndex) pendingOrInFlight(entry dedupEntry) bool {
+	if entry.inFlight {
+		return true
+	}
+	d.store.mu.RLock()
+	defer d.store.mu.RUnlock()
+
+	task, exists := d.store.tasks[entry.taskID]
+	return exists && task.Status == TaskStatusPending
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestTaskDedupIndex tests window expiry and that only pending tasks block duplicates
+func TestTaskDedupIndex(t *testing.T) {
+	store := NewStore()
+	clock := newFakeClock()
+	index := NewTaskDedupIndex(store, time.Minute, clock)
+
+	first := &Task{ID: "task1", Location: Location{Lat: 60.17000, Lon: 24.94000}, RequiredSkill: "delivery", Status: TaskStatusPending}
+	if _, ok := index.Reserve(first); !ok {
+		t.Fatal("First reservation should succeed")
+	}
+	store.AddTask(first)
+	index.Confirm(first)
+
+	// Within ~1m of the first task: same rounded location
+	nearDup := &Task{ID: "task2", Location: Location{Lat: 60.170001, Lon: 24.940002}, RequiredSkill: "delivery"}
+	if existing, ok := index.Reserve(nearDup); ok || existing != "task1" {
+		t.Fatalf("Expected near-identical task to be deduped against task1, got %q ok=%v", existing, ok)
+	}
+
+	// Different skill is not a duplicate
+	if _, ok := index.Reserve(&Task{ID: "task3", Location: first.Location, RequiredSkill: "cleaning"}); !ok {
+		t.Error("Task with a different skill should not be deduped")
+	}
+
+	// Once the original is no longer pending it stops blocking
+	store.UpdateTask("task1", TaskStatusAssigned, "emp1")
+	if _, ok := index.Reserve(&Task{ID: "task4", Location: first.Location, RequiredSkill: "delivery"}); !ok {
+		t.Error("Assigned task should not block a new identical task")
+	}
+	store.AddTask(&Task{ID: "task4", Location: first.Location, RequiredSkill: "delivery", Status: TaskStatusPending})
+	index.Confirm(&Task{ID: "task4", Location: first.Location, RequiredSkill: "delivery"})
+
+	// After the window the pending task4 no longer blocks
+	clock.Advance(time.Minute + time.Second)
+	if _, ok := index.Reserve(&Task{ID: "task5", Location: first.Location, RequiredSkill: "delivery"}); !ok {
+		t.Error("Reservation outside the window should succeed")
+	}
+}
+
+// TestTaskDedupIndexCleanup tests that a stored task that disappeared stops
+// blocking, and that expired keys are swept even if never reserved again
+func TestTaskDedupIndexCleanup(t *testing.T) {
+	store := NewStore()
+	clock := newFakeClock()
+	index := NewTaskDedupIndex(store, time.Minute, clock)
+
+	// Confirmed but no longer in the store (e.g. replaced by a restore)
+	gone := &Task{ID: "gone", Location: Location{Lat: 60.17, Lon: 24.94}, RequiredSkill: "delivery"}
+	index.Reserve(gone)
+	index.Confirm(gone)
+	if _, ok := index.Reserve(&Task{ID: "again", Location: gone.Location, RequiredSkill: "delivery"}); !ok {
+		t.Error("A task missing from the store after creation should not block")
+	}
+
+	for i := 0; i < 100; i++ {
+		index.Reserve(&Task{ID: fmt.Sprintf("task%d", i), Location: Location{Lat: 60 + float64(i)*0.01, Lon: 24.94}, RequiredSkill: "delivery"})
+	}
+	clock.Advance(time.Minute + time.Second)
+	index.Reserve(&Task{ID: "late", Location: Location{Lat: 61.5, Lon: 25.5}, RequiredSkill: "delivery"})
+
+	index.mu.Lock()
+	defer index.mu.Unlock()
+	if len(index.entries) != 1 {
+		t.Errorf("Expected only the latest key after the window, got %d keys", len(index.entries))
+	}
+}
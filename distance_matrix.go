@@ -0,0 +1,100 @@
+package main
+
+import (
+	"math"
+	"sort"
+)
+
+// DistanceMatrix holds the distance from every task in a batch to every
+// employee, computed once so matching passes over the batch can share it
+// Pairs the eligibility check rejected have no distance
+type DistanceMatrix struct {
+	tasks     []*Task
+	employees []*Employee
+	taskIndex map[string]int
+	empIndex  map[string]int
+	km        [][]float64 // [task][employee]; NaN where ineligible
+	eligible  []int       // eligible employees per task
+}
+
+// MatrixPair is one eligible task-employee pair in a DistanceMatrix
+type MatrixPair struct {
+	Task       *Task
+	Employee   *Employee
+	DistanceKm float64
+}
+
+// NewDistanceMatrix evaluates eligible for every task-employee pair and
+// records the distance it reports for the eligible ones
+func NewDistanceMatrix(tasks []*Task, employees []*Employee, eligible func(*Task, *Employee) (float64, bool)) *DistanceMatrix {
+	m := &DistanceMatrix{
+		tasks:     tasks,
+		employees: employees,
+		taskIndex: make(map[string]int, len(tasks)),
+		empIndex:  make(map[string]int, len(employees)),
+		km:        make([][]float64, len(tasks)),
+		eligible:  make([]int, len(tasks)),
+	}
+	for j, emp := range employees {
+		m.empIndex[emp.ID] = j
+	}
+	// One backing array keeps the rows contiguous
+	cells := make([]float64, len(tasks)*len(employees))
+	for i, task := range tasks {
+		m.taskIndex[task.ID] = i
+		row := cells[i*len(employees) : (i+1)*len(employees)]
+		for j, emp := range employees {
+			distance, ok := eligible(task, emp)
+			if !ok {
+				row[j] = math.NaN()
+				continue
+			}
+			row[j] = distance
+			m.eligible[i]++
+		}
+		m.km[i] = row
+	}
+	return m
+}
+
+// Distance returns the distance for a pair, or false if the pair is not
+// eligible or not part of the batch
+func (m *DistanceMatrix) Distance(taskID, employeeID string) (float64, bool) {
+	i, okTask := m.taskIndex[taskID]
+	j, okEmp := m.empIndex[employeeID]
+	if !okTask || !okEmp || math.IsNaN(m.km[i][j]) {
+		return 0, false
+	}
+	return m.km[i][j], true
+}
+
+// EligibleCount returns how many employees are eligible for the task
+func (m *DistanceMatrix) EligibleCount(taskID string) int {
+	if i, ok := m.taskIndex[taskID]; ok {
+		return m.eligible[i]
+	}
+	return 0
+}
+
+// Pairs returns every eligible pair, closest first (ties by task, then employee ID)
+func (m *DistanceMatrix) Pairs() []MatrixPair {
+	var pairs []MatrixPair
+	for i, row := range m.km {
+		for j, distance := range row {
+			if !math.IsNaN(distance) {
+				pairs = append(pairs, MatrixPair{Task: m.tasks[i], Employee: m.employees[j], DistanceKm: distance})
+			}
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		a, b := pairs[i], pairs[j]
+		if a.DistanceKm != b.DistanceKm {
+			return a.DistanceKm < b.DistanceKm
+		}
+		if a.Task.ID != b.Task.ID {
+			return a.Task.ID < b.Task.ID
+		}
+		return a.Employee.ID < b.Employee.ID
+	})
+	return pairs
+}
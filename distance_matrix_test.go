@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestDistanceMatrix tests that matrix distances match per-pair CalculateDistance
+// for eligible pairs and that ineligible pairs are left out
+func TestDistanceMatrix(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+
+	employees := []*Employee{
+		{ID: "emp1", Location: Location{Lat: 60.1699, Lon: 24.9384}, Skills: []string{"delivery"}, IsAvailable: true},
+		{ID: "emp2", Location: Location{Lat: 60.2055, Lon: 24.6559}, Skills: []string{"delivery"}, IsAvailable: true},
+		{ID: "welder", Location: Location{Lat: 60.1700, Lon: 24.9400}, Skills: []string{"welding"}, IsAvailable: true},
+	}
+	tasks := []*Task{
+		{ID: "task1", Location: Location{Lat: 60.1710, Lon: 24.9410}, RequiredSkill: "delivery"},
+		{ID: "task2", Location: Location{Lat: 60.1800, Lon: 24.9500}, RequiredSkill: "delivery", MaxDistanceKm: 5},
+		{ID: "task3", Location: Location{Lat: 60.1650, Lon: 24.9300}, RequiredSkill: "welding"},
+	}
+	// emp2 is ~16 km from task2, beyond its 5 km bound; only the welder can weld
+	eligible := map[string]bool{
+		"task1/emp1":   true,
+		"task1/emp2":   true,
+		"task2/emp1":   true,
+		"task3/welder": true,
+	}
+
+	store.mu.RLock()
+	matrix := NewDistanceMatrix(tasks, employees, func(task *Task, emp *Employee) (float64, bool) {
+		return assigner.eligibleLocked(task, emp, 1)
+	})
+	store.mu.RUnlock()
+
+	for _, task := range tasks {
+		count := 0
+		for _, emp := range employees {
+			distance, ok := matrix.Distance(task.ID, emp.ID)
+			if want := eligible[task.ID+"/"+emp.ID]; ok != want {
+				t.Errorf("Distance(%s, %s) eligible = %v, want %v", task.ID, emp.ID, ok, want)
+				continue
+			}
+			if !ok {
+				continue
+			}
+			count++
+			if want := CalculateDistance(task.Location, emp.Location); distance != want {
+				t.Errorf("Distance(%s, %s) = %f, want %f", task.ID, emp.ID, distance, want)
+			}
+		}
+		if got := matrix.EligibleCount(task.ID); got != count {
+			t.Errorf("EligibleCount(%s) = %d, want %d", task.ID, got, count)
+		}
+	}
+	if _, ok := matrix.Distance("unknown", "emp1"); ok {
+		t.Error("Expected no distance for a task outside the batch")
+	}
+
+	pairs := matrix.Pairs()
+	if len(pairs) != len(eligible) {
+		t.Fatalf("Expected %d pairs, got %d", len(eligible), len(pairs))
+	}
+	for i := 1; i < len(pairs); i++ {
+		if pairs[i-1].DistanceKm > pairs[i].DistanceKm {
+			t.Errorf("Pairs not ordered by distance: %v before %v", pairs[i-1].DistanceKm, pairs[i].DistanceKm)
+		}
+	}
+}
+
+// BenchmarkDistanceMatrix builds the matrix for a 100 task x 500 employee batch
+func BenchmarkDistanceMatrix(b *testing.B) {
+	store := newBenchmarkStore(500)
+	assigner := NewTaskAssigner(store)
+	employees := make([]*Employee, 0, len(store.employees))
+	for _, emp := range store.employees {
+		employees = append(employees, emp)
+	}
+	tasks := make([]*Task, 100)
+	for i := range tasks {
+		tasks[i] = &Task{
+			ID:            fmt.Sprintf("task-%d", i),
+			Location:      Location{Lat: 60.1700 + float64(i)*0.001, Lon: 24.9400},
+			RequiredSkill: "delivery",
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		store.mu.RLock()
+		matrix := NewDistanceMatrix(tasks, employees, func(task *Task, emp *Employee) (float64, bool) {
+			return assigner.eligibleLocked(task, emp, 1)
+		})
+		matrix.Pairs()
+		store.mu.RUnlock()
+	}
+}
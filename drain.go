@@ -0,0 +1,78 @@
+package main
+
+import (
+	"log"
+	"sort"
+	"time"
+)
+
+// DefaultDrainTimeout bounds how long Shutdown waits for workers to finish
+const DefaultDrainTimeout = 30 * time.Second
+
+// SetDrainTimeout bounds how long Shutdown waits for in-flight tasks; past it,
+// their assignments are cancelled and the tasks failed. d <= 0 waits indefinitely
+// Must be called before the pool is shut down
+func (pool *AssignmentWorkerPool) SetDrainTimeout(d time.Duration) {
+	pool.drainTimeout = d
+}
+
+// waitDrained waits for the workers to exit; if the drain timeout passes
+// first it cancels their contexts, fails every task still being processed and
+// returns without waiting for the stuck workers
+func (pool *AssignmentWorkerPool) waitDrained() {
+	done := make(chan struct{})
+	go func() {
+		pool.wg.Wait()
+		close(done)
+	}()
+
+	// Workers are gone (or abandoned) once this returns; release their context
+	defer func() {
+		if pool.cancel != nil {
+			pool.cancel()
+		}
+	}()
+
+	if pool.drainTimeout <= 0 {
+		<-done
+		return
+	}
+	timer := time.NewTimer(pool.drainTimeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return
+	case <-timer.C:
+	}
+
+	if pool.cancel != nil {
+		pool.cancel()
+	}
+	failed := pool.failProcessing()
+	log.Printf("Worker pool drain timed out after %v; failed %d stuck tasks: %v", pool.drainTimeout, len(failed), failed)
+}
+
+// failProcessing fails the in-flight tasks that are still pending and
+// returns their IDs in order; a late commit by a stuck worker then sees the
+// task is no longer pending and backs off
+func (pool *AssignmentWorkerPool) failProcessing() []string {
+	pool.queuedMu.Lock()
+	ids := make([]string, 0, len(pool.processing))
+	for id := range pool.processing {
+		ids = append(ids, id)
+	}
+	pool.queuedMu.Unlock()
+	sort.Strings(ids)
+
+	store := pool.assigner.store
+	failed := ids[:0]
+	store.mu.Lock()
+	for _, id := range ids {
+		if task, exists := store.tasks[id]; exists && task.Status == TaskStatusPending {
+			store.transitionTaskLocked(id, TaskStatusFailed, "", "shutdown drain timed out")
+			failed = append(failed, id)
+		}
+	}
+	store.mu.Unlock()
+	return failed
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingStrategy stalls in Select until released, ignoring cancellation
+type blockingStrategy struct {
+	picked  chan struct{}
+	release chan struct{}
+}
+
+func (s blockingStrategy) Name() string { return "blocking" }
+
+func (s blockingStrategy) Select(task *Task, candidates []Candidate) int {
+	close(s.picked)
+	<-s.release
+	return 0
+}
+
+// TestShutdownDrainTimeout tests that Shutdown gives up on a stuck worker
+// after the drain timeout and fails the task it was holding
+func TestShutdownDrainTimeout(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+	strategy := blockingStrategy{picked: make(chan struct{}), release: make(chan struct{})}
+	assigner.SetStrategy(strategy)
+	pool := NewAssignmentWorkerPool(assigner, 1, time.Minute)
+	pool.SetDrainTimeout(100 * time.Millisecond)
+
+	store.AddEmployee(&Employee{ID: "emp1", Name: "Alice", Location: Location{Lat: 60.17, Lon: 24.94}, Skills: []string{"delivery"}, IsAvailable: true})
+	task := &Task{ID: "stuck", Location: Location{Lat: 60.17, Lon: 24.94}, RequiredSkill: "delivery"}
+	store.AddTask(task)
+	if err := pool.Start(context.Background()); err != nil {
+		t.Fatalf("Start() unexpected error: %v", err)
+	}
+	if err := pool.SubmitTask(task); err != nil {
+		t.Fatalf("SubmitTask() unexpected error: %v", err)
+	}
+	<-strategy.picked
+
+	start := time.Now()
+	pool.Shutdown()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Shutdown took %v, want about the 100ms drain timeout", elapsed)
+	}
+	got, _ := store.GetTask("stuck")
+	if got.Status != TaskStatusFailed || got.History[len(got.History)-1].Reason != "shutdown drain timed out" {
+		t.Errorf("Expected the stuck task failed by the drain, got %+v", got)
+	}
+
+	// The worker finally wakes up but must not commit the abandoned task
+	close(strategy.release)
+	deadline := time.Now().Add(time.Second)
+	for pool.ActiveWorkers() > 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if status, _ := store.taskStatus("stuck"); status != TaskStatusFailed {
+		t.Errorf("Late worker changed the failed task to %s", status)
+	}
+	if emp, _ := store.GetEmployee("emp1"); emp.ActiveTasks != 0 {
+		t.Errorf("Late worker assigned the failed task: %+v", emp)
+	}
+}
+
+// TestSubmitDuringShutdown tests that submissions racing a shutdown either
+// land or get POOL_SHUTTING_DOWN, never a panic or a misleading QUEUE_FULL
+func TestSubmitDuringShutdown(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+	pool := NewAssignmentWorkerPool(assigner, 2, time.Second)
+	pool.SetQueueSize(10000)
+	if err := pool.Start(context.Background()); err != nil {
+		t.Fatalf("Start() unexpected error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("SubmitTask panicked during shutdown: %v", r)
+				}
+			}()
+			<-start
+			for i := 0; i < 200; i++ {
+				task := &Task{ID: fmt.Sprintf("task-%d-%d", g, i), Location: Location{Lat: 60.17, Lon: 24.94}, RequiredSkill: "delivery"}
+				store.AddTask(task)
+				if err := pool.SubmitTask(task); err != nil && !errors.Is(err, ErrPoolShuttingDown) {
+					t.Errorf("SubmitTask() = %v, want nil or %s", err, ErrPoolShuttingDown.Code)
+					return
+				}
+			}
+		}(g)
+	}
+	close(start)
+	pool.Shutdown()
+	wg.Wait()
+
+	task := &Task{ID: "late", Location: Location{Lat: 60.17, Lon: 24.94}, RequiredSkill: "delivery"}
+	if err := pool.SubmitTask(task); !errors.Is(err, ErrPoolShuttingDown) {
+		t.Errorf("SubmitTask() after Shutdown = %v, want %s", err, ErrPoolShuttingDown.Code)
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import "sort"
+
+// EligibleTasksForEmployee returns snapshots of the pending tasks the employee
+// could be assigned right now under the assigner's rules: matching skill and
+// team, reachable (see reaches), and the employee is available and not reserved
+// This is the reverse of the task->employee lookup done by the assigner
+func (ta *TaskAssigner) EligibleTasksForEmployee(id string) ([]*Task, error) {
+	s := ta.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	emp, exists := s.employees[id]
+	if !exists {
+		return nil, ErrEmployeeNotFound
+	}
+
+	tasks := []*Task{}
+	if !emp.assignableAt(s.clock.Now()) {
+		return tasks, nil
+	}
+	for _, task := range s.tasks {
+		if task.Status != TaskStatusPending || !ta.matchesLocked(task, emp) {
+			continue
+		}
+		if _, ok := ta.reaches(task, emp); !ok {
+			continue
+		}
+		snapshot := task.snapshot()
+		tasks = append(tasks, &snapshot)
+	}
+
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	return tasks, nil
+}
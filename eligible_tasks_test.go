@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestGetEligibleTasksHandler tests the reverse employee->task eligibility count
+func TestGetEligibleTasksHandler(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	here := Location{Lat: 60.1699, Lon: 24.9384}
+	api.store.AddEmployee(&Employee{
+		ID:          "emp1",
+		Name:        "John",
+		Location:    here,
+		Skills:      []string{"delivery"},
+		IsAvailable: true,
+	})
+
+	near := Location{Lat: 60.1700, Lon: 24.9400}
+	far := Location{Lat: 61.4978, Lon: 23.7610} // Tampere, ~160 km away
+	api.store.AddTask(&Task{ID: "task1", Location: near, RequiredSkill: "delivery"})
+	api.store.AddTask(&Task{ID: "task2", Location: far, RequiredSkill: "delivery"})
+	api.store.AddTask(&Task{ID: "task3", Location: far, RequiredSkill: "delivery", MaxDistanceKm: 50})
+	api.store.AddTask(&Task{ID: "task4", Location: near, RequiredSkill: "repair"})
+	api.store.AddTask(&Task{ID: "task5", Location: near, RequiredSkill: "delivery", TeamID: "north"})
+	api.store.AddTask(&Task{ID: "task6", Location: near, RequiredSkill: "delivery"})
+	api.store.HoldTask("task6")
+
+	get := func(path string) (*httptest.ResponseRecorder, EligibleTasksResponse) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		var response struct {
+			Data EligibleTasksResponse `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return w, response.Data
+	}
+
+	w, data := get("/employees/emp1/eligible-tasks")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if data.Count != 2 {
+		t.Errorf("Expected 2 eligible tasks, got %d", data.Count)
+	}
+	if data.Tasks != nil {
+		t.Errorf("Expected no task list by default, got %d tasks", len(data.Tasks))
+	}
+
+	_, data = get("/employees/emp1/eligible-tasks?include_tasks=true")
+	if len(data.Tasks) != 2 || data.Tasks[0].ID != "task1" || data.Tasks[1].ID != "task2" {
+		t.Errorf("Expected [task1 task2], got %+v", data.Tasks)
+	}
+
+	// An employee at capacity can serve nothing right now
+	api.store.mu.Lock()
+	api.store.employees["emp1"].IsAvailable = false
+	api.store.mu.Unlock()
+	if _, data = get("/employees/emp1/eligible-tasks"); data.Count != 0 {
+		t.Errorf("Expected 0 eligible tasks for unavailable employee, got %d", data.Count)
+	}
+
+	if w, _ = get("/employees/missing/eligible-tasks"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown employee, got %d", w.Code)
+	}
+}
+
+// TestEligibleTasksMatchesAssigner tests that strict location and
+// ignore_distance decide eligibility the same way as a real assignment
+func TestEligibleTasksMatchesAssigner(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+	assigner.SetStrictLocation(true)
+
+	helsinki := Location{Lat: 60.1700, Lon: 24.9400}
+	turku := Location{Lat: 60.4518, Lon: 22.2666}
+	store.AddEmployee(&Employee{ID: "no-fix", Name: "Alice", Skills: []string{"delivery"}, IsAvailable: true})
+	store.AddEmployee(&Employee{ID: "short-range", Name: "Bob", Location: helsinki, Skills: []string{"delivery"}, IsAvailable: true, MaxRangeKm: 1})
+	store.AddTask(&Task{ID: "near", Location: helsinki, RequiredSkill: "delivery"})
+	store.AddTask(&Task{ID: "anywhere", Location: turku, RequiredSkill: "delivery", IgnoreDistance: true})
+
+	ids := func(employeeID string) []string {
+		tasks, err := assigner.EligibleTasksForEmployee(employeeID)
+		if err != nil {
+			t.Fatalf("EligibleTasksForEmployee(%s) unexpected error: %v", employeeID, err)
+		}
+		var got []string
+		for _, task := range tasks {
+			got = append(got, task.ID)
+		}
+		return got
+	}
+
+	// No fix: only the task that ignores distance; out of range: the same task is still theirs
+	if got := ids("no-fix"); len(got) != 1 || got[0] != "anywhere" {
+		t.Errorf("no-fix: expected [anywhere], got %v", got)
+	}
+	if got := ids("short-range"); len(got) != 2 {
+		t.Errorf("short-range: expected [anywhere near], got %v", got)
+	}
+
+	assigner.SetIgnoreDistance(true)
+	if got := ids("no-fix"); len(got) != 2 {
+		t.Errorf("no-fix with IGNORE_DISTANCE: expected both tasks, got %v", got)
+	}
+}
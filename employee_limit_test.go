@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestMaxEmployees tests that the employee cap applies to single and batch
+// creation and that soft-deleted employees don't count toward it
+func TestMaxEmployees(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxEmployees = 3
+	api, err := NewAPIWithConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewAPIWithConfig() unexpected error: %v", err)
+	}
+	api.ids = &sequentialIDs{prefix: "emp"}
+	router := api.setupRouter()
+
+	post := func(path string, body any) *httptest.ResponseRecorder {
+		data, _ := json.Marshal(body)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", path, bytes.NewBuffer(data)))
+		return w
+	}
+	employee := func(name string) CreateEmployeeRequest {
+		return CreateEmployeeRequest{Name: name, Location: Location{Lat: 60.1699, Lon: 24.9384}, Skills: []string{"delivery"}}
+	}
+
+	if w := post("/employees", employee("Alice")); w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Two slots left: the invalid entry is skipped, the fourth entry hits the cap
+	w := post("/employees/batch", []CreateEmployeeRequest{employee("Bob"), {Name: "", Location: Location{Lat: 60.1, Lon: 24.9}, Skills: []string{"delivery"}}, employee("Carol"), employee("Dave")})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var batch struct {
+		Data []EmployeeBatchResult `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &batch)
+	wantStatus := []int{http.StatusCreated, http.StatusBadRequest, http.StatusCreated, http.StatusServiceUnavailable}
+	if len(batch.Data) != len(wantStatus) {
+		t.Fatalf("Expected %d results, got %d", len(wantStatus), len(batch.Data))
+	}
+	for i, want := range wantStatus {
+		if batch.Data[i].Status != want {
+			t.Errorf("Entry %d status = %d, want %d", i, batch.Data[i].Status, want)
+		}
+	}
+	if batch.Data[3].Code != ErrEmployeeLimitReached.Code {
+		t.Errorf("Entry 3 code = %q, want %q", batch.Data[3].Code, ErrEmployeeLimitReached.Code)
+	}
+
+	w = post("/employees", employee("Erin"))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected status 503 at the limit, got %d", w.Code)
+	}
+	var errResp ErrorResponse
+	json.Unmarshal(w.Body.Bytes(), &errResp)
+	if errResp.Code != ErrEmployeeLimitReached.Code {
+		t.Errorf("Expected code %s, got %s", ErrEmployeeLimitReached.Code, errResp.Code)
+	}
+
+	// Deleting frees a slot; restoring the deleted employee then doesn't fit
+	if _, err := api.store.DeleteEmployee(batch.Data[0].ID); err != nil {
+		t.Fatalf("DeleteEmployee() unexpected error: %v", err)
+	}
+	if w := post("/employees", employee("Erin")); w.Code != http.StatusCreated {
+		t.Errorf("Expected status 201 after a delete, got %d", w.Code)
+	}
+	if w := post("/employees/"+batch.Data[0].ID+"/restore", nil); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503 restoring past the limit, got %d", w.Code)
+	}
+	if _, err := api.store.RestoreEmployee(batch.Data[0].ID); !errors.Is(err, ErrEmployeeLimitReached) {
+		t.Errorf("Expected ErrEmployeeLimitReached, got %v", err)
+	}
+}
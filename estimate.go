@@ -0,0 +1,120 @@
+package main
+
+import (
+	"math"
+)
+
+// CapacityEstimate reports how many hypothetical tasks could be matched right now
+type CapacityEstimate struct {
+	Requested          int `json:"requested"`
+	Assignable         int `json:"assignable"`
+	Unassignable       int `json:"unassignable"`
+	AvailableEmployees int `json:"available_employees"`
+	AvailableSlots     int `json:"available_slots"`
+}
+
+// EstimateCapacity simulates assigning count tasks spread evenly over box
+// Each simulated assignment runs the assigner's strategy against a private copy of
+// the available employees and consumes one unit of the chosen employee's capacity.
+// Candidates per task pass the same team, skill and reach checks as a real
+// assignment. The store is never mutated
+func (ta *TaskAssigner) EstimateCapacity(skill string, box BoundingBox, count int, maxDistanceKm float64) CapacityEstimate {
+	type simEmployee struct {
+		emp       *Employee
+		candidate Candidate
+		remaining int
+	}
+
+	// One strategy for the whole simulation, even if it is swapped meanwhile
+	strategy := ta.Strategy()
+	task := &Task{RequiredSkill: normalizeSkill(skill), MaxDistanceKm: maxDistanceKm, Priority: PriorityNormal}
+
+	// The simulation reads the live employees, so it runs under the read lock
+	ta.store.mu.RLock()
+	defer ta.store.mu.RUnlock()
+
+	// Snapshot unreserved available employees with the skill and their free capacity
+	now := ta.store.clock.Now()
+	var pool []*simEmployee
+	for _, emp := range ta.store.employees {
+		if !emp.assignableAt(now) || !ta.matchesLocked(task, emp) {
+			continue
+		}
+		remaining := emp.maxActiveTasks() - emp.ActiveTasks
+		if remaining <= 0 {
+			continue
+		}
+		pool = append(pool, &simEmployee{
+			emp: emp,
+			candidate: Candidate{
+				EmployeeID:  emp.ID,
+				Location:    emp.Location,
+				ActiveTasks: emp.ActiveTasks,
+				Tier:        emp.Tier,
+				MaxRangeKm:  emp.MaxRangeKm,
+				Rating:      emp.Rating,
+			},
+			remaining: remaining,
+		})
+	}
+
+	estimate := CapacityEstimate{
+		Requested:          count,
+		AvailableEmployees: len(pool),
+	}
+	for _, e := range pool {
+		estimate.AvailableSlots += e.remaining
+	}
+
+	for _, loc := range spreadLocations(box, count) {
+		task.Location = loc
+
+		var candidates []Candidate
+		var owners []*simEmployee
+		for _, e := range pool {
+			if e.remaining <= 0 {
+				continue
+			}
+			distance, ok := ta.reaches(task, e.emp)
+			if !ok {
+				continue
+			}
+			c := e.candidate
+			c.Distance = distance
+			candidates = append(candidates, c)
+			owners = append(owners, e)
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+
+		chosen := owners[strategy.Select(task, candidates)]
+		chosen.remaining--
+		chosen.candidate.ActiveTasks++
+		estimate.Assignable++
+	}
+
+	estimate.Unassignable = count - estimate.Assignable
+	return estimate
+}
+
+// spreadLocations returns count points laid out on an even grid over the box
+func spreadLocations(box BoundingBox, count int) []Location {
+	if count <= 0 {
+		return nil
+	}
+	cols := int(math.Ceil(math.Sqrt(float64(count))))
+	rows := int(math.Ceil(float64(count) / float64(cols)))
+	latStep := (box.MaxLat - box.MinLat) / float64(rows)
+	lonStep := (box.MaxLon - box.MinLon) / float64(cols)
+
+	locations := make([]Location, 0, count)
+	for i := 0; i < count; i++ {
+		row, col := i/cols, i%cols
+		locations = append(locations, Location{
+			Lat: box.MinLat + latStep*(float64(row)+0.5),
+			Lon: box.MinLon + lonStep*(float64(col)+0.5),
+		})
+	}
+	return locations
+}
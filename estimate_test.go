@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestEstimateCapacityCapsAtAvailableSlots tests the estimate never exceeds free capacity
+func TestEstimateCapacityCapsAtAvailableSlots(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+
+	employees := []*Employee{
+		{ID: "emp1", Location: Location{Lat: 60.17, Lon: 24.94}, Skills: []string{"delivery"}, Capacity: 1, IsAvailable: true},
+		{ID: "emp2", Location: Location{Lat: 60.18, Lon: 24.95}, Skills: []string{"delivery"}, Capacity: 2, IsAvailable: true},
+		{ID: "busy", Location: Location{Lat: 60.18, Lon: 24.95}, Skills: []string{"delivery"}, Capacity: 1, ActiveTasks: 1, IsAvailable: false},
+		{ID: "cook", Location: Location{Lat: 60.18, Lon: 24.95}, Skills: []string{"cooking"}, Capacity: 5, IsAvailable: true},
+	}
+	for _, emp := range employees {
+		store.AddEmployee(emp)
+	}
+
+	box := BoundingBox{MinLat: 60.15, MinLon: 24.90, MaxLat: 60.20, MaxLon: 25.00}
+	estimate := assigner.EstimateCapacity("delivery", box, 50, 0)
+
+	if estimate.Assignable != 3 {
+		t.Errorf("Assignable = %d, want 3", estimate.Assignable)
+	}
+	if estimate.Unassignable != 47 {
+		t.Errorf("Unassignable = %d, want 47", estimate.Unassignable)
+	}
+	if estimate.AvailableEmployees != 2 || estimate.AvailableSlots != 3 {
+		t.Errorf("Expected 2 employees with 3 slots, got %d with %d", estimate.AvailableEmployees, estimate.AvailableSlots)
+	}
+
+	// The simulation must not consume real capacity
+	for _, emp := range employees {
+		if emp.ID == "emp1" && (emp.ActiveTasks != 0 || !emp.IsAvailable) {
+			t.Error("EstimateCapacity() mutated employee state")
+		}
+	}
+}
+
+// TestEstimateCapacityRespectsRadius tests that out-of-range employees don't count
+func TestEstimateCapacityRespectsRadius(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+
+	store.AddEmployee(&Employee{ID: "far", Location: Location{Lat: 61.5, Lon: 23.8}, Skills: []string{"delivery"}, Capacity: 10, IsAvailable: true})
+
+	box := BoundingBox{MinLat: 60.15, MinLon: 24.90, MaxLat: 60.20, MaxLon: 25.00}
+	if estimate := assigner.EstimateCapacity("delivery", box, 5, 10); estimate.Assignable != 0 {
+		t.Errorf("Assignable = %d, want 0 with everyone out of range", estimate.Assignable)
+	}
+	if estimate := assigner.EstimateCapacity("delivery", box, 5, 0); estimate.Assignable != 5 {
+		t.Errorf("Assignable = %d, want 5 without a radius", estimate.Assignable)
+	}
+}
+
+// TestEstimateCapacityMatchesAssigner tests that employees a real assignment
+// would skip (out of their own range, no fix under strict location) don't count
+func TestEstimateCapacityMatchesAssigner(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+	assigner.SetStrictLocation(true)
+
+	store.AddEmployee(&Employee{ID: "near", Location: Location{Lat: 60.17, Lon: 24.95}, Skills: []string{"delivery"}, Capacity: 2, IsAvailable: true})
+	store.AddEmployee(&Employee{ID: "short-range", Location: Location{Lat: 61.5, Lon: 23.8}, Skills: []string{"delivery"}, Capacity: 10, IsAvailable: true, MaxRangeKm: 5})
+	store.AddEmployee(&Employee{ID: "no-fix", Skills: []string{"delivery"}, Capacity: 10, IsAvailable: true})
+
+	box := BoundingBox{MinLat: 60.15, MinLon: 24.90, MaxLat: 60.20, MaxLon: 25.00}
+	if estimate := assigner.EstimateCapacity("delivery", box, 5, 0); estimate.Assignable != 2 {
+		t.Errorf("Assignable = %d, want 2 (only near can reach the box)", estimate.Assignable)
+	}
+}
+
+// TestEstimateCapacityHandler tests request validation on POST /capacity/estimate
+func TestEstimateCapacityHandler(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	api.store.AddEmployee(&Employee{ID: "emp1", Location: Location{Lat: 60.17, Lon: 24.94}, Skills: []string{"delivery"}, Capacity: 1, IsAvailable: true})
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		assignable     int
+	}{
+		{
+			name:           "Valid estimate",
+			body:           `{"skill": "Delivery", "bounding_box": {"min_lat": 60.1, "min_lon": 24.9, "max_lat": 60.2, "max_lon": 25.0}, "count": 4}`,
+			expectedStatus: http.StatusOK,
+			assignable:     1,
+		},
+		{
+			name:           "Inverted box",
+			body:           `{"skill": "delivery", "bounding_box": {"min_lat": 60.2, "min_lon": 24.9, "max_lat": 60.1, "max_lon": 25.0}, "count": 4}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Missing count",
+			body:           `{"skill": "delivery", "bounding_box": {"min_lat": 60.1, "min_lon": 24.9, "max_lat": 60.2, "max_lon": 25.0}}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/capacity/estimate", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Data CapacityEstimate `json:"data"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if response.Data.Assignable != tt.assignable {
+				t.Errorf("Assignable = %d, want %d", response.Data.Assignable, tt.assignable)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MIMENDJSON is the media type for newline-delimited JSON exports
+const MIMENDJSON = "application/x-ndjson"
+
+// TaskIDs returns the IDs of all tasks in order
+func (s *Store) TaskIDs() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := make([]string, 0, len(s.tasks))
+	for id := range s.tasks {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// EmployeeIDs returns the IDs of all live employees in order
+func (s *Store) EmployeeIDs() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := make([]string, 0, len(s.employees))
+	for id := range s.employees {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// taskSnapshot returns a lock-free copy of the task, if it still exists
+func (s *Store) taskSnapshot(id string) (any, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	task, exists := s.tasks[id]
+	if !exists {
+		return nil, false
+	}
+	return task.snapshot(), true
+}
+
+// employeeSnapshot returns a lock-free copy of the employee, if still live
+func (s *Store) employeeSnapshot(id string) (any, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	emp, exists := s.employees[id]
+	if !exists {
+		return nil, false
+	}
+	snapshot := *emp
+	snapshot.Skills = append([]string(nil), emp.Skills...)
+	return snapshot, true
+}
+
+// streamNDJSON writes one JSON object per line for each ID, fetching records
+// one at a time so memory stays flat and the store lock is never held across
+// a write; IDs removed since listing are skipped
+// model and ?fields= trim records the same way as the list endpoints
+func streamNDJSON(c *gin.Context, ids []string, fetch func(string) (any, bool), model any) {
+	fields, err := parseFields(c, model)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid fields parameter",
+			Code:    "INVALID_FIELDS",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Type", MIMENDJSON)
+	c.Status(http.StatusOK)
+	enc := json.NewEncoder(c.Writer)
+	for i, id := range ids {
+		record, ok := fetch(id)
+		if !ok {
+			continue
+		}
+		if fields != nil {
+			if record, err = selectFields(record, fields); err != nil {
+				log.Printf("Failed to export %s: %v", id, err)
+				return
+			}
+		}
+		// The status line is already out, so a failed write can only end the stream
+		if err := enc.Encode(record); err != nil {
+			log.Printf("NDJSON export aborted: %v", err)
+			return
+		}
+		if i%100 == 99 {
+			c.Writer.Flush()
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestExportNDJSON tests that each export line is a complete record, deleted
+// employees are left out and ?fields= trims records like the list endpoints
+func TestExportNDJSON(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	loc := Location{Lat: 60.1699, Lon: 24.9384}
+	for _, id := range []string{"emp1", "emp2", "emp3"} {
+		api.store.AddEmployee(&Employee{ID: id, Name: strings.ToUpper(id), Location: loc, Skills: []string{"delivery"}, IsAvailable: true})
+	}
+	api.store.DeleteEmployee("emp3")
+	for _, id := range []string{"task1", "task2"} {
+		api.store.AddTask(&Task{ID: id, Location: loc, RequiredSkill: "delivery"})
+	}
+
+	get := func(path string) []string {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("GET %s: expected status 200, got %d: %s", path, w.Code, w.Body.String())
+		}
+		if ct := w.Header().Get("Content-Type"); ct != MIMENDJSON {
+			t.Errorf("GET %s: Content-Type = %q, want %q", path, ct, MIMENDJSON)
+		}
+		var lines []string
+		scanner := bufio.NewScanner(w.Body)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		return lines
+	}
+
+	lines := get("/tasks/export.ndjson")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 task lines, got %d", len(lines))
+	}
+	for i, line := range lines {
+		var task Task
+		if err := json.Unmarshal([]byte(line), &task); err != nil {
+			t.Fatalf("Line %d is not a task: %v", i, err)
+		}
+		if task.ID == "" || task.Status != TaskStatusPending || task.RequiredSkill != "delivery" {
+			t.Errorf("Line %d: unexpected task %+v", i, task)
+		}
+	}
+
+	lines = get("/employees/export.ndjson")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 employee lines (deleted excluded), got %d", len(lines))
+	}
+	for i, want := range []string{"emp1", "emp2"} {
+		var emp Employee
+		if err := json.Unmarshal([]byte(lines[i]), &emp); err != nil {
+			t.Fatalf("Line %d is not an employee: %v", i, err)
+		}
+		if emp.ID != want || emp.Name != strings.ToUpper(want) || len(emp.Skills) != 1 {
+			t.Errorf("Line %d: unexpected employee %+v", i, emp)
+		}
+	}
+
+	for _, line := range get("/employees/export.ndjson?fields=id,name") {
+		var obj map[string]any
+		if err := json.Unmarshal([]byte(line), &obj); err != nil || len(obj) != 2 {
+			t.Errorf("Expected only id and name, got %s", line)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/export.ndjson?fields=nope", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an unknown field, got %d", w.Code)
+	}
+}
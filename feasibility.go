@@ -0,0 +1,105 @@
+package main
+
+// AssignmentCheck reports whether an employee could take a task right now,
+// check by check
+type AssignmentCheck struct {
+	TaskID     string `json:"task_id"`
+	EmployeeID string `json:"employee_id"`
+	// Feasible is true when every check below passes
+	Feasible    bool `json:"feasible"`
+	TaskPending bool `json:"task_pending"`
+	HasSkill    bool `json:"has_skill"`
+	// TeamMatches is true when the task has no team or the employee is in it
+	TeamMatches bool `json:"team_matches"`
+	Available   bool `json:"available"`
+	Reserved    bool `json:"reserved"`
+	HasCapacity bool `json:"has_capacity"`
+	// WithinRadius is true when DistanceKm lies within the task's distance bounds
+	WithinRadius bool `json:"within_radius"`
+	// WithinRange is true when DistanceKm is inside the employee's max_range_km
+	WithinRange bool `json:"within_range"`
+	// HasLocation is false when strict location rules out an employee with no fix
+	HasLocation bool    `json:"has_location"`
+	DistanceKm  float64 `json:"distance_km"`
+}
+
+// withinDistanceBounds reports whether an employee distanceKm away satisfies
+// the task's min and max distance
+func (t *Task) withinDistanceBounds(distanceKm float64) bool {
+	if t.MaxDistanceKm > 0 && distanceKm > t.MaxDistanceKm {
+		return false
+	}
+	return distanceKm >= t.MinDistanceKm
+}
+
+// matchesLocked reports whether emp has the task's skill and is in its team
+// (if it has one); caller must hold the store lock
+func (ta *TaskAssigner) matchesLocked(task *Task, emp *Employee) bool {
+	if task.TeamID != "" && emp.TeamID != task.TeamID {
+		return false
+	}
+	return ta.store.skillMatcher.Matches(emp.Skills, task.RequiredSkill)
+}
+
+// reaches reports whether emp is close enough to take the task under the
+// assigner's distance rules, and how far away they are
+// Tasks that ignore distance are reachable by anyone; otherwise the task's
+// distance bounds, the employee's own range and strict location all apply
+func (ta *TaskAssigner) reaches(task *Task, emp *Employee) (float64, bool) {
+	return ta.reachesFrom(task, emp.Location, emp.MaxRangeKm)
+}
+
+// reachesFrom is reaches for someone at loc with their own range of maxRangeKm,
+// for callers holding a Candidate rather than the employee
+func (ta *TaskAssigner) reachesFrom(task *Task, loc Location, maxRangeKm float64) (float64, bool) {
+	ignoreDistance := ta.ignoresDistance(task)
+	if ta.strictLocation && !ignoreDistance && loc.isUnknown() {
+		return 0, false
+	}
+	distance := CalculateDistance(task.Location, loc)
+	if ignoreDistance {
+		return distance, true
+	}
+	return distance, task.withinDistanceBounds(distance) && withinRangeKm(maxRangeKm, distance)
+}
+
+// CanAssign evaluates the assigner's eligibility rules for one employee-task
+// pair without changing anything
+// Feasible comes from the same predicates as a real assignment; the per-rule
+// flags explain which of them failed
+func (ta *TaskAssigner) CanAssign(taskID, employeeID string) (AssignmentCheck, error) {
+	s := ta.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	task, exists := s.tasks[taskID]
+	if !exists {
+		return AssignmentCheck{}, ErrTaskNotFound
+	}
+	emp, exists := s.employees[employeeID]
+	if !exists {
+		return AssignmentCheck{}, ErrEmployeeNotFound
+	}
+
+	now := s.clock.Now()
+	ignoreDistance := ta.ignoresDistance(task)
+	distance := CalculateDistance(task.Location, emp.Location)
+	_, reachable := ta.reaches(task, emp)
+	check := AssignmentCheck{
+		TaskID:       taskID,
+		EmployeeID:   employeeID,
+		TaskPending:  task.Status == TaskStatusPending,
+		HasSkill:     s.skillMatcher.Matches(emp.Skills, task.RequiredSkill),
+		TeamMatches:  task.TeamID == "" || emp.TeamID == task.TeamID,
+		Available:    emp.IsAvailable,
+		Reserved:     emp.isReserved(now),
+		HasCapacity:  emp.ActiveTasks < emp.maxActiveTasks(),
+		WithinRadius: ignoreDistance || task.withinDistanceBounds(distance),
+		WithinRange:  ignoreDistance || emp.withinRange(distance),
+		HasLocation:  ignoreDistance || !ta.strictLocation || !emp.Location.isUnknown(),
+		DistanceKm:   distance,
+	}
+	check.Feasible = check.TaskPending && ta.matchesLocked(task, emp) &&
+		emp.assignableAt(now) && check.HasCapacity && reachable
+	return check, nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestCanAssign tests an eligible pair, a wrong-skill pair and unknown IDs,
+// and that checking assigns nothing
+func TestCanAssign(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	api.store.AddEmployee(&Employee{ID: "driver", Name: "Driver", Location: Location{Lat: 60.1710, Lon: 24.9410}, Skills: []string{"delivery"}, IsAvailable: true})
+	api.store.AddEmployee(&Employee{ID: "welder", Name: "Welder", Location: Location{Lat: 60.1710, Lon: 24.9410}, Skills: []string{"welding"}, IsAvailable: true})
+	api.store.AddTask(&Task{ID: "task1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery", MaxDistanceKm: 5})
+
+	get := func(path string) (int, AssignmentCheck) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		var response struct {
+			Data AssignmentCheck `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return w.Code, response.Data
+	}
+
+	code, check := get("/tasks/task1/can-assign/driver")
+	if code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", code)
+	}
+	if !check.Feasible || !check.HasSkill || !check.WithinRadius || !check.HasCapacity || check.DistanceKm <= 0 || check.DistanceKm > 1 {
+		t.Errorf("Expected a feasible pair, got %+v", check)
+	}
+
+	code, check = get("/tasks/task1/can-assign/welder")
+	if code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", code)
+	}
+	if check.Feasible || check.HasSkill || !check.Available || !check.WithinRadius {
+		t.Errorf("Expected only the skill check to fail, got %+v", check)
+	}
+
+	for path, want := range map[string]string{
+		"/tasks/nope/can-assign/driver":  ErrTaskNotFound.Code,
+		"/tasks/task1/can-assign/nobody": ErrEmployeeNotFound.Code,
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		var errResp ErrorResponse
+		json.Unmarshal(w.Body.Bytes(), &errResp)
+		if w.Code != http.StatusNotFound || errResp.Code != want {
+			t.Errorf("GET %s = %d %s, want 404 %s", path, w.Code, errResp.Code, want)
+		}
+	}
+
+	if task, _ := api.store.GetTask("task1"); task.Status != TaskStatusPending {
+		t.Errorf("Checking changed the task status to %s", task.Status)
+	}
+	if emp, _ := api.store.GetEmployee("driver"); emp.ActiveTasks != 0 {
+		t.Errorf("Checking changed the employee's load to %d", emp.ActiveTasks)
+	}
+}
+
+// TestCanAssignMatchesAssigner tests that the check follows the assigner's
+// strict-location and global ignore-distance settings
+func TestCanAssignMatchesAssigner(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+	assigner.SetStrictLocation(true)
+
+	helsinki := Location{Lat: 60.1700, Lon: 24.9400}
+	store.AddEmployee(&Employee{ID: "no-fix", Name: "Alice", Skills: []string{"delivery"}, IsAvailable: true})
+	store.AddEmployee(&Employee{ID: "short-range", Name: "Bob", Location: Location{Lat: 60.4518, Lon: 22.2666}, Skills: []string{"delivery"}, IsAvailable: true, MaxRangeKm: 1})
+	store.AddTask(&Task{ID: "task1", Location: helsinki, RequiredSkill: "delivery"})
+
+	if check, _ := assigner.CanAssign("task1", "no-fix"); check.Feasible || check.HasLocation {
+		t.Errorf("Expected an employee with no fix to be infeasible under strict location, got %+v", check)
+	}
+	if check, _ := assigner.CanAssign("task1", "short-range"); check.Feasible || check.WithinRange {
+		t.Errorf("Expected an out-of-range employee to be infeasible, got %+v", check)
+	}
+
+	assigner.SetIgnoreDistance(true)
+	for _, id := range []string{"no-fix", "short-range"} {
+		if check, _ := assigner.CanAssign("task1", id); !check.Feasible {
+			t.Errorf("%s: expected feasible with distance ignored, got %+v", id, check)
+		}
+	}
+}
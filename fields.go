@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// jsonFieldNames returns the JSON keys a struct type marshals to
+// Embedded structs are flattened the way encoding/json flattens them
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			for embedded := range jsonFieldNames(field.Type) {
+				names[embedded] = true
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = true
+	}
+	return names
+}
+
+// parseFields reads the comma-separated ?fields= parameter and checks each
+// name against model's JSON keys; nil means no trimming was requested
+func parseFields(c *gin.Context, model any) ([]string, error) {
+	raw := strings.TrimSpace(c.Query("fields"))
+	if raw == "" {
+		return nil, nil
+	}
+
+	known := jsonFieldNames(reflect.TypeOf(model))
+	var fields []string
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !known[name] {
+			valid := make([]string, 0, len(known))
+			for k := range known {
+				valid = append(valid, k)
+			}
+			sort.Strings(valid)
+			return nil, fmt.Errorf("unknown field %q (available: %s)", name, strings.Join(valid, ", "))
+		}
+		fields = append(fields, name)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("fields must name at least one field")
+	}
+	return fields, nil
+}
+
+// selectFields re-marshals data (an object or a list of objects) keeping only
+// the given top-level keys; values are passed through untouched
+func selectFields(data any, fields []string) (any, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	keep := func(obj map[string]json.RawMessage) map[string]json.RawMessage {
+		trimmed := make(map[string]json.RawMessage, len(fields))
+		for _, name := range fields {
+			if value, ok := obj[name]; ok {
+				trimmed[name] = value
+			}
+		}
+		return trimmed
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(encoded), []byte("[")) {
+		var list []map[string]json.RawMessage
+		if err := json.Unmarshal(encoded, &list); err != nil {
+			return nil, err
+		}
+		trimmed := make([]map[string]json.RawMessage, len(list))
+		for i, obj := range list {
+			trimmed[i] = keep(obj)
+		}
+		return trimmed, nil
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &obj); err != nil {
+		return nil, err
+	}
+	return keep(obj), nil
+}
+
+// respondSparse writes resp with respond, unless ?fields= is set: then
+// resp.Data is trimmed to those fields (validated against model) and always
+// written as JSON. Unknown field names return 400 INVALID_FIELDS
+func respondSparse(c *gin.Context, status int, resp SuccessResponse, model any, respond func(*gin.Context, int, SuccessResponse)) {
+	fields, err := parseFields(c, model)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid fields parameter",
+			Code:    "INVALID_FIELDS",
+			Message: err.Error(),
+		})
+		return
+	}
+	if fields == nil {
+		respond(c, status, resp)
+		return
+	}
+
+	trimmed, err := selectFields(resp.Data, fields)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to encode response",
+			Message: err.Error(),
+		})
+		return
+	}
+	resp.Data = trimmed
+	respondSuccess(c, status, resp)
+}
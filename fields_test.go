@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestSparseFieldsets tests that ?fields= trims task and employee responses to the requested keys
+func TestSparseFieldsets(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	api.store.AddEmployee(&Employee{ID: "emp1", Name: "Alice", Location: Location{Lat: 60.1699, Lon: 24.9384}, Skills: []string{"delivery"}, IsAvailable: true})
+	api.store.AddTask(&Task{ID: "task1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"})
+
+	tests := []struct {
+		name     string
+		path     string
+		wantKeys []string
+	}{
+		{"Task list", "/tasks?fields=id,status", []string{"id", "status"}},
+		{"Single task", "/tasks/task1?fields=id,location", []string{"id", "location"}},
+		{"Employee list", "/employees?fields=id,%20name", []string{"id", "name"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+
+			var response struct {
+				Data json.RawMessage `json:"data"`
+			}
+			json.Unmarshal(w.Body.Bytes(), &response)
+
+			var objects []map[string]any
+			if err := json.Unmarshal(response.Data, &objects); err != nil {
+				var obj map[string]any
+				if err := json.Unmarshal(response.Data, &obj); err != nil {
+					t.Fatalf("Failed to parse data: %v", err)
+				}
+				objects = []map[string]any{obj}
+			}
+			if len(objects) != 1 {
+				t.Fatalf("Expected 1 object, got %d", len(objects))
+			}
+			if len(objects[0]) != len(tt.wantKeys) {
+				t.Errorf("Expected keys %v, got %v", tt.wantKeys, objects[0])
+			}
+			for _, key := range tt.wantKeys {
+				if _, ok := objects[0][key]; !ok {
+					t.Errorf("Expected key %q in %v", key, objects[0])
+				}
+			}
+		})
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks?fields=id,secret", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for an unknown field, got %d", w.Code)
+	}
+	var errResp ErrorResponse
+	json.Unmarshal(w.Body.Bytes(), &errResp)
+	if errResp.Code != "INVALID_FIELDS" {
+		t.Errorf("Expected INVALID_FIELDS, got %s", errResp.Code)
+	}
+}
@@ -0,0 +1,16 @@
+package main
+
+// SetStrictFIFO makes the pool commit assignments in submission order,
+// ignoring priority and aging. Workers still run concurrently, but each
+// pop-and-assign step holds a pool-wide lock, so throughput drops to that of
+// a single worker; extra workers only help absorb per-task timeouts
+// With a spillover queue, new tasks join the spillover queue while it holds
+// anything so they cannot overtake older overflow work
+// Must be called before Start
+func (pool *AssignmentWorkerPool) SetStrictFIFO(enabled bool) {
+	pool.strictFIFO = enabled
+	pool.taskQueue.SetFIFO(enabled)
+	if pool.spillover != nil {
+		pool.spillover.SetFIFO(enabled)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// tickingClock advances by one nanosecond on every reading, so timestamps
+// taken under the store lock record the order events happened in
+type tickingClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *tickingClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(time.Nanosecond)
+	return c.now
+}
+
+// TestStrictFIFOCommitOrder tests that strict FIFO commits follow submission
+// order across several workers, regardless of task priority
+func TestStrictFIFOCommitOrder(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+	assigner.SetClock(&tickingClock{now: time.Date(2026, 1, 31, 12, 0, 0, 0, time.UTC)})
+	pool := NewAssignmentWorkerPool(assigner, 4, 5*time.Second)
+	pool.SetStrictFIFO(true)
+
+	store.AddEmployee(&Employee{ID: "emp1", Name: "Alice", Location: Location{Lat: 60.1699, Lon: 24.9384}, Skills: []string{"delivery"}, IsAvailable: true, Capacity: 100})
+
+	const n = 40
+	var ids []string
+	for i := 0; i < n; i++ {
+		task := &Task{
+			ID:            fmt.Sprintf("task%02d", i),
+			Location:      Location{Lat: 60.1700, Lon: 24.9400},
+			RequiredSkill: "delivery",
+			// Mixed priorities would reorder a normal queue
+			Priority: PriorityLow + i%4,
+		}
+		store.AddTask(task)
+		if err := pool.SubmitTask(task); err != nil {
+			t.Fatalf("SubmitTask(%s) unexpected error: %v", task.ID, err)
+		}
+		ids = append(ids, task.ID)
+	}
+
+	if err := pool.Start(context.Background()); err != nil {
+		t.Fatalf("Start() unexpected error: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for pool.taskQueue.Len() > 0 || pool.IsQueued(ids[n-1]) {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the queue to drain")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	pool.Shutdown()
+
+	log := store.AssignmentLog()
+	if len(log) != n {
+		t.Fatalf("Expected %d assignments, got %d", n, len(log))
+	}
+	committed := make([]string, len(log))
+	for i, entry := range log {
+		committed[i] = entry.TaskID
+	}
+	if !sort.StringsAreSorted(committed) {
+		t.Errorf("Commit order %v does not match submission order", committed)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ServiceArea is a named region given either as a bounding box or a polygon
+type ServiceArea struct {
+	Name        string       `json:"name"`
+	BoundingBox *BoundingBox `json:"bounding_box,omitempty"`
+	Polygon     []Location   `json:"polygon,omitempty"`
+}
+
+// Validate checks the area has exactly one valid shape
+func (a ServiceArea) Validate() error {
+	if (a.BoundingBox == nil) == (len(a.Polygon) == 0) {
+		return errors.New("exactly one of bounding_box or polygon must be set")
+	}
+	if a.BoundingBox != nil {
+		return a.BoundingBox.Validate()
+	}
+	if len(a.Polygon) < 3 {
+		return fmt.Errorf("polygon needs at least 3 vertices, got %d", len(a.Polygon))
+	}
+	for i, vertex := range a.Polygon {
+		if err := vertex.Validate(); err != nil {
+			return fmt.Errorf("invalid polygon vertex %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Contains reports whether the location lies inside the area
+func (a ServiceArea) Contains(l Location) bool {
+	if a.BoundingBox != nil {
+		return a.BoundingBox.Contains(l)
+	}
+	return pointInPolygon(l, a.Polygon)
+}
+
+// ServiceAreas is a set of areas; an empty set places no restriction
+type ServiceAreas []ServiceArea
+
+// Contains reports whether the location falls inside any area
+func (areas ServiceAreas) Contains(l Location) bool {
+	if len(areas) == 0 {
+		return true
+	}
+	for _, area := range areas {
+		if area.Contains(l) {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadServiceAreas reads a JSON array of service areas from path
+func LoadServiceAreas(path string) (ServiceAreas, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var areas ServiceAreas
+	if err := json.Unmarshal(data, &areas); err != nil {
+		return nil, fmt.Errorf("parse service areas: %w", err)
+	}
+	for i, area := range areas {
+		if err := area.Validate(); err != nil {
+			return nil, fmt.Errorf("service area %d (%s): %w", i, area.Name, err)
+		}
+	}
+	return areas, nil
+}
+
+// SkillZones restricts skills to geofences: a task requiring a mapped skill is
+// only allowed inside one of its areas; unmapped skills are unrestricted
+type SkillZones map[string]ServiceAreas
+
+// Allows reports whether the skill may be requested at the location
+func (zones SkillZones) Allows(skill string, l Location) bool {
+	areas, restricted := zones[normalizeSkill(skill)]
+	return !restricted || areas.Contains(l)
+}
+
+// LoadSkillZones reads a JSON object mapping skill names to arrays of areas
+func LoadSkillZones(path string) (SkillZones, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]ServiceAreas
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse skill zones: %w", err)
+	}
+	zones := make(SkillZones, len(raw))
+	for skill, areas := range raw {
+		// An empty list would read as "unrestricted", the opposite of the intent
+		if len(areas) == 0 {
+			return nil, fmt.Errorf("skill %q: at least one area is required", skill)
+		}
+		for i, area := range areas {
+			if err := area.Validate(); err != nil {
+				return nil, fmt.Errorf("skill %q area %d (%s): %w", skill, i, area.Name, err)
+			}
+		}
+		zones[normalizeSkill(skill)] = areas
+	}
+	return zones, nil
+}
+
+// pointInPolygon uses ray casting on lon/lat treated as planar coordinates
+// Accurate for city- and region-sized polygons that don't cross the antimeridian
+func pointInPolygon(l Location, polygon []Location) bool {
+	inside := false
+	j := len(polygon) - 1
+	for i := 0; i < len(polygon); i++ {
+		a, b := polygon[i], polygon[j]
+		if (a.Lat > l.Lat) != (b.Lat > l.Lat) {
+			crossLon := a.Lon + (l.Lat-a.Lat)*(b.Lon-a.Lon)/(b.Lat-a.Lat)
+			if l.Lon < crossLon {
+				inside = !inside
+			}
+		}
+		j = i
+	}
+	return inside
+}
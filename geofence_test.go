@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// helsinkiPolygon is a rough quadrilateral around central Helsinki
+var helsinkiPolygon = []Location{
+	{Lat: 60.15, Lon: 24.90},
+	{Lat: 60.15, Lon: 25.00},
+	{Lat: 60.20, Lon: 25.00},
+	{Lat: 60.20, Lon: 24.90},
+}
+
+// TestServiceAreaContains tests point-in-bbox and point-in-polygon checks
+func TestServiceAreaContains(t *testing.T) {
+	bbox := ServiceArea{Name: "espoo", BoundingBox: &BoundingBox{MinLat: 60.15, MinLon: 24.60, MaxLat: 60.25, MaxLon: 24.75}}
+	// Triangle with its hypotenuse cutting the square diagonally
+	triangle := ServiceArea{Name: "triangle", Polygon: []Location{
+		{Lat: 60.10, Lon: 24.90},
+		{Lat: 60.10, Lon: 25.00},
+		{Lat: 60.20, Lon: 24.90},
+	}}
+
+	tests := []struct {
+		name     string
+		area     ServiceArea
+		location Location
+		want     bool
+	}{
+		{"Inside bbox", bbox, Location{Lat: 60.2055, Lon: 24.6559}, true},
+		{"Outside bbox", bbox, Location{Lat: 60.1699, Lon: 24.9384}, false},
+		{"Inside polygon", ServiceArea{Polygon: helsinkiPolygon}, Location{Lat: 60.1699, Lon: 24.9384}, true},
+		{"Outside polygon", ServiceArea{Polygon: helsinkiPolygon}, Location{Lat: 60.2055, Lon: 24.6559}, false},
+		{"Inside triangle", triangle, Location{Lat: 60.12, Lon: 24.92}, true},
+		{"Outside triangle hypotenuse", triangle, Location{Lat: 60.18, Lon: 24.98}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.area.Contains(tt.location); got != tt.want {
+				t.Errorf("Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if !(ServiceAreas{}).Contains(Location{Lat: -33.87, Lon: 151.21}) {
+		t.Error("Empty service areas should not restrict locations")
+	}
+}
+
+// TestLoadServiceAreas tests loading and validating the service-area file
+func TestLoadServiceAreas(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "areas.json")
+	os.WriteFile(valid, []byte(`[
+		{"name": "helsinki", "polygon": [{"lat": 60.15, "lon": 24.90}, {"lat": 60.15, "lon": 25.00}, {"lat": 60.20, "lon": 25.00}]},
+		{"name": "espoo", "bounding_box": {"min_lat": 60.15, "min_lon": 24.60, "max_lat": 60.25, "max_lon": 24.75}}
+	]`), 0o600)
+	areas, err := LoadServiceAreas(valid)
+	if err != nil {
+		t.Fatalf("LoadServiceAreas() unexpected error: %v", err)
+	}
+	if len(areas) != 2 {
+		t.Errorf("Expected 2 areas, got %d", len(areas))
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	os.WriteFile(invalid, []byte(`[{"name": "line", "polygon": [{"lat": 60.15, "lon": 24.90}, {"lat": 60.20, "lon": 25.00}]}]`), 0o600)
+	if _, err := LoadServiceAreas(invalid); err == nil {
+		t.Error("Expected error for a polygon with fewer than 3 vertices")
+	}
+}
+
+// TestCreateTaskOutOfServiceArea tests that POST /tasks rejects locations outside all areas
+func TestCreateTaskOutOfServiceArea(t *testing.T) {
+	api := setupTestAPI()
+	api.serviceAreas = ServiceAreas{{Name: "helsinki", Polygon: helsinkiPolygon}}
+	router := api.setupRouter()
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{"In area", `{"location": {"lat": 60.1700, "lon": 24.9400}, "required_skill": "delivery"}`, http.StatusCreated},
+		{"Out of area", `{"location": {"lat": 60.2055, "lon": 24.6559}, "required_skill": "delivery"}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/tasks", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if tt.expectedStatus == http.StatusBadRequest {
+				var response ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &response)
+				if response.Code != "OUT_OF_SERVICE_AREA" {
+					t.Errorf("Expected OUT_OF_SERVICE_AREA, got %s", response.Code)
+				}
+			}
+		})
+	}
+
+	if tasks := api.store.GetAllTasks(); len(tasks) != 1 {
+		t.Errorf("Expected only the in-area task to be stored, got %d", len(tasks))
+	}
+}
+
+// TestCreateTaskSkillZones tests that restricted skills are only accepted inside their zones
+func TestCreateTaskSkillZones(t *testing.T) {
+	api := setupTestAPI()
+	api.skillZones = SkillZones{"alcohol_delivery": {{Name: "licensed", Polygon: helsinkiPolygon}}}
+	router := api.setupRouter()
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{"Restricted skill in zone", `{"location": {"lat": 60.1700, "lon": 24.9400}, "required_skill": "alcohol_delivery"}`, http.StatusCreated},
+		{"Restricted skill out of zone", `{"location": {"lat": 60.2055, "lon": 24.6559}, "required_skill": "Alcohol_Delivery"}`, http.StatusBadRequest},
+		{"Unmapped skill anywhere", `{"location": {"lat": 60.2055, "lon": 24.6559}, "required_skill": "delivery"}`, http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/tasks", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if tt.expectedStatus == http.StatusBadRequest {
+				var response ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &response)
+				if response.Code != "SKILL_NOT_ALLOWED_HERE" {
+					t.Errorf("Expected SKILL_NOT_ALLOWED_HERE, got %s", response.Code)
+				}
+			}
+		})
+	}
+}
+
+// TestLoadSkillZones tests loading the skill-to-geofence mapping
+func TestLoadSkillZones(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "zones.json")
+	os.WriteFile(valid, []byte(`{"Alcohol_Delivery": [
+		{"name": "licensed", "bounding_box": {"min_lat": 60.15, "min_lon": 24.90, "max_lat": 60.20, "max_lon": 25.00}}
+	]}`), 0o600)
+	zones, err := LoadSkillZones(valid)
+	if err != nil {
+		t.Fatalf("LoadSkillZones() unexpected error: %v", err)
+	}
+	if !zones.Allows("alcohol_delivery", Location{Lat: 60.17, Lon: 24.94}) {
+		t.Error("Expected skill to be allowed inside its zone")
+	}
+	if zones.Allows("alcohol_delivery", Location{Lat: 60.2055, Lon: 24.6559}) {
+		t.Error("Expected skill to be rejected outside its zone")
+	}
+
+	empty := filepath.Join(dir, "empty.json")
+	os.WriteFile(empty, []byte(`{"alcohol_delivery": []}`), 0o600)
+	if _, err := LoadSkillZones(empty); err == nil {
+		t.Error("Expected error for a skill with no areas")
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GraphNode kinds
+const (
+	GraphNodeEmployee = "employee"
+	GraphNodeTask     = "task"
+)
+
+// GraphNode is an employee or task in the assignment graph
+type GraphNode struct {
+	ID       string   `json:"id"`
+	Kind     string   `json:"kind"`
+	Label    string   `json:"label"`
+	Location Location `json:"location"`
+}
+
+// GraphEdge links an employee to a task they are assigned
+type GraphEdge struct {
+	EmployeeID string `json:"employee_id"`
+	TaskID     string `json:"task_id"`
+	// DistanceKm is the distance the assignment was made at
+	DistanceKm float64 `json:"distance_km"`
+}
+
+// AssignmentGraph is the current employee→task assignments for visualization
+type AssignmentGraph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// AssignmentGraph returns every live employee and assigned task as nodes,
+// with an edge per assignment; employees come first, each group ordered by
+// ID, and edges are ordered by employee then task
+func (s *Store) AssignmentGraph() AssignmentGraph {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	graph := AssignmentGraph{Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+	for _, emp := range s.employees {
+		graph.Nodes = append(graph.Nodes, GraphNode{ID: emp.ID, Kind: GraphNodeEmployee, Label: emp.Name, Location: emp.Location})
+	}
+	var tasks []GraphNode
+	for _, task := range s.tasks {
+		if task.Status != TaskStatusAssigned || task.AssignedEmployeeID == "" {
+			continue
+		}
+		tasks = append(tasks, GraphNode{ID: task.ID, Kind: GraphNodeTask, Label: task.RequiredSkill, Location: task.Location})
+		graph.Edges = append(graph.Edges, GraphEdge{EmployeeID: task.AssignedEmployeeID, TaskID: task.ID, DistanceKm: task.AssignedDistanceKm})
+	}
+
+	sort.Slice(graph.Nodes, func(i, j int) bool { return graph.Nodes[i].ID < graph.Nodes[j].ID })
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	graph.Nodes = append(graph.Nodes, tasks...)
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		a, b := graph.Edges[i], graph.Edges[j]
+		if a.EmployeeID != b.EmployeeID {
+			return a.EmployeeID < b.EmployeeID
+		}
+		return a.TaskID < b.TaskID
+	})
+	return graph
+}
+
+// DOT renders the graph in Graphviz DOT, with employees as boxes, tasks as
+// ellipses and edges labelled with their distance in km
+// Employee and task IDs may collide, so node names are prefixed by kind
+func (g AssignmentGraph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph assignments {\n")
+	for _, node := range g.Nodes {
+		shape := "ellipse"
+		if node.Kind == GraphNodeEmployee {
+			shape = "box"
+		}
+		fmt.Fprintf(&b, "  %s [label=%s, shape=%s];\n",
+			dotQuote(node.Kind+":"+node.ID), dotQuote(node.ID+"\n"+node.Label), shape)
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "  %s -> %s [label=%s];\n",
+			dotQuote(GraphNodeEmployee+":"+edge.EmployeeID), dotQuote(GraphNodeTask+":"+edge.TaskID),
+			dotQuote(fmt.Sprintf("%.2f km", edge.DistanceKm)))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotQuote makes s a DOT quoted string; newlines become DOT line breaks
+func dotQuote(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+	return `"` + s + `"`
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestAssignmentGraph tests that the graph has one edge per assignment with
+// its distance, in both JSON and DOT
+func TestAssignmentGraph(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	helsinki := Location{Lat: 60.1699, Lon: 24.9384}
+	turku := Location{Lat: 60.4518, Lon: 22.2666}
+	api.store.AddEmployee(&Employee{ID: "hel", Name: "Helsinki", Location: helsinki, Skills: []string{"delivery"}, IsAvailable: true})
+	api.store.AddEmployee(&Employee{ID: "tku", Name: "Turku", Location: turku, Skills: []string{"delivery"}, IsAvailable: true})
+	api.store.AddEmployee(&Employee{ID: "idle", Name: "Idle", Location: helsinki, Skills: []string{"welding"}, IsAvailable: true})
+
+	taskLocations := map[string]Location{"task1": {Lat: 60.1700, Lon: 24.9400}, "task2": {Lat: 60.4500, Lon: 22.2700}}
+	for _, id := range []string{"task1", "task2"} {
+		task := &Task{ID: id, Location: taskLocations[id], RequiredSkill: "delivery"}
+		api.store.AddTask(task)
+		if _, err := api.assigner.AssignTask(context.Background(), task); err != nil {
+			t.Fatalf("AssignTask(%s) unexpected error: %v", id, err)
+		}
+	}
+	// Pending tasks are not part of the graph
+	api.store.AddTask(&Task{ID: "task3", Location: helsinki, RequiredSkill: "plumbing"})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/graph", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response struct {
+		Data AssignmentGraph `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	graph := response.Data
+
+	var ids []string
+	for _, node := range graph.Nodes {
+		ids = append(ids, node.Kind+":"+node.ID)
+	}
+	if got, want := strings.Join(ids, ","), "employee:hel,employee:idle,employee:tku,task:task1,task:task2"; got != want {
+		t.Errorf("Nodes = %s, want %s", got, want)
+	}
+
+	want := []GraphEdge{
+		{EmployeeID: "hel", TaskID: "task1", DistanceKm: CalculateDistance(helsinki, taskLocations["task1"])},
+		{EmployeeID: "tku", TaskID: "task2", DistanceKm: CalculateDistance(turku, taskLocations["task2"])},
+	}
+	if len(graph.Edges) != len(want) {
+		t.Fatalf("Expected %d edges, got %+v", len(want), graph.Edges)
+	}
+	for i, edge := range graph.Edges {
+		if edge.EmployeeID != want[i].EmployeeID || edge.TaskID != want[i].TaskID || math.Abs(edge.DistanceKm-want[i].DistanceKm) > 1e-9 {
+			t.Errorf("Edge %d = %+v, want %+v", i, edge, want[i])
+		}
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/graph?format=dot", nil))
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/vnd.graphviz") {
+		t.Fatalf("Expected a 200 DOT response, got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	dot := w.Body.String()
+	for _, edge := range want {
+		line := fmt.Sprintf(`"employee:%s" -> "task:%s" [label="%.2f km"];`, edge.EmployeeID, edge.TaskID, edge.DistanceKm)
+		if !strings.Contains(dot, line) {
+			t.Errorf("DOT output missing %s:\n%s", line, dot)
+		}
+	}
+	if !strings.HasPrefix(dot, "digraph assignments {") || strings.Contains(dot, "task3") {
+		t.Errorf("Unexpected DOT output:\n%s", dot)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/graph?format=svg", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an unknown format, got %d", w.Code)
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import "time"
+
+// MaxTaskHistory caps the number of status events kept per task
+const MaxTaskHistory = 50
+
+// TaskEvent is one status transition in a task's history
+type TaskEvent struct {
+	Status     TaskStatus `json:"status"`
+	At         time.Time  `json:"at"`
+	EmployeeID string     `json:"employee_id,omitempty"`
+	Reason     string     `json:"reason,omitempty"`
+}
+
+// SetClock sets the clock used to timestamp task history and expire reservations
+// Must be called before the store is used concurrently
+func (s *Store) SetClock(clock Clock) {
+	s.clock = clock
+}
+
+// recordTaskEventLocked appends to the task's history, dropping the oldest
+// events beyond MaxTaskHistory; caller must hold s.mu for writing
+func (s *Store) recordTaskEventLocked(task *Task, reason string) {
+	event := TaskEvent{
+		Status:     task.Status,
+		At:         s.clock.Now().UTC(),
+		EmployeeID: task.AssignedEmployeeID,
+		Reason:     reason,
+	}
+	// Always build a new slice so snapshots sharing the old one never see writes
+	history := make([]TaskEvent, 0, min(len(task.History)+1, MaxTaskHistory))
+	if len(task.History) >= MaxTaskHistory {
+		history = append(history, task.History[len(task.History)-MaxTaskHistory+1:]...)
+	} else {
+		history = append(history, task.History...)
+	}
+	task.History = append(history, event)
+}
+
+// transitionTaskLocked moves a task to a new status, recording the reason in
+// its history and waking waiters; caller must hold s.mu for writing
+func (s *Store) transitionTaskLocked(id string, status TaskStatus, employeeID, reason string) {
+	task, exists := s.tasks[id]
+	if !exists {
+		return
+	}
+	task.Status = status
+	task.AssignedEmployeeID = employeeID
+	if employeeID == "" {
+		task.AssignedAt = nil
+		task.AssignedDistanceKm = 0
+		task.Rationale = nil
+	}
+	s.recordTaskEventLocked(task, reason)
+	s.wakeTaskWaitersLocked(id)
+
+	switch status {
+	case TaskStatusAssigned:
+		s.counters.Assigned++
+	case TaskStatusFailed:
+		s.counters.Failed++
+	}
+}
+
+// snapshot returns a copy of the task that is safe to read without the store lock
+func (t *Task) snapshot() Task {
+	copied := *t
+	copied.History = append([]TaskEvent(nil), t.History...)
+	return copied
+}
@@ -0,0 +1,33 @@
+package main
+
+// HoldTask parks a pending task so workers skip it
+func (s *Store) HoldTask(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, exists := s.tasks[id]
+	if !exists {
+		return ErrTaskNotFound
+	}
+	if task.Status != TaskStatusPending {
+		return ErrTaskNotPending
+	}
+	s.setTaskStatusLocked(id, TaskStatusHeld, "")
+	return nil
+}
+
+// ReleaseTask returns a held task to pending; the caller re-queues it
+func (s *Store) ReleaseTask(id string) (*Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, exists := s.tasks[id]
+	if !exists {
+		return nil, ErrTaskNotFound
+	}
+	if task.Status != TaskStatusHeld {
+		return nil, ErrTaskNotHeld
+	}
+	s.setTaskStatusLocked(id, TaskStatusPending, "")
+	return task, nil
+}
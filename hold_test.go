@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestHoldReleaseRoundTrip tests hold, release and re-assignment through the API
+func TestHoldReleaseRoundTrip(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	api.store.AddEmployee(&Employee{
+		ID:          "emp1",
+		Name:        "John",
+		Location:    Location{Lat: 60.1699, Lon: 24.9384},
+		Skills:      []string{"delivery"},
+		IsAvailable: true,
+	})
+	api.store.AddTask(&Task{ID: "task1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"})
+
+	post := func(path string) int {
+		req := httptest.NewRequest("POST", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := post("/tasks/task1/hold"); code != http.StatusOK {
+		t.Fatalf("Hold expected status 200, got %d", code)
+	}
+	if code := post("/tasks/task1/hold"); code != http.StatusConflict {
+		t.Errorf("Holding a held task expected status 409, got %d", code)
+	}
+	if code := post("/tasks/missing/hold"); code != http.StatusNotFound {
+		t.Errorf("Holding an unknown task expected status 404, got %d", code)
+	}
+
+	api.workerPool.Start(context.Background())
+	defer api.workerPool.Shutdown()
+
+	if code := post("/tasks/task1/release"); code != http.StatusOK {
+		t.Fatalf("Release expected status 200, got %d", code)
+	}
+	if code := post("/tasks/task1/release"); code != http.StatusConflict {
+		t.Errorf("Releasing a non-held task expected status 409, got %d", code)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	task, completed, _ := api.store.WaitForTerminal(ctx, "task1")
+	if !completed || task.Status != TaskStatusAssigned {
+		t.Errorf("Expected released task to be assigned, got %s", task.Status)
+	}
+}
+
+// TestWorkerSkipsHeldTask tests that a queued task held before processing is not assigned
+func TestWorkerSkipsHeldTask(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+	pool := NewAssignmentWorkerPool(assigner, 1, 5*time.Second)
+
+	store.AddEmployee(&Employee{
+		ID:          "emp1",
+		Name:        "John",
+		Location:    Location{Lat: 60.1699, Lon: 24.9384},
+		Skills:      []string{"delivery"},
+		IsAvailable: true,
+	})
+	task := &Task{ID: "task1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"}
+	store.AddTask(task)
+
+	// Queue the task, then hold it before any worker runs
+	if err := pool.SubmitTask(task); err != nil {
+		t.Fatalf("SubmitTask() unexpected error: %v", err)
+	}
+	if err := store.HoldTask("task1"); err != nil {
+		t.Fatalf("HoldTask() unexpected error: %v", err)
+	}
+
+	pool.Start(context.Background())
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) && pool.IsQueued("task1") {
+		time.Sleep(time.Millisecond)
+	}
+	pool.Shutdown()
+
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	if status := store.tasks["task1"].Status; status != TaskStatusHeld {
+		t.Errorf("Expected task to stay held, got %s", status)
+	}
+	if !store.employees["emp1"].IsAvailable {
+		t.Error("Expected employee to remain available")
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+// PreAssignHook is consulted on the chosen candidate just before an
+// assignment commits; a non-nil error vetoes that candidate and the next-best
+// one is tried instead
+// It receives copies and runs with the store lock held, so it must be quick
+// and must not call back into the store
+type PreAssignHook func(task *Task, candidate *Employee) error
+
+// SetPreAssignHook installs a hook that can veto proposed assignments (nil removes it)
+// Must be called before the assigner is used concurrently
+func (ta *TaskAssigner) SetPreAssignHook(hook PreAssignHook) {
+	ta.preAssignHook = hook
+}
+
+// vetoLocked runs the pre-assign hook on copies of task and emp, returning
+// its veto; caller must hold the store lock
+func (ta *TaskAssigner) vetoLocked(task *Task, emp *Employee) error {
+	if ta.preAssignHook == nil {
+		return nil
+	}
+	current := task
+	if t, exists := ta.store.tasks[task.ID]; exists {
+		current = t
+	}
+	taskCopy := current.snapshot()
+	empCopy := *emp
+	empCopy.Skills = append([]string(nil), emp.Skills...)
+	return ta.preAssignHook(&taskCopy, &empCopy)
+}
+
+// withoutCandidate returns candidates minus the given employee, in a new slice
+func withoutCandidate(candidates []Candidate, employeeID string) []Candidate {
+	rest := make([]Candidate, 0, len(candidates)-1)
+	for _, c := range candidates {
+		if c.EmployeeID != employeeID {
+			rest = append(rest, c)
+		}
+	}
+	return rest
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestPreAssignHook tests that a vetoed nearest employee is skipped for the
+// next-nearest, and that vetoing everyone fails the task
+func TestPreAssignHook(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+
+	taskLoc := Location{Lat: 60.1700, Lon: 24.9400}
+	for id, loc := range map[string]Location{
+		"nearest": {Lat: 60.1710, Lon: 24.9410},
+		"second":  {Lat: 60.1800, Lon: 24.9500},
+		"third":   {Lat: 60.2500, Lon: 25.0500},
+	} {
+		store.AddEmployee(&Employee{ID: id, Name: id, Location: loc, Skills: []string{"delivery"}, IsAvailable: true})
+	}
+
+	var consulted []string
+	failedCreditCheck := errors.New("failed credit check")
+	assigner.SetPreAssignHook(func(task *Task, candidate *Employee) error {
+		consulted = append(consulted, candidate.ID)
+		if task.ID == "task2" || candidate.ID == "nearest" {
+			return failedCreditCheck
+		}
+		return nil
+	})
+
+	task := &Task{ID: "task1", Location: taskLoc, RequiredSkill: "delivery"}
+	store.AddTask(task)
+	result, err := assigner.AssignTask(context.Background(), task)
+	if err != nil {
+		t.Fatalf("AssignTask() unexpected error: %v", err)
+	}
+	if result.EmployeeID != "second" {
+		t.Errorf("Expected next-nearest employee second, got %s", result.EmployeeID)
+	}
+	if want := []string{"nearest", "second"}; !reflect.DeepEqual(consulted, want) {
+		t.Errorf("Hook consulted %v, want %v", consulted, want)
+	}
+	if rationale, _ := store.TaskRationale("task1"); rationale.ChosenEmployeeID != "second" ||
+		!reflect.DeepEqual(rationale.VetoedEmployeeIDs, []string{"nearest"}) {
+		t.Errorf("Unexpected rationale %+v", rationale)
+	}
+	if emp, _ := store.GetEmployee("nearest"); emp.ActiveTasks != 0 {
+		t.Errorf("Vetoed employee took the task: %+v", emp)
+	}
+
+	// Every remaining candidate is vetoed
+	task2 := &Task{ID: "task2", Location: taskLoc, RequiredSkill: "delivery"}
+	store.AddTask(task2)
+	result, err = assigner.AssignTask(context.Background(), task2)
+	if !errors.Is(err, ErrAssignmentVetoed) {
+		t.Fatalf("Expected ErrAssignmentVetoed, got %v", err)
+	}
+	if !errors.Is(result.Error, failedCreditCheck) || !errors.Is(err, failedCreditCheck) {
+		t.Errorf("Expected the result and the error to carry the hook's error, got %v and %v", result.Error, err)
+	}
+	if status, _ := store.taskStatus("task2"); status != TaskStatusFailed {
+		t.Errorf("Task status = %s, want %s", status, TaskStatusFailed)
+	}
+}
+
+// TestPreAssignHookSkipsUnavailable tests that after a veto, a next-best
+// candidate who became unavailable is skipped rather than failing the task
+func TestPreAssignHookSkipsUnavailable(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+
+	second := &Employee{ID: "second", Name: "second", Location: Location{Lat: 60.1800, Lon: 24.9500}, Skills: []string{"delivery"}, IsAvailable: true}
+	store.AddEmployee(&Employee{ID: "nearest", Name: "nearest", Location: Location{Lat: 60.1710, Lon: 24.9410}, Skills: []string{"delivery"}, IsAvailable: true})
+	store.AddEmployee(second)
+	store.AddEmployee(&Employee{ID: "third", Name: "third", Location: Location{Lat: 60.2500, Lon: 25.0500}, Skills: []string{"delivery"}, IsAvailable: true})
+
+	assigner.SetPreAssignHook(func(task *Task, candidate *Employee) error {
+		if candidate.ID != "nearest" {
+			return nil
+		}
+		// The runner-up is taken elsewhere before the fallback reaches them
+		second.IsAvailable = false
+		return errors.New("failed credit check")
+	})
+
+	task := &Task{ID: "task1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"}
+	store.AddTask(task)
+	result, err := assigner.AssignTask(context.Background(), task)
+	if err != nil {
+		t.Fatalf("AssignTask() unexpected error: %v", err)
+	}
+	if result.EmployeeID != "third" {
+		t.Errorf("Expected third after skipping the unavailable second, got %s", result.EmployeeID)
+	}
+}
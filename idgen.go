@@ -0,0 +1,13 @@
+package main
+
+import "github.com/google/uuid"
+
+// IDGenerator produces IDs for newly created employees and tasks
+type IDGenerator interface {
+	NewID() string
+}
+
+// uuidGenerator is the default IDGenerator, producing random UUIDv4 strings
+type uuidGenerator struct{}
+
+func (uuidGenerator) NewID() string { return uuid.New().String() }
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"sort"
+	"time"
+)
+
+// LatencyStats summarizes how long tasks waited before reaching a status
+type LatencyStats struct {
+	Count      int     `json:"count"`
+	AvgSeconds float64 `json:"avg_seconds"`
+	P95Seconds float64 `json:"p95_seconds"`
+}
+
+// TransitionLatency reports time spent pending before the first assignment or failure
+// Tasks whose history never left pending are only counted in NeverLeftPending
+type TransitionLatency struct {
+	TimeToAssignment LatencyStats `json:"time_to_assignment"`
+	TimeToFailure    LatencyStats `json:"time_to_failure"`
+	NeverLeftPending int          `json:"never_left_pending"`
+}
+
+// TransitionLatency measures, from each task's history, the time between its
+// first recorded event (creation) and its first assigned or failed event
+func (s *Store) TransitionLatency() TransitionLatency {
+	var toAssignment, toFailure []time.Duration
+	var result TransitionLatency
+
+	s.mu.RLock()
+	for _, task := range s.tasks {
+		if len(task.History) == 0 {
+			continue
+		}
+		start := task.History[0].At
+		left := false
+		for _, event := range task.History[1:] {
+			if event.Status == TaskStatusAssigned {
+				toAssignment = append(toAssignment, event.At.Sub(start))
+				left = true
+				break
+			}
+			if event.Status == TaskStatusFailed {
+				toFailure = append(toFailure, event.At.Sub(start))
+				left = true
+				break
+			}
+		}
+		if !left {
+			result.NeverLeftPending++
+		}
+	}
+	s.mu.RUnlock()
+
+	result.TimeToAssignment = summarizeLatency(toAssignment)
+	result.TimeToFailure = summarizeLatency(toFailure)
+	return result
+}
+
+// summarizeLatency computes the mean and nearest-rank 95th percentile
+func summarizeLatency(samples []time.Duration) LatencyStats {
+	if len(samples) == 0 {
+		return LatencyStats{}
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+
+	var total time.Duration
+	for _, d := range samples {
+		total += d
+	}
+	// Nearest rank: the smallest sample with at least 95% of samples at or below it
+	rank := (len(samples)*95 + 99) / 100
+	return LatencyStats{
+		Count:      len(samples),
+		AvgSeconds: (total / time.Duration(len(samples))).Seconds(),
+		P95Seconds: samples[rank-1].Seconds(),
+	}
+}
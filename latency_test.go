@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestLatencyStatsHandler tests latency computed from history timestamps
+func TestLatencyStatsHandler(t *testing.T) {
+	api := setupTestAPI()
+	clock := newFakeClock()
+	api.store.SetClock(clock)
+	router := api.setupRouter()
+
+	loc := Location{Lat: 60.1700, Lon: 24.9400}
+	for _, id := range []string{"a1", "a2", "f1", "p1"} {
+		api.store.AddTask(&Task{ID: id, Location: loc, RequiredSkill: "delivery"})
+	}
+
+	transition := func(id string, status TaskStatus, employeeID string) {
+		api.store.mu.Lock()
+		api.store.setTaskStatusLocked(id, status, employeeID)
+		api.store.mu.Unlock()
+	}
+
+	// a1 assigned after 10s, f1 failed after 20s, a2 assigned after 30s; p1 stays pending
+	clock.Advance(10 * time.Second)
+	transition("a1", TaskStatusAssigned, "emp1")
+	clock.Advance(10 * time.Second)
+	transition("f1", TaskStatusFailed, "")
+	clock.Advance(10 * time.Second)
+	transition("a2", TaskStatusAssigned, "emp2")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/stats/latency", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	var response struct {
+		Data TransitionLatency `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	got := response.Data
+
+	if got.TimeToAssignment.Count != 2 || math.Abs(got.TimeToAssignment.AvgSeconds-20) > 1e-9 {
+		t.Errorf("Expected 2 assignments averaging 20s, got %+v", got.TimeToAssignment)
+	}
+	if got.TimeToAssignment.P95Seconds != 30 {
+		t.Errorf("Expected p95 time to assignment 30s, got %v", got.TimeToAssignment.P95Seconds)
+	}
+	if got.TimeToFailure.Count != 1 || got.TimeToFailure.AvgSeconds != 20 {
+		t.Errorf("Expected 1 failure after 20s, got %+v", got.TimeToFailure)
+	}
+	if got.NeverLeftPending != 1 {
+		t.Errorf("Expected 1 task never left pending, got %d", got.NeverLeftPending)
+	}
+}
+
+// TestSummarizeLatencyP95 tests the nearest-rank percentile
+func TestSummarizeLatencyP95(t *testing.T) {
+	var samples []time.Duration
+	for i := 100; i >= 1; i-- {
+		samples = append(samples, time.Duration(i)*time.Second)
+	}
+	stats := summarizeLatency(samples)
+	if stats.P95Seconds != 95 {
+		t.Errorf("Expected p95 of 1..100s to be 95s, got %v", stats.P95Seconds)
+	}
+	if stats.AvgSeconds != 50.5 {
+		t.Errorf("Expected average 50.5s, got %v", stats.AvgSeconds)
+	}
+	if empty := summarizeLatency(nil); empty.Count != 0 || empty.AvgSeconds != 0 {
+		t.Errorf("Expected zero stats for no samples, got %+v", empty)
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import "time"
+
+// MaxLocationHistory caps the number of location records kept per employee
+const MaxLocationHistory = 100
+
+// LocationRecord is one entry in an employee's location trail
+type LocationRecord struct {
+	Location   Location  `json:"location"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// recordLocationLocked appends to an employee's trail, dropping the oldest
+// entries beyond MaxLocationHistory; caller must hold s.mu
+func (s *Store) recordLocationLocked(id string, loc Location) {
+	history := append(s.locationHistory[id], LocationRecord{
+		Location:   loc,
+		RecordedAt: time.Now().UTC(),
+	})
+	if len(history) > MaxLocationHistory {
+		history = append([]LocationRecord(nil), history[len(history)-MaxLocationHistory:]...)
+	}
+	s.locationHistory[id] = history
+}
+
+// UpdateEmployeeLocation moves an employee and records the update in its trail
+func (s *Store) UpdateEmployeeLocation(id string, loc Location) error {
+	if err := loc.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	emp, exists := s.employees[id]
+	if !exists {
+		return ErrEmployeeNotFound
+	}
+	emp.Location = loc
+	s.recordLocationLocked(id, loc)
+	return nil
+}
+
+// LocationHistory returns a copy of an employee's location trail, oldest first
+func (s *Store) LocationHistory(id string) ([]LocationRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, exists := s.employees[id]; !exists {
+		return nil, ErrEmployeeNotFound
+	}
+	history := make([]LocationRecord, len(s.locationHistory[id]))
+	copy(history, s.locationHistory[id])
+	return history, nil
+}
+
+// LocationUpdate is one entry of a bulk location update
+type LocationUpdate struct {
+	ID       string   `json:"id"`
+	Location Location `json:"location"`
+}
+
+// UpdateEmployeeLocations applies a batch of location updates under a single
+// lock; the returned slice holds each update's error (nil on success), in order
+func (s *Store) UpdateEmployeeLocations(updates []LocationUpdate) []error {
+	errs := make([]error, len(updates))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, update := range updates {
+		if err := update.Location.Validate(); err != nil {
+			errs[i] = err
+			continue
+		}
+		emp, exists := s.employees[update.ID]
+		if !exists {
+			errs[i] = ErrEmployeeNotFound
+			continue
+		}
+		emp.Location = update.Location
+		s.recordLocationLocked(update.ID, update.Location)
+	}
+	return errs
+}
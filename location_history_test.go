@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestLocationHistoryOrderAndCap tests that the trail is oldest-first and bounded
+func TestLocationHistoryOrderAndCap(t *testing.T) {
+	store := NewStore()
+	store.AddEmployee(&Employee{
+		ID:          "emp1",
+		Name:        "John",
+		Location:    Location{Lat: 60.0, Lon: 24.0},
+		Skills:      []string{"delivery"},
+		IsAvailable: true,
+	})
+
+	for i := 1; i <= 5; i++ {
+		if err := store.UpdateEmployeeLocation("emp1", Location{Lat: 60.0 + float64(i)*0.01, Lon: 24.0}); err != nil {
+			t.Fatalf("UpdateEmployeeLocation() unexpected error: %v", err)
+		}
+	}
+
+	history, err := store.LocationHistory("emp1")
+	if err != nil {
+		t.Fatalf("LocationHistory() unexpected error: %v", err)
+	}
+	if len(history) != 6 {
+		t.Fatalf("Expected 6 records (initial + 5 moves), got %d", len(history))
+	}
+	for i := 1; i < len(history); i++ {
+		if history[i].Location.Lat <= history[i-1].Location.Lat {
+			t.Errorf("History out of order at %d: %v after %v", i, history[i].Location, history[i-1].Location)
+		}
+		if history[i].RecordedAt.Before(history[i-1].RecordedAt) {
+			t.Errorf("Timestamps out of order at %d", i)
+		}
+	}
+
+	for i := 0; i < MaxLocationHistory+10; i++ {
+		store.UpdateEmployeeLocation("emp1", Location{Lat: 61.0, Lon: 24.0 + float64(i)*0.001})
+	}
+	history, _ = store.LocationHistory("emp1")
+	if len(history) != MaxLocationHistory {
+		t.Fatalf("Expected history capped at %d, got %d", MaxLocationHistory, len(history))
+	}
+	if last := history[len(history)-1].Location.Lon; last != 24.0+float64(MaxLocationHistory+9)*0.001 {
+		t.Errorf("Expected newest record last, got lon %f", last)
+	}
+
+	emp, _ := store.GetEmployee("emp1")
+	if emp.Location != history[len(history)-1].Location {
+		t.Errorf("Employee location %v does not match latest record", emp.Location)
+	}
+
+	if _, err := store.LocationHistory("missing"); err != ErrEmployeeNotFound {
+		t.Errorf("Expected ErrEmployeeNotFound, got %v", err)
+	}
+	if err := store.UpdateEmployeeLocation("emp1", Location{Lat: 91, Lon: 0}); err == nil {
+		t.Error("Expected invalid location to be rejected")
+	}
+}
+
+// TestBulkUpdateLocations tests a GPS batch mixing valid, unknown and invalid entries
+func TestBulkUpdateLocations(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+	for _, id := range []string{"emp1", "emp2"} {
+		api.store.AddEmployee(&Employee{ID: id, Name: id, Location: Location{Lat: 60.0, Lon: 24.0}, Skills: []string{"delivery"}, IsAvailable: true})
+	}
+
+	body := `[
+		{"id": "emp1", "location": {"lat": 60.1700, "lon": 24.9400}},
+		{"id": "ghost", "location": {"lat": 60.1700, "lon": 24.9400}},
+		{"id": "emp2", "location": {"lat": 95.0, "lon": 24.9400}}
+	]`
+	req := httptest.NewRequest("POST", "/employees/locations", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response struct {
+		Data []LocationUpdateResult `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+
+	want := []struct {
+		id     string
+		status int
+		code   string
+	}{
+		{"emp1", http.StatusOK, ""},
+		{"ghost", http.StatusNotFound, "EMPLOYEE_NOT_FOUND"},
+		{"emp2", http.StatusBadRequest, "INVALID_COORDINATES"},
+	}
+	if len(response.Data) != len(want) {
+		t.Fatalf("Expected %d results, got %d", len(want), len(response.Data))
+	}
+	for i, w := range want {
+		got := response.Data[i]
+		if got.ID != w.id || got.Status != w.status || got.Code != w.code {
+			t.Errorf("Result %d = %+v, want id=%s status=%d code=%s", i, got, w.id, w.status, w.code)
+		}
+	}
+
+	emp1, _ := api.store.GetEmployee("emp1")
+	emp2, _ := api.store.GetEmployee("emp2")
+	if emp1.Location.Lat != 60.1700 {
+		t.Errorf("Expected emp1 to move, got %+v", emp1.Location)
+	}
+	if emp2.Location.Lat != 60.0 {
+		t.Errorf("Expected emp2 to stay put after an invalid update, got %+v", emp2.Location)
+	}
+}
@@ -1,250 +1,2489 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
+	"github.com/gin-gonic/gin/binding"
 )
 
 // API represents the HTTP API server
 type API struct {
+	config         Config // the configuration the API was built from
 	store          *Store
 	assigner       *TaskAssigner
 	workerPool     *AssignmentWorkerPool
 	workerPoolCtx  context.Context
 	workerPoolStop context.CancelFunc
+	notifier       *WebhookNotifier
+	serviceAreas   ServiceAreas    // empty means no restriction
+	skillZones     SkillZones      // per-skill geofences; unmapped skills are unrestricted
+	stressEnabled  bool            // gates POST /admin/stress
+	rejectNoSkill  bool            // reject tasks nobody available can serve instead of queuing them
+	ids            IDGenerator     // IDs for created employees and tasks
+	dedup          *TaskDedupIndex // optional, nil disables content dedup
+	distanceUnit   DistanceUnit    // unit for GET /distance
+	reaper         *Reaper         // releases expired reservations
+	snapshotDir    string          // where admin snapshots live; empty disables them
+	snapshots      snapshotTracker // outcome of the latest snapshot attempts
+	snapshotter    *Snapshotter    // periodic snapshots; nil unless SNAPSHOT_INTERVAL is set
+	snapshotMaxAge time.Duration   // GET /health/snapshot degrades past this age (0 = never)
+	startedAt      time.Time
 }
 
-// NewAPI creates a new API instance
+// NewAPI creates a new API instance with the default configuration
 func NewAPI() *API {
+	api, err := NewAPIWithConfig(DefaultConfig())
+	if err != nil {
+		// DefaultConfig is always valid and names no files to load
+		panic(err)
+	}
+	return api
+}
+
+// strategyFromConfig looks up a registered strategy and applies cfg's tuning
+// for it; strategy-specific tuning only applies to the strategy it belongs to
+func strategyFromConfig(cfg Config, name string) (AssignmentStrategy, error) {
+	strategy, err := StrategyByName(name)
+	if err != nil {
+		return nil, err
+	}
+	switch strategy.(type) {
+	case NearestStrategy:
+		strategy = NearestStrategy{DistanceBandKm: cfg.DistanceBandKm}
+	case *SoftmaxStrategy:
+		if cfg.SoftmaxTemperatureKm > 0 {
+			strategy = NewSoftmaxStrategy(cfg.SoftmaxTemperatureKm, time.Now().UnixNano())
+		}
+	case TopRatedStrategy:
+		strategy = TopRatedStrategy{K: cfg.TopRatedK}
+	}
+	return strategy, nil
+}
+
+// NewAPIWithConfig creates a new API instance from cfg
+// Returns an error if cfg is invalid or a configured file cannot be loaded
+func NewAPIWithConfig(cfg Config) (*API, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	SetSkillSeparator(cfg.SkillSeparator)
 	store := NewStore()
+	store.SetSkillMatcher(SkillMatcher{Mode: cfg.SkillMatchMode, FuzzyThreshold: cfg.SkillFuzzyThreshold})
+	store.SetMaxEmployees(cfg.MaxEmployees)
 	assigner := NewTaskAssigner(store)
 
-	// Create worker pool with 5 workers and 30 second timeout
-	workerPool := NewAssignmentWorkerPool(assigner, 5, 30*time.Second)
+	strategy, _ := strategyFromConfig(cfg, cfg.Strategy)
+	assigner.SetStrategy(strategy)
+	assigner.SetMaxConcurrent(cfg.MaxConcurrentAssignments)
+	assigner.SetMaxCandidates(cfg.MaxCandidates)
+	assigner.SetIgnoreDistance(cfg.IgnoreDistance)
+	assigner.SetPartialResultFraction(cfg.PartialResultMinFraction)
+	assigner.SetRadiusTiers(cfg.RadiusTiersKm)
+	assigner.SetStrictLocation(cfg.StrictLocation)
+
+	workerPool := NewAssignmentWorkerPool(assigner, cfg.WorkerCount, cfg.AssignmentTimeout)
+	workerPool.SetQueueSize(cfg.QueueSize)
+	workerPool.SetSkillTimeouts(cfg.SkillAssignmentTimeouts)
+	workerPool.SetDrainTimeout(cfg.DrainTimeout)
+	workerPool.taskQueue.agingInterval = cfg.QueueAgingInterval
+	workerPool.SetSpillover(cfg.SpilloverQueueSize)
+	workerPool.SetStrictFIFO(cfg.StrictFIFO)
+	workerPool.SetMaxAttempts(cfg.MaxAssignmentAttempts)
+	workerPool.SetCASRaceAction(cfg.CASRaceAction, cfg.CASRaceMaxRequeues)
+	if cfg.CircuitBreakerThreshold > 0 {
+		workerPool.breaker = NewCircuitBreaker(cfg.CircuitBreakerThreshold, cfg.CircuitBreakerCooldown, realClock{})
+	}
+
+	// Webhooks are optional: only enabled when a receiver URL is configured
+	var notifier *WebhookNotifier
+	if cfg.WebhookURL != "" {
+		notifier = NewWebhookNotifier(cfg.WebhookURL, 5, time.Second)
+		workerPool.notifier = notifier
+	}
+
+	// Optional service areas restricting where tasks may be created
+	var serviceAreas ServiceAreas
+	if cfg.ServiceAreasFile != "" {
+		areas, err := LoadServiceAreas(cfg.ServiceAreasFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load service areas: %w", err)
+		}
+		serviceAreas = areas
+		log.Printf("Loaded %d service areas", len(areas))
+	}
+
+	// Optional per-skill geofences (e.g. licensed areas for alcohol delivery)
+	var skillZones SkillZones
+	if cfg.SkillZonesFile != "" {
+		zones, err := LoadSkillZones(cfg.SkillZonesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load skill zones: %w", err)
+		}
+		skillZones = zones
+		log.Printf("Loaded geofences for %d skills", len(zones))
+	}
+
+	// Optional rejection of identical pending tasks created within a window
+	var dedup *TaskDedupIndex
+	if cfg.TaskDedupWindow > 0 {
+		dedup = NewTaskDedupIndex(store, cfg.TaskDedupWindow, realClock{})
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 
-	return &API{
+	api := &API{
+		config:         cfg,
 		store:          store,
 		assigner:       assigner,
 		workerPool:     workerPool,
 		workerPoolCtx:  ctx,
 		workerPoolStop: cancel,
+		notifier:       notifier,
+		serviceAreas:   serviceAreas,
+		skillZones:     skillZones,
+		stressEnabled:  cfg.EnableAdminStress,
+		rejectNoSkill:  cfg.RejectWithoutWorkforce,
+		ids:            uuidGenerator{},
+		dedup:          dedup,
+		distanceUnit:   cfg.DistanceUnit,
+		reaper:         NewReaper(store, cfg.ReaperInterval),
+		snapshotDir:    cfg.SnapshotDir,
+		snapshotMaxAge: cfg.SnapshotMaxAge,
+		startedAt:      store.clock.Now(),
+	}
+	if api.snapshotDir != "" && cfg.SnapshotInterval > 0 {
+		api.snapshotter = NewSnapshotter(cfg.SnapshotInterval, api.takeSnapshot)
+	}
+	return api, nil
+}
+
+// ErrorResponse represents an API error response
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// SuccessResponse represents a generic success response
+type SuccessResponse struct {
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	// NextOffset is set on a truncated list: pass it as ?offset= for the next page
+	NextOffset *int `json:"next_offset,omitempty"`
+}
+
+// CreateEmployeeRequest represents the request body for creating an employee
+type CreateEmployeeRequest struct {
+	// ID is optional; a new one is generated when omitted. Imports supply it
+	// so that ?upsert=true can match existing employees
+	ID       string   `json:"id"`
+	Name     string   `json:"name" binding:"required"`
+	Location Location `json:"location" binding:"required"`
+	Skills   []string `json:"skills" binding:"required"`
+	// IsAvailable is optional; employees are created available when omitted
+	IsAvailable *bool `json:"is_available,omitempty"`
+	// Capacity is optional; defaults to one task at a time
+	Capacity int `json:"capacity"`
+	// Tier is optional seniority used by the priority_aware strategy
+	Tier int `json:"tier"`
+	// TeamID optionally places the employee in a team
+	TeamID string `json:"team_id"`
+	// MaxRangeKm optionally caps how far from their location the employee travels
+	MaxRangeKm float64 `json:"max_range_km"`
+	// Rating is optional quality (0-5) used by the top_rated strategy
+	Rating float64 `json:"rating"`
+}
+
+// CreateTaskRequest represents the request body for creating a task
+type CreateTaskRequest struct {
+	Location      Location `json:"location" binding:"required"`
+	RequiredSkill string   `json:"required_skill" binding:"required"`
+	MaxDistanceKm float64  `json:"max_distance_km"`
+	MinDistanceKm float64  `json:"min_distance_km"`
+	Priority      int      `json:"priority"`
+	TeamID        string   `json:"team_id"`
+	// IgnoreDistance assigns any eligible employee without computing distances
+	IgnoreDistance bool `json:"ignore_distance"`
+	// Strategy optionally overrides the configured assignment strategy (e.g. "nearest")
+	Strategy string `json:"strategy"`
+	// RadiusTiersKm optionally overrides the configured radius tiers (e.g. [2, 10])
+	RadiusTiersKm []float64 `json:"radius_tiers_km"`
+}
+
+// toEmployee builds an unvalidated employee from the request
+func (req CreateEmployeeRequest) toEmployee(id string) *Employee {
+	if clientID := strings.TrimSpace(req.ID); clientID != "" {
+		id = clientID
+	}
+	isAvailable := true
+	if req.IsAvailable != nil {
+		isAvailable = *req.IsAvailable
+	}
+	return &Employee{
+		ID:          id,
+		Name:        req.Name,
+		Location:    req.Location,
+		Skills:      req.Skills,
+		IsAvailable: isAvailable,
+		Capacity:    req.Capacity,
+		Tier:        req.Tier,
+		TeamID:      req.TeamID,
+		MaxRangeKm:  req.MaxRangeKm,
+		Rating:      req.Rating,
+	}
+}
+
+// handleCreateEmployee handles POST /employees
+func (api *API) handleCreateEmployee(c *gin.Context) {
+	var req CreateEmployeeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	// Generate unique ID for the employee
+	employee := req.toEmployee(api.ids.NewID())
+
+	// Validate employee data
+	if err := employee.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	// With ?upsert=true an existing employee with the same ID is updated instead
+	created := true
+	var err error
+	if c.Query("upsert") == "true" {
+		created, err = api.store.AddOrUpdateEmployee(employee)
+	} else {
+		err = api.store.AddEmployee(employee)
+	}
+	if err != nil {
+		if taskErr, ok := err.(*TaskError); ok {
+			respondError(c, employeeErrorStatus(err), ErrorResponse{
+				Error:   taskErr.Error(),
+				Code:    taskErr.Code,
+				Message: taskErr.Message,
+			})
+			return
+		}
+		respondError(c, http.StatusInternalServerError, ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if !created {
+		respondSuccess(c, http.StatusOK, SuccessResponse{
+			Message: "Employee updated successfully",
+			Data:    employee,
+		})
+		return
+	}
+	c.Header("Location", "/employees/"+employee.ID)
+	respondSuccess(c, http.StatusCreated, SuccessResponse{
+		Message: "Employee created successfully",
+		Data:    employee,
+	})
+}
+
+// employeeErrorStatus maps a store error from adding or restoring an employee to an HTTP status
+func employeeErrorStatus(err error) int {
+	if errors.Is(err, ErrEmployeeLimitReached) {
+		return http.StatusServiceUnavailable
+	}
+	return taskStateErrorStatus(err)
+}
+
+// EmployeeBatchResult is the outcome of one entry in POST /employees/batch
+type EmployeeBatchResult struct {
+	Index   int       `json:"index"`
+	Status  int       `json:"status"`
+	ID      string    `json:"id,omitempty"`
+	Error   string    `json:"error,omitempty"`
+	Code    string    `json:"code,omitempty"`
+	Message string    `json:"message,omitempty"`
+	Data    *Employee `json:"data,omitempty"`
+}
+
+// handleCreateEmployees handles POST /employees/batch
+// Valid entries are added under one store lock; each entry reports its own outcome
+func (api *API) handleCreateEmployees(c *gin.Context) {
+	// Entries are validated one by one so a bad entry doesn't reject the batch
+	var reqs []CreateEmployeeRequest
+	if err := json.NewDecoder(c.Request.Body).Decode(&reqs); err != nil {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+	if len(reqs) == 0 {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: "at least one employee is required",
+		})
+		return
+	}
+
+	results := make([]EmployeeBatchResult, len(reqs))
+	var valid []*Employee
+	var validIdx []int
+	for i, req := range reqs {
+		results[i] = EmployeeBatchResult{Index: i}
+		if err := binding.Validator.ValidateStruct(&req); err != nil {
+			results[i].Status = http.StatusBadRequest
+			results[i].Error = "Invalid request body"
+			results[i].Message = err.Error()
+			continue
+		}
+		employee := req.toEmployee(api.ids.NewID())
+		if err := employee.Validate(); err != nil {
+			results[i].Status = http.StatusBadRequest
+			results[i].Error = "Validation failed"
+			results[i].Message = err.Error()
+			continue
+		}
+		valid = append(valid, employee)
+		validIdx = append(validIdx, i)
+	}
+
+	// With ?upsert=true entries matching an existing employee update it (200)
+	var isNew []bool
+	var errs []error
+	if c.Query("upsert") == "true" {
+		isNew, errs = api.store.AddOrUpdateEmployees(valid)
+	} else {
+		errs = api.store.AddEmployees(valid)
+	}
+
+	created, updated := 0, 0
+	for j, err := range errs {
+		result := &results[validIdx[j]]
+		if err != nil {
+			var taskErr *TaskError
+			errors.As(err, &taskErr)
+			result.Status = employeeErrorStatus(err)
+			result.Error = taskErr.Error()
+			result.Code = taskErr.Code
+			result.Message = taskErr.Message
+			continue
+		}
+		result.ID = valid[j].ID
+		result.Data = valid[j]
+		if isNew != nil && !isNew[j] {
+			updated++
+			result.Status = http.StatusOK
+			continue
+		}
+		created++
+		result.Status = http.StatusCreated
+	}
+
+	message := fmt.Sprintf("Created %d of %d employees", created, len(reqs))
+	if updated > 0 {
+		message = fmt.Sprintf("Created %d and updated %d of %d employees", created, updated, len(reqs))
+	}
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: message,
+		Data:    results,
+	})
+}
+
+// handleCreateTask handles POST /tasks
+func (api *API) handleCreateTask(c *gin.Context) {
+	var req CreateTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	// Optional short wait so quick assignments are reported in this response
+	wait, ok := parseWait(c, MaxCreateWait)
+	if !ok {
+		return
+	}
+
+	task, status, errResp := api.createTask(req)
+	if errResp != nil {
+		respondError(c, status, *errResp)
+		return
+	}
+
+	c.Header("Location", "/tasks/"+task.ID)
+	if wait == 0 {
+		// A worker may already be assigning the task, so encode a copy
+		api.store.mu.RLock()
+		snapshot := task.snapshot()
+		api.store.mu.RUnlock()
+		respondSuccess(c, http.StatusCreated, SuccessResponse{
+			Message: "Task created and assignment initiated",
+			Data:    snapshot,
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), wait)
+	defer cancel()
+	snapshot, _, _ := api.store.WaitForTerminal(ctx, task.ID)
+
+	message := "Task created and assignment initiated"
+	switch snapshot.Status {
+	case TaskStatusAssigned:
+		message = "Task created and assigned"
+	case TaskStatusFailed:
+		message = "Task created but assignment failed"
+	}
+	respondSuccess(c, http.StatusCreated, SuccessResponse{
+		Message: message,
+		Data:    snapshot,
+	})
+}
+
+// toTask builds an unvalidated pending task from the request
+func (req CreateTaskRequest) toTask(id string) *Task {
+	return &Task{
+		ID:             id,
+		Location:       req.Location,
+		RequiredSkill:  req.RequiredSkill,
+		MaxDistanceKm:  req.MaxDistanceKm,
+		MinDistanceKm:  req.MinDistanceKm,
+		Priority:       req.Priority,
+		TeamID:         req.TeamID,
+		IgnoreDistance: req.IgnoreDistance,
+		Strategy:       strings.ToLower(strings.TrimSpace(req.Strategy)),
+		RadiusTiersKm:  req.RadiusTiersKm,
+		Status:         TaskStatusPending,
+	}
+}
+
+// createTask builds a task from a request, queues it for assignment and stores it
+// On failure it returns the HTTP status and error body to send
+func (api *API) createTask(req CreateTaskRequest) (*Task, int, *ErrorResponse) {
+	// Generate unique ID for the task
+	task := req.toTask(api.ids.NewID())
+
+	// Validate task data
+	if err := task.Validate(); err != nil {
+		return nil, http.StatusBadRequest, &ErrorResponse{
+			Error:   "Validation failed",
+			Message: err.Error(),
+		}
+	}
+
+	if !api.serviceAreas.Contains(task.Location) {
+		return nil, http.StatusBadRequest, &ErrorResponse{
+			Error:   "Validation failed",
+			Code:    ErrOutOfServiceArea.Code,
+			Message: ErrOutOfServiceArea.Message,
+		}
+	}
+
+	if !api.skillZones.Allows(task.RequiredSkill, task.Location) {
+		return nil, http.StatusBadRequest, &ErrorResponse{
+			Error:   "Validation failed",
+			Code:    ErrSkillNotAllowedHere.Code,
+			Message: fmt.Sprintf("%s: %s", ErrSkillNotAllowedHere.Message, task.RequiredSkill),
+		}
+	}
+
+	// Opt-in fail-fast: don't accept work that can only fail asynchronously
+	if api.rejectNoSkill && len(api.store.GetAvailableEmployees(task.RequiredSkill)) == 0 {
+		return nil, http.StatusConflict, &ErrorResponse{
+			Error:   ErrNoCapableWorkforce.Message,
+			Code:    ErrNoCapableWorkforce.Code,
+			Message: fmt.Sprintf("%s: %s", ErrNoCapableWorkforce.Message, task.RequiredSkill),
+		}
+	}
+
+	if api.dedup != nil {
+		if existingID, ok := api.dedup.Reserve(task); !ok {
+			return nil, http.StatusConflict, &ErrorResponse{
+				Error:   ErrDuplicateTaskContent.Message,
+				Code:    ErrDuplicateTaskContent.Code,
+				Message: fmt.Sprintf("Identical pending task %s was created recently", existingID),
+			}
+		}
+	}
+
+	// CRITICAL: Submit to queue FIRST to check capacity
+	// This prevents orphaned tasks in store if queue is full
+	if err := api.workerPool.SubmitTask(task); err != nil {
+		api.releaseDedup(task)
+		// Queue is full, reject request immediately
+		if taskErr, ok := err.(*TaskError); ok && taskErr.Code == "QUEUE_FULL" {
+			return nil, http.StatusServiceUnavailable, &ErrorResponse{
+				Error:   "System at capacity",
+				Code:    "QUEUE_FULL",
+				Message: "Worker pool is full. Please retry in a few seconds.",
+			}
+		}
+		if errors.Is(err, ErrNoWorkers) {
+			return nil, http.StatusServiceUnavailable, &ErrorResponse{
+				Error:   "No workers running",
+				Code:    ErrNoWorkers.Code,
+				Message: ErrNoWorkers.Message,
+			}
+		}
+		if errors.Is(err, ErrPoolShuttingDown) {
+			return nil, http.StatusServiceUnavailable, &ErrorResponse{
+				Error:   "Shutting down",
+				Code:    ErrPoolShuttingDown.Code,
+				Message: ErrPoolShuttingDown.Message,
+			}
+		}
+		return nil, http.StatusInternalServerError, &ErrorResponse{
+			Error: err.Error(),
+		}
+	}
+
+	// Only add to store AFTER successful queue submission
+	if err := api.store.AddTask(task); err != nil {
+		api.releaseDedup(task)
+		if taskErr, ok := err.(*TaskError); ok {
+			return nil, http.StatusConflict, &ErrorResponse{
+				Error:   taskErr.Error(),
+				Code:    taskErr.Code,
+				Message: taskErr.Message,
+			}
+		}
+		return nil, http.StatusInternalServerError, &ErrorResponse{
+			Error: err.Error(),
+		}
+	}
+
+	if api.dedup != nil {
+		api.dedup.Confirm(task)
+	}
+
+	return task, http.StatusCreated, nil
+}
+
+// releaseDedup drops the task's dedup reservation after a failed creation
+func (api *API) releaseDedup(task *Task) {
+	if api.dedup != nil {
+		api.dedup.Release(task)
+	}
+}
+
+// StreamLineResult is the per-line outcome written by POST /tasks/stream
+type StreamLineResult struct {
+	Line    int    `json:"line"`
+	Status  int    `json:"status"`
+	TaskID  string `json:"task_id,omitempty"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// handleStreamTasks handles POST /tasks/stream
+// Accepts newline-delimited JSON task requests and creates each one as it is read,
+// streaming back one StreamLineResult per line; bad lines are reported and skipped
+func (api *API) handleStreamTasks(c *gin.Context) {
+	// Results are written while the body is still being read
+	http.NewResponseController(c.Writer).EnableFullDuplex()
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Status(http.StatusOK)
+
+	scanner := bufio.NewScanner(c.Request.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	encoder := json.NewEncoder(c.Writer)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+
+		result := StreamLineResult{Line: line}
+		var req CreateTaskRequest
+		if err := json.Unmarshal(raw, &req); err != nil {
+			result.Status = http.StatusBadRequest
+			result.Error = "Invalid JSON"
+			result.Message = err.Error()
+		} else if err := binding.Validator.ValidateStruct(&req); err != nil {
+			result.Status = http.StatusBadRequest
+			result.Error = "Invalid request body"
+			result.Message = err.Error()
+		} else if task, status, errResp := api.createTask(req); errResp != nil {
+			result.Status = status
+			result.Error = errResp.Error
+			result.Code = errResp.Code
+			result.Message = errResp.Message
+		} else {
+			result.Status = http.StatusCreated
+			result.TaskID = task.ID
+		}
+
+		encoder.Encode(result)
+		c.Writer.Flush()
+	}
+
+	if err := scanner.Err(); err != nil {
+		encoder.Encode(StreamLineResult{
+			Line:    line + 1,
+			Status:  http.StatusBadRequest,
+			Error:   "Failed to read request body",
+			Message: err.Error(),
+		})
+		c.Writer.Flush()
+	}
+}
+
+// handleGetTasks handles GET /tasks
+// With ?created_after= and/or ?created_before= (RFC3339) only tasks created in
+// [created_after, created_before) are returned, oldest first; otherwise by ID
+// At most MAX_PAGE_SIZE tasks are returned, paged with ?offset= and ?limit=
+func (api *API) handleGetTasks(c *gin.Context) {
+	offset, limit, ok := parsePage(c, api.config.MaxPageSize)
+	if !ok {
+		return
+	}
+
+	var bounds [2]time.Time
+	for i, name := range []string{"created_after", "created_before"} {
+		v := c.Query(name)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid time range",
+				Code:    "INVALID_TIME_RANGE",
+				Message: fmt.Sprintf("%s must be an RFC3339 timestamp", name),
+			})
+			return
+		}
+		bounds[i] = t
+	}
+	after, before := bounds[0], bounds[1]
+
+	var tasks []*Task
+	switch {
+	case after.IsZero() && before.IsZero():
+		tasks = api.store.GetAllTasks()
+		sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	case !after.IsZero() && !before.IsZero() && !after.Before(before):
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid time range",
+			Code:    "INVALID_TIME_RANGE",
+			Message: "created_after must be before created_before",
+		})
+		return
+	default:
+		tasks = api.store.TasksCreatedBetween(after, before)
 	}
+
+	page, next := paginate(tasks, offset, limit)
+	respondSparse(c, http.StatusOK, SuccessResponse{
+		Message:    fmt.Sprintf("Retrieved %d tasks", len(page)),
+		Data:       page,
+		NextOffset: next,
+	}, Task{}, respondCollection)
+}
+
+// handleExportTasks handles GET /tasks/export.ndjson
+func (api *API) handleExportTasks(c *gin.Context) {
+	streamNDJSON(c, api.store.TaskIDs(), api.store.taskSnapshot, Task{})
+}
+
+// handleGetProcessingTasks handles GET /tasks/processing
+// Lists the tasks a worker has picked up but not yet resolved, for spotting slow assignments
+func (api *API) handleGetProcessingTasks(c *gin.Context) {
+	tasks := api.workerPool.Processing()
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("%d tasks are being processed", len(tasks)),
+		Data:    tasks,
+	})
+}
+
+// handleGetBlockedTasks handles GET /tasks/blocked
+// Lists pending tasks that would fail assignment right now, with the reason, for triage
+func (api *API) handleGetBlockedTasks(c *gin.Context) {
+	blocked := api.assigner.BlockedTasks()
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("%d pending tasks are blocked", len(blocked)),
+		Data:    blocked,
+	})
+}
+
+// handleCanAssign handles GET /tasks/:id/can-assign/:employee_id
+// Reports whether the employee could take the task now, without assigning it
+func (api *API) handleCanAssign(c *gin.Context) {
+	check, err := api.assigner.CanAssign(c.Param("id"), c.Param("employee_id"))
+	if err != nil {
+		var taskErr *TaskError
+		errors.As(err, &taskErr)
+		respondError(c, http.StatusNotFound, ErrorResponse{
+			Error:   taskErr.Error(),
+			Code:    taskErr.Code,
+			Message: taskErr.Message,
+		})
+		return
+	}
+
+	message := "Assignment is feasible"
+	if !check.Feasible {
+		message = "Assignment is not feasible"
+	}
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: message,
+		Data:    check,
+	})
+}
+
+// handleCancelTasks handles POST /tasks/cancel
+// Cancels pending tasks matching ?skill= and/or a min_lat/min_lon/max_lat/max_lon
+// box, or every pending task with ?all_pending=true; ?include_assigned=true
+// also cancels assigned ones and frees their employees
+func (api *API) handleCancelTasks(c *gin.Context) {
+	filter := CancelFilter{
+		Skill:           strings.TrimSpace(c.Query("skill")),
+		IncludeAssigned: c.Query("include_assigned") == "true",
+	}
+	for _, name := range []string{"min_lat", "min_lon", "max_lat", "max_lon"} {
+		if _, ok := c.GetQuery(name); ok {
+			box, ok := parseBoundingBox(c)
+			if !ok {
+				return
+			}
+			filter.Box = &box
+			break
+		}
+	}
+	// Refuse an empty filter so a bare request can't cancel everything by accident
+	if filter.Skill == "" && filter.Box == nil && c.Query("all_pending") != "true" {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Missing filter",
+			Code:    "INVALID_FILTER",
+			Message: "set skill, a bounding box, or all_pending=true",
+		})
+		return
+	}
+
+	cancelled := api.store.CancelTasks(filter)
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("Cancelled %d tasks", len(cancelled)),
+		Data:    gin.H{"cancelled": len(cancelled), "task_ids": cancelled},
+	})
+}
+
+// handleGetTasksWithin handles GET /tasks/within
+// Lists tasks inside a min_lat/min_lon/max_lat/max_lon box, optionally filtered by ?status=
+// Boxes crossing the antimeridian are not supported: min_lon must not exceed max_lon
+func (api *API) handleGetTasksWithin(c *gin.Context) {
+	box, ok := parseBoundingBox(c)
+	if !ok {
+		return
+	}
+
+	var status TaskStatus
+	if v := c.Query("status"); v != "" {
+		parsed, err := ParseTaskStatus(v)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid status",
+				Code:    "INVALID_STATUS",
+				Message: err.Error(),
+			})
+			return
+		}
+		status = parsed
+	}
+
+	tasks := api.store.TasksWithin(box, status)
+
+	respondCollection(c, http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("Retrieved %d tasks within bounding box", len(tasks)),
+		Data:    tasks,
+	})
+}
+
+// parseBoundingBox reads the min_lat, min_lon, max_lat and max_lon query
+// parameters; on failure it writes a 400 response and returns false
+func parseBoundingBox(c *gin.Context) (BoundingBox, bool) {
+	var corners [4]float64
+	for i, name := range []string{"min_lat", "min_lon", "max_lat", "max_lon"} {
+		v, err := strconv.ParseFloat(c.Query(name), 64)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid bounding box",
+				Code:    "INVALID_COORDINATES",
+				Message: fmt.Sprintf("%s must be a number", name),
+			})
+			return BoundingBox{}, false
+		}
+		corners[i] = v
+	}
+
+	box := BoundingBox{MinLat: corners[0], MinLon: corners[1], MaxLat: corners[2], MaxLon: corners[3]}
+	if err := box.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid bounding box",
+			Code:    "INVALID_COORDINATES",
+			Message: err.Error(),
+		})
+		return BoundingBox{}, false
+	}
+	return box, true
+}
+
+// handleGetTaskByID handles GET /tasks/:id
+func (api *API) handleGetTaskByID(c *gin.Context) {
+	taskID := c.Param("id")
+
+	task, err := api.store.GetTask(taskID)
+	if err != nil {
+		if taskErr, ok := err.(*TaskError); ok {
+			respondError(c, http.StatusNotFound, ErrorResponse{
+				Error:   taskErr.Error(),
+				Code:    taskErr.Code,
+				Message: taskErr.Message,
+			})
+			return
+		}
+		respondError(c, http.StatusInternalServerError, ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	// Conditional GET: let pollers skip the body when nothing changed
+	api.store.mu.RLock()
+	snapshot := task.snapshot()
+	api.store.mu.RUnlock()
+	etag := taskETag(&snapshot)
+
+	c.Header("ETag", etag)
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	respondSparse(c, http.StatusOK, SuccessResponse{
+		Message: "Task retrieved successfully",
+		Data:    snapshot,
+	}, Task{}, respondSuccess)
+}
+
+// taskETag derives a strong ETag from the task's mutable state
+// Caller must hold the store lock or pass a snapshot
+func taskETag(task *Task) string {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s|%s|%s|%d", task.ID, task.Status, task.AssignedEmployeeID, len(task.History))
+	return fmt.Sprintf("\"%016x\"", h.Sum64())
+}
+
+// etagMatches reports whether an If-None-Match header matches the given ETag
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// handleGetEmployees handles GET /employees
+// Employees are ordered by ID; at most MAX_PAGE_SIZE are returned, paged with
+// ?offset= and ?limit=
+func (api *API) handleGetEmployees(c *gin.Context) {
+	offset, limit, ok := parsePage(c, api.config.MaxPageSize)
+	if !ok {
+		return
+	}
+
+	api.store.mu.RLock()
+	employees := make([]*Employee, 0, len(api.store.employees))
+	for _, emp := range api.store.employees {
+		employees = append(employees, emp)
+	}
+	api.store.mu.RUnlock()
+	sort.Slice(employees, func(i, j int) bool { return employees[i].ID < employees[j].ID })
+
+	page, next := paginate(employees, offset, limit)
+	respondSparse(c, http.StatusOK, SuccessResponse{
+		Message:    fmt.Sprintf("Retrieved %d employees", len(page)),
+		Data:       page,
+		NextOffset: next,
+	}, Employee{}, respondCollection)
+}
+
+// handleExportEmployees handles GET /employees/export.ndjson
+func (api *API) handleExportEmployees(c *gin.Context) {
+	streamNDJSON(c, api.store.EmployeeIDs(), api.store.employeeSnapshot, Employee{})
+}
+
+// ReprocessResponse reports the outcome of a reprocess request
+type ReprocessResponse struct {
+	Resubmitted   int `json:"resubmitted"`
+	QueueRejected int `json:"queue_rejected"`
+	AlreadyQueued int `json:"already_queued"`
+	// AttemptsExceeded counts tasks failed permanently instead of requeued
+	AttemptsExceeded int `json:"attempts_exceeded"`
+}
+
+// handleReprocessTasks handles POST /tasks/reprocess
+// Resubmits pending tasks (and failed ones when include_failed=true) to the worker pool
+func (api *API) handleReprocessTasks(c *gin.Context) {
+	resp := api.resubmitTasks(c.Query("include_failed") == "true")
+
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("Resubmitted %d tasks", resp.Resubmitted),
+		Data:    resp,
+	})
+}
+
+// resubmitTasks queues every pending task (and failed ones with includeFailed)
+// for assignment again, counting what happened to each
+func (api *API) resubmitTasks(includeFailed bool) ReprocessResponse {
+	var resp ReprocessResponse
+	for _, task := range api.store.GetAllTasks() {
+		status := task.Status
+		if status != TaskStatusPending && !(includeFailed && status == TaskStatusFailed) {
+			continue
+		}
+		// Tasks out of attempts are failed permanently rather than requeued
+		if api.workerPool.exhausted(task.ID) {
+			resp.AttemptsExceeded++
+			continue
+		}
+		// Failed tasks go back to pending before they are queued again
+		if status == TaskStatusFailed {
+			api.store.UpdateTask(task.ID, TaskStatusPending, "")
+		}
+
+		if err := api.workerPool.SubmitTask(task); err != nil {
+			if status == TaskStatusFailed {
+				api.store.UpdateTask(task.ID, TaskStatusFailed, "")
+			}
+			if errors.Is(err, ErrDuplicateSubmission) {
+				resp.AlreadyQueued++
+			} else {
+				resp.QueueRejected++
+			}
+			continue
+		}
+		resp.Resubmitted++
+	}
+	return resp
+}
+
+// CapacityEstimateRequest represents the request body for POST /capacity/estimate
+type CapacityEstimateRequest struct {
+	Skill         string      `json:"skill" binding:"required"`
+	BoundingBox   BoundingBox `json:"bounding_box" binding:"required"`
+	Count         int         `json:"count" binding:"required,min=1,max=10000"`
+	MaxDistanceKm float64     `json:"max_distance_km"`
+}
+
+// handleEstimateCapacity handles POST /capacity/estimate
+// Read-only: simulates assigning hypothetical tasks without touching the store
+func (api *API) handleEstimateCapacity(c *gin.Context) {
+	var req CapacityEstimateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := req.BoundingBox.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: fmt.Sprintf("invalid bounding_box: %v", err),
+		})
+		return
+	}
+	if strings.TrimSpace(req.Skill) == "" {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: "skill cannot be empty",
+		})
+		return
+	}
+	if req.MaxDistanceKm < 0 {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: fmt.Sprintf("max_distance_km cannot be negative, got %.2f", req.MaxDistanceKm),
+		})
+		return
+	}
+
+	estimate := api.assigner.EstimateCapacity(req.Skill, req.BoundingBox, req.Count, req.MaxDistanceKm)
+
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("%d of %d tasks could be assigned", estimate.Assignable, estimate.Requested),
+		Data:    estimate,
+	})
+}
+
+// ResizeWorkersRequest represents the request body for resizing the worker pool
+type ResizeWorkersRequest struct {
+	Count int `json:"count" binding:"required"`
+}
+
+// RebalanceRequest represents the optional body of POST /admin/rebalance
+type RebalanceRequest struct {
+	// Threshold is the load above which an employee is overloaded (0 = DefaultRebalanceThreshold)
+	Threshold int `json:"threshold" binding:"min=0"`
+}
+
+// StrategyRequest is the request body for PUT /admin/strategy
+type StrategyRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// OptimizeRequest is the optional body for POST /assign/optimize
+type OptimizeRequest struct {
+	// TaskIDs limits the batch to these tasks (empty = every pending task)
+	TaskIDs []string `json:"task_ids"`
+	// SortBy orders the results: "task_id" (default) or "distance" (farthest first)
+	SortBy string `json:"sort_by"`
+}
+
+// SnapshotInfo describes a snapshot file
+type SnapshotInfo struct {
+	Name      string `json:"name"`
+	Path      string `json:"path"`
+	SizeBytes int64  `json:"size_bytes,omitempty"`
+	Employees int    `json:"employees"`
+	Tasks     int    `json:"tasks"`
+	// Resubmitted counts restored pending tasks queued for assignment
+	Resubmitted int `json:"resubmitted,omitempty"`
+}
+
+// RestoreRequest names the snapshot file to load from SNAPSHOT_DIR
+type RestoreRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// snapshotsEnabled responds 403 and returns false when SNAPSHOT_DIR is unset
+func (api *API) snapshotsEnabled(c *gin.Context) bool {
+	if api.snapshotDir != "" {
+		return true
+	}
+	respondError(c, http.StatusForbidden, ErrorResponse{
+		Error:   "Snapshots disabled",
+		Code:    "ADMIN_DISABLED",
+		Message: "Set SNAPSHOT_DIR to enable this endpoint",
+	})
+	return false
+}
+
+// takeSnapshot writes the current state to a new timestamped file in
+// SNAPSHOT_DIR and records the outcome for GET /health/snapshot
+func (api *API) takeSnapshot() (SnapshotInfo, error) {
+	snap := api.store.Snapshot()
+	name := fmt.Sprintf("snapshot-%s.json", snap.TakenAt.Format("20060102T150405.000000000Z"))
+	path := filepath.Join(api.snapshotDir, name)
+	size, err := WriteSnapshot(path, snap)
+	if err != nil {
+		api.snapshots.recordFailure(err)
+		return SnapshotInfo{}, err
+	}
+	api.snapshots.recordSuccess(snap.TakenAt)
+
+	return SnapshotInfo{
+		Name:      name,
+		Path:      path,
+		SizeBytes: size,
+		Employees: len(snap.Employees),
+		Tasks:     len(snap.Tasks),
+	}, nil
+}
+
+// handleSnapshot handles POST /admin/snapshot
+// Writes the current state to a new timestamped file in SNAPSHOT_DIR
+func (api *API) handleSnapshot(c *gin.Context) {
+	if !api.snapshotsEnabled(c) {
+		return
+	}
+
+	info, err := api.takeSnapshot()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to write snapshot",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	respondSuccess(c, http.StatusCreated, SuccessResponse{
+		Message: "Snapshot written",
+		Data:    info,
+	})
+}
+
+// handleRestore handles POST /admin/restore
+// Replaces all state with a snapshot from SNAPSHOT_DIR and queues its pending
+// tasks; refused with 409 POOL_BUSY while any task is queued or being assigned
+func (api *API) handleRestore(c *gin.Context) {
+	if !api.snapshotsEnabled(c) {
+		return
+	}
+
+	var req RestoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+	// Only plain file names inside SNAPSHOT_DIR may be loaded
+	if req.Name != filepath.Base(req.Name) || strings.HasPrefix(req.Name, ".") {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid snapshot name",
+			Code:    "INVALID_SNAPSHOT",
+			Message: "name must be a file name inside SNAPSHOT_DIR",
+		})
+		return
+	}
+
+	path := filepath.Join(api.snapshotDir, req.Name)
+	snap, err := ReadSnapshot(path)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, os.ErrNotExist) {
+			status = http.StatusNotFound
+		}
+		respondError(c, status, ErrorResponse{
+			Error:   "Failed to read snapshot",
+			Code:    "INVALID_SNAPSHOT",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	err = api.workerPool.WhileIdle(func() error {
+		return api.store.Restore(snap)
+	})
+	if err != nil {
+		var taskErr *TaskError
+		if errors.As(err, &taskErr) {
+			respondError(c, http.StatusConflict, ErrorResponse{
+				Error:   taskErr.Error(),
+				Code:    taskErr.Code,
+				Message: taskErr.Message,
+			})
+			return
+		}
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Failed to restore snapshot",
+			Code:    "INVALID_SNAPSHOT",
+			Message: err.Error(),
+		})
+		return
+	}
+	if api.dedup != nil {
+		api.dedup.Reset()
+	}
+	// Restored pending tasks were never queued in this process
+	resubmitted := api.resubmitTasks(false)
+
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: "Snapshot restored",
+		Data: SnapshotInfo{
+			Name:        req.Name,
+			Path:        path,
+			Employees:   len(snap.Employees),
+			Tasks:       len(snap.Tasks),
+			Resubmitted: resubmitted.Resubmitted,
+		},
+	})
+}
+
+// handleResizeWorkers handles POST /admin/workers
+func (api *API) handleResizeWorkers(c *gin.Context) {
+	var req ResizeWorkersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := api.workerPool.Resize(req.Count); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, ErrPoolStopped) {
+			status = http.StatusServiceUnavailable
+		}
+		var taskErr *TaskError
+		errors.As(err, &taskErr)
+		respondError(c, status, ErrorResponse{
+			Error:   "Failed to resize worker pool",
+			Code:    taskErr.Code,
+			Message: taskErr.Message,
+		})
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("Worker pool resized to %d", req.Count),
+		Data: gin.H{
+			"count": req.Count,
+		},
+	})
+}
+
+// ReplayRequest is the body for POST /admin/replay
+// An empty Log replays the store's own assignment log; an empty Strategy uses the current one
+type ReplayRequest struct {
+	Strategy string               `json:"strategy"`
+	Log      []AssignmentLogEntry `json:"log" binding:"dive"`
+}
+
+// handleReplay handles POST /admin/replay
+// Re-runs an assignment log against the current employees in a throwaway store
+func (api *API) handleReplay(c *gin.Context) {
+	var req ReplayRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid request body",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	strategy := api.assigner.Strategy()
+	if req.Strategy != "" {
+		named, err := StrategyByName(req.Strategy)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid strategy",
+				Code:    "INVALID_STRATEGY",
+				Message: err.Error(),
+			})
+			return
+		}
+		strategy = named
+	}
+
+	entries := req.Log
+	if len(entries) == 0 {
+		entries = api.store.AssignmentLog()
+	}
+	report := ReplayAssignments(c.Request.Context(), api.store.Snapshot().Employees, entries, strategy, api.store.skillMatcher)
+
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("Replayed %d assignments: %d differ", report.Replayed, len(report.Differences)),
+		Data:    report,
+	})
+}
+
+// handleStressTest handles POST /admin/stress
+// Runs a concurrent assignment stress pass in an isolated store; disabled unless ENABLE_ADMIN_STRESS=true
+func (api *API) handleStressTest(c *gin.Context) {
+	if !api.stressEnabled {
+		respondError(c, http.StatusForbidden, ErrorResponse{
+			Error:   "Stress testing disabled",
+			Code:    "ADMIN_DISABLED",
+			Message: "Set ENABLE_ADMIN_STRESS=true to enable this endpoint",
+		})
+		return
+	}
+
+	var cfg StressConfig
+	if err := c.ShouldBindJSON(&cfg); err != nil {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	result := RunStressTest(c.Request.Context(), cfg, api.assigner.Strategy(), api.store.skillMatcher)
+
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("Stress pass completed: %d of %d assignments succeeded", result.Successes, result.Attempts),
+		Data:    result,
+	})
+}
+
+// taskStateErrorStatus maps task lookup and state errors to HTTP statuses
+func taskStateErrorStatus(err error) int {
+	if errors.Is(err, ErrTaskNotFound) || errors.Is(err, ErrEmployeeNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusConflict
+}
+
+// handleHoldTask handles POST /tasks/:id/hold
+func (api *API) handleHoldTask(c *gin.Context) {
+	taskID := c.Param("id")
+
+	if err := api.store.HoldTask(taskID); err != nil {
+		var taskErr *TaskError
+		errors.As(err, &taskErr)
+		respondError(c, taskStateErrorStatus(err), ErrorResponse{
+			Error:   taskErr.Error(),
+			Code:    taskErr.Code,
+			Message: taskErr.Message,
+		})
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: "Task held",
+		Data:    gin.H{"id": taskID, "status": TaskStatusHeld},
+	})
+}
+
+// handleReleaseTask handles POST /tasks/:id/release
+// The task returns to pending and is queued for assignment again
+func (api *API) handleReleaseTask(c *gin.Context) {
+	taskID := c.Param("id")
+
+	task, err := api.store.ReleaseTask(taskID)
+	if err != nil {
+		var taskErr *TaskError
+		errors.As(err, &taskErr)
+		respondError(c, taskStateErrorStatus(err), ErrorResponse{
+			Error:   taskErr.Error(),
+			Code:    taskErr.Code,
+			Message: taskErr.Message,
+		})
+		return
+	}
+
+	// Still queued from before the hold means a worker will pick it up anyway
+	if err := api.workerPool.SubmitTask(task); err != nil && !errors.Is(err, ErrDuplicateSubmission) {
+		api.store.HoldTask(taskID)
+		var taskErr *TaskError
+		errors.As(err, &taskErr)
+		respondError(c, http.StatusServiceUnavailable, ErrorResponse{
+			Error:   "Failed to re-queue task",
+			Code:    taskErr.Code,
+			Message: taskErr.Message,
+		})
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: "Task released and queued for assignment",
+		Data:    gin.H{"id": taskID, "status": TaskStatusPending},
+	})
+}
+
+// RetryTimeout bounds the synchronous assignment attempt in POST /tasks/:id/retry
+const RetryTimeout = 5 * time.Second
+
+// RetryResponse is the immediate outcome of POST /tasks/:id/retry
+type RetryResponse struct {
+	Task       Task    `json:"task"`
+	Assigned   bool    `json:"assigned"`
+	EmployeeID string  `json:"employee_id,omitempty"`
+	DistanceKm float64 `json:"distance_km,omitempty"`
+	// Code and Message explain a failed attempt
+	Code        string                  `json:"code,omitempty"`
+	Message     string                  `json:"message,omitempty"`
+	Diagnostics *EligibilityDiagnostics `json:"diagnostics,omitempty"`
+}
+
+// handleRetryTask handles POST /tasks/:id/retry
+// Runs one assignment attempt for a pending task right away, bypassing the
+// queue, and reports how it went; a failed attempt fails the task like a
+// worker pass would
+func (api *API) handleRetryTask(c *gin.Context) {
+	task, err := api.store.GetTask(c.Param("id"))
+	if err == nil {
+		if status, _ := api.store.taskStatus(task.ID); status != TaskStatusPending {
+			err = ErrTaskNotPending
+		}
+	}
+	if err != nil {
+		var taskErr *TaskError
+		errors.As(err, &taskErr)
+		respondError(c, taskStateErrorStatus(err), ErrorResponse{
+			Error:   taskErr.Error(),
+			Code:    taskErr.Code,
+			Message: taskErr.Message,
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), RetryTimeout)
+	defer cancel()
+	result, err := api.assigner.AssignTask(ctx, task)
+	// Lost a race with a worker or a hold: nothing was attempted
+	if errors.Is(err, ErrTaskNotPending) {
+		respondError(c, http.StatusConflict, ErrorResponse{
+			Error:   ErrTaskNotPending.Error(),
+			Code:    ErrTaskNotPending.Code,
+			Message: ErrTaskNotPending.Message,
+		})
+		return
+	}
+	api.workerPool.notify(task.ID, result, err)
+
+	var resp RetryResponse
+	if err == nil {
+		resp.Assigned = true
+		resp.EmployeeID = result.EmployeeID
+		resp.DistanceKm = result.Distance
+	} else {
+		if result != nil && result.Error != nil {
+			err = result.Error
+		}
+		var taskErr *TaskError
+		if errors.As(err, &taskErr) {
+			resp.Code = taskErr.Code
+		}
+		resp.Message = err.Error()
+		if result != nil {
+			resp.Diagnostics = result.Diagnostics
+		}
+	}
+	api.store.mu.RLock()
+	resp.Task = task.snapshot()
+	api.store.mu.RUnlock()
+
+	message := "Task assigned"
+	if !resp.Assigned {
+		message = "Retry failed to assign task"
+	}
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: message,
+		Data:    resp,
+	})
+}
+
+// FailTaskRequest represents the optional body for manually failing a task
+type FailTaskRequest struct {
+	Reason string `json:"reason"`
+}
+
+// handleFailTask handles POST /tasks/:id/fail
+// Fails the task with an optional reason; an assigned employee is freed
+func (api *API) handleFailTask(c *gin.Context) {
+	taskID := c.Param("id")
+
+	var req FailTaskRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid request body",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	freed, err := api.store.FailTask(taskID, strings.TrimSpace(req.Reason))
+	if err != nil {
+		var taskErr *TaskError
+		errors.As(err, &taskErr)
+		respondError(c, taskStateErrorStatus(err), ErrorResponse{
+			Error:   taskErr.Error(),
+			Code:    taskErr.Code,
+			Message: taskErr.Message,
+		})
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: "Task marked as failed",
+		Data: gin.H{
+			"id":                taskID,
+			"status":            TaskStatusFailed,
+			"freed_employee_id": freed,
+		},
+	})
+}
+
+// handleGetTaskReceipt handles GET /tasks/:id/receipt
+func (api *API) handleGetTaskReceipt(c *gin.Context) {
+	receipt, err := api.store.TaskReceipt(c.Param("id"))
+	if err != nil {
+		if taskErr, ok := err.(*TaskError); ok {
+			respondError(c, http.StatusNotFound, ErrorResponse{
+				Error:   taskErr.Error(),
+				Code:    taskErr.Code,
+				Message: taskErr.Message,
+			})
+			return
+		}
+		respondError(c, http.StatusInternalServerError, ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: "Task receipt retrieved successfully",
+		Data:    receipt,
+	})
+}
+
+// handleGetTaskRationale handles GET /tasks/:id/rationale
+// Explains the committed assignment; 409 TASK_NOT_ASSIGNED if there is none
+func (api *API) handleGetTaskRationale(c *gin.Context) {
+	rationale, err := api.store.TaskRationale(c.Param("id"))
+	if err != nil {
+		var taskErr *TaskError
+		errors.As(err, &taskErr)
+		respondError(c, taskStateErrorStatus(err), ErrorResponse{
+			Error:   taskErr.Error(),
+			Code:    taskErr.Code,
+			Message: taskErr.Message,
+		})
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: "Assignment rationale retrieved successfully",
+		Data:    rationale,
+	})
+}
+
+// MaxResultWait caps how long GET /tasks/:id/result may block
+const MaxResultWait = 30 * time.Second
+
+// MaxCreateWait caps the optional synchronous wait in POST /tasks
+const MaxCreateWait = time.Second
+
+// parseWait reads the optional wait query parameter, capped at max
+// On an invalid value it writes a 400 response and returns false
+func parseWait(c *gin.Context, max time.Duration) (time.Duration, bool) {
+	v := c.Query("wait")
+	if v == "" {
+		return 0, true
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid wait",
+			Code:    "INVALID_WAIT",
+			Message: "wait must be a non-negative duration such as 200ms",
+		})
+		return 0, false
+	}
+	return min(d, max), true
+}
+
+// TaskResultResponse is the payload for GET /tasks/:id/result
+type TaskResultResponse struct {
+	Task      Task `json:"task"`
+	Completed bool `json:"completed"`
+}
+
+// handleGetTaskResult handles GET /tasks/:id/result?wait=5s
+// Long-polls until the task is assigned or failed, or the wait elapses
+func (api *API) handleGetTaskResult(c *gin.Context) {
+	wait, ok := parseWait(c, MaxResultWait)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), wait)
+	defer cancel()
+
+	task, completed, err := api.store.WaitForTerminal(ctx, c.Param("id"))
+	if err != nil {
+		if taskErr, ok := err.(*TaskError); ok {
+			respondError(c, http.StatusNotFound, ErrorResponse{
+				Error:   taskErr.Error(),
+				Code:    taskErr.Code,
+				Message: taskErr.Message,
+			})
+			return
+		}
+		respondError(c, http.StatusInternalServerError, ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	message := "Task result retrieved successfully"
+	if !completed {
+		message = "Task still pending"
+	}
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: message,
+		Data:    TaskResultResponse{Task: task, Completed: completed},
+	})
+}
+
+// handleGetFailedWebhooks handles GET /webhooks/failed
+func (api *API) handleGetFailedWebhooks(c *gin.Context) {
+	failed := []FailedDelivery{}
+	if api.notifier != nil {
+		failed = api.notifier.FailedDeliveries()
+	}
+
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("Retrieved %d failed webhook deliveries", len(failed)),
+		Data:    failed,
+	})
+}
+
+// handleGetEmployeeWorkload handles GET /employees/workload
+func (api *API) handleGetEmployeeWorkload(c *gin.Context) {
+	employees := api.store.EmployeesByWorkload()
+
+	respondCollection(c, http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("Retrieved %d employees by workload", len(employees)),
+		Data:    employees,
+	})
+}
+
+// handleGetBusyEmployees handles GET /employees/busy
+// Lists employees who are unavailable or at capacity, with their assigned tasks
+func (api *API) handleGetBusyEmployees(c *gin.Context) {
+	employees := api.store.BusyEmployees()
+
+	respondCollection(c, http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("Retrieved %d busy employees", len(employees)),
+		Data:    employees,
+	})
+}
+
+// handleGetEmployeeMetrics handles GET /employees/:id/metrics
+func (api *API) handleGetEmployeeMetrics(c *gin.Context) {
+	employeeID := c.Param("id")
+
+	if _, err := api.store.GetEmployee(employeeID); err != nil {
+		if taskErr, ok := err.(*TaskError); ok {
+			respondError(c, http.StatusNotFound, ErrorResponse{
+				Error:   taskErr.Error(),
+				Code:    taskErr.Code,
+				Message: taskErr.Message,
+			})
+			return
+		}
+		respondError(c, http.StatusInternalServerError, ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: "Employee metrics retrieved successfully",
+		Data:    api.assigner.Metrics().Get(employeeID),
+	})
+}
+
+// handleGetNearbyEmployees handles GET /employees/nearby?lat=...&lon=...&skill=...
+// Lists employees nearest first; include_unavailable=true also lists busy or
+// reserved ones, flagged so a dispatcher can decide to wait for someone closer
+func (api *API) handleGetNearbyEmployees(c *gin.Context) {
+	var coords [2]float64
+	for i, name := range []string{"lat", "lon"} {
+		v, err := strconv.ParseFloat(c.Query(name), 64)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid coordinates",
+				Code:    "INVALID_COORDINATES",
+				Message: fmt.Sprintf("%s must be a number", name),
+			})
+			return
+		}
+		coords[i] = v
+	}
+	origin := Location{Lat: coords[0], Lon: coords[1]}
+	if err := origin.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid coordinates",
+			Code:    "INVALID_COORDINATES",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	limit := DefaultNearbyLimit
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			respondError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid limit",
+				Code:    "INVALID_LIMIT",
+				Message: "limit must be a positive integer",
+			})
+			return
+		}
+		limit = n
+	}
+
+	skill := normalizeSkill(c.Query("skill"))
+	nearby := api.store.NearbyEmployees(origin, skill, limit, c.Query("include_unavailable") == "true")
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("Found %d nearby employees", len(nearby)),
+		Data:    nearby,
+	})
+}
+
+// EligibleTasksResponse is the payload for GET /employees/:id/eligible-tasks
+type EligibleTasksResponse struct {
+	EmployeeID string  `json:"employee_id"`
+	Count      int     `json:"count"`
+	Tasks      []*Task `json:"tasks,omitempty"`
+}
+
+// handleGetEligibleTasks handles GET /employees/:id/eligible-tasks
+// Returns how many pending tasks the employee could serve; ?include_tasks=true adds the list
+func (api *API) handleGetEligibleTasks(c *gin.Context) {
+	employeeID := c.Param("id")
+
+	tasks, err := api.assigner.EligibleTasksForEmployee(employeeID)
+	if err != nil {
+		if taskErr, ok := err.(*TaskError); ok {
+			respondError(c, http.StatusNotFound, ErrorResponse{
+				Error:   taskErr.Error(),
+				Code:    taskErr.Code,
+				Message: taskErr.Message,
+			})
+			return
+		}
+		respondError(c, http.StatusInternalServerError, ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	response := EligibleTasksResponse{EmployeeID: employeeID, Count: len(tasks)}
+	if c.Query("include_tasks") == "true" {
+		response.Tasks = tasks
+	}
+
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("Employee is eligible for %d pending tasks", len(tasks)),
+		Data:    response,
+	})
+}
+
+// ReserveEmployeeRequest represents the request body for reserving an employee
+type ReserveEmployeeRequest struct {
+	// Duration is a Go duration (e.g. "45m") after which the reservation lapses
+	Duration string `json:"duration" binding:"required"`
+}
+
+// handleReserveEmployee handles POST /employees/:id/reserve
+// The employee is excluded from assignment until the reservation expires or is released
+func (api *API) handleReserveEmployee(c *gin.Context) {
+	employeeID := c.Param("id")
+
+	var req ReserveEmployeeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+	d, err := time.ParseDuration(req.Duration)
+	if err != nil || d <= 0 || d > MaxReservation {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid duration",
+			Code:    "INVALID_DURATION",
+			Message: fmt.Sprintf("duration must be a positive Go duration up to %v", MaxReservation),
+		})
+		return
+	}
+
+	until, err := api.store.ReserveEmployee(employeeID, d)
+	if err != nil {
+		var taskErr *TaskError
+		errors.As(err, &taskErr)
+		respondError(c, taskStateErrorStatus(err), ErrorResponse{
+			Error:   taskErr.Error(),
+			Code:    taskErr.Code,
+			Message: taskErr.Message,
+		})
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: "Employee reserved",
+		Data:    gin.H{"id": employeeID, "reserved_until": until},
+	})
+}
+
+// handleUnreserveEmployee handles POST /employees/:id/unreserve
+func (api *API) handleUnreserveEmployee(c *gin.Context) {
+	employeeID := c.Param("id")
+
+	if err := api.store.UnreserveEmployee(employeeID); err != nil {
+		var taskErr *TaskError
+		errors.As(err, &taskErr)
+		respondError(c, taskStateErrorStatus(err), ErrorResponse{
+			Error:   taskErr.Error(),
+			Code:    taskErr.Code,
+			Message: taskErr.Message,
+		})
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: "Employee reservation released",
+		Data:    gin.H{"id": employeeID},
+	})
+}
+
+// handleDeleteEmployee handles DELETE /employees/:id
+// The employee is soft-deleted and can be brought back with POST /employees/:id/restore
+func (api *API) handleDeleteEmployee(c *gin.Context) {
+	employee, err := api.store.DeleteEmployee(c.Param("id"))
+	if err != nil {
+		var taskErr *TaskError
+		errors.As(err, &taskErr)
+		respondError(c, taskStateErrorStatus(err), ErrorResponse{
+			Error:   taskErr.Error(),
+			Code:    taskErr.Code,
+			Message: taskErr.Message,
+		})
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: "Employee deleted",
+		Data:    employee,
+	})
 }
 
-// ErrorResponse represents an API error response
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Code    string `json:"code,omitempty"`
-	Message string `json:"message,omitempty"`
-}
+// handleRestoreEmployee handles POST /employees/:id/restore
+func (api *API) handleRestoreEmployee(c *gin.Context) {
+	employee, err := api.store.RestoreEmployee(c.Param("id"))
+	if err != nil {
+		var taskErr *TaskError
+		errors.As(err, &taskErr)
+		respondError(c, employeeErrorStatus(err), ErrorResponse{
+			Error:   taskErr.Error(),
+			Code:    taskErr.Code,
+			Message: taskErr.Message,
+		})
+		return
+	}
 
-// SuccessResponse represents a generic success response
-type SuccessResponse struct {
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: "Employee restored",
+		Data:    employee,
+	})
 }
 
-// CreateEmployeeRequest represents the request body for creating an employee
-type CreateEmployeeRequest struct {
-	Name     string   `json:"name" binding:"required"`
-	Location Location `json:"location" binding:"required"`
-	Skills   []string `json:"skills" binding:"required"`
+// handlePurgeEmployees handles POST /admin/employees/purge
+// Soft-deleted employees are removed for good and cannot be restored
+func (api *API) handlePurgeEmployees(c *gin.Context) {
+	purged := api.store.PurgeDeletedEmployees()
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("Purged %d deleted employees", len(purged)),
+		Data:    gin.H{"purged": purged},
+	})
 }
 
-// CreateTaskRequest represents the request body for creating a task
-type CreateTaskRequest struct {
-	Location      Location `json:"location" binding:"required"`
-	RequiredSkill string   `json:"required_skill" binding:"required"`
+// handleRebalance handles POST /admin/rebalance
+// Moves assigned tasks from overloaded employees to idle ones
+func (api *API) handleRebalance(c *gin.Context) {
+	var req RebalanceRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid request body",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+	threshold := req.Threshold
+	if threshold == 0 {
+		threshold = DefaultRebalanceThreshold
+	}
+
+	report := api.assigner.Rebalance(threshold)
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("Moved %d tasks", len(report.Moves)),
+		Data:    report,
+	})
 }
 
-// handleCreateEmployee handles POST /employees
-func (api *API) handleCreateEmployee(c *gin.Context) {
-	var req CreateEmployeeRequest
+// handleSetStrategy handles PUT /admin/strategy
+// Swaps the default assignment strategy for assignments that start from now on
+func (api *API) handleSetStrategy(c *gin.Context) {
+	var req StrategyRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		respondError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "Invalid request body",
 			Message: err.Error(),
 		})
 		return
 	}
+	strategy, err := strategyFromConfig(api.config, req.Name)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid strategy",
+			Code:    "INVALID_STRATEGY",
+			Message: err.Error(),
+		})
+		return
+	}
 
-	// Generate unique ID for the employee
-	employee := &Employee{
-		ID:          uuid.New().String(),
-		Name:        req.Name,
-		Location:    req.Location,
-		Skills:      req.Skills,
-		IsAvailable: true,
+	previous := api.assigner.Strategy()
+	api.assigner.SetStrategy(strategy)
+	log.Printf("Assignment strategy changed from %s to %s", previous.Name(), strategy.Name())
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("Assignment strategy set to %s", strategy.Name()),
+		Data:    gin.H{"strategy": strategy.Name(), "previous": previous.Name()},
+	})
+}
+
+// handleOptimizeAssignments handles POST /assign/optimize
+// Assigns a batch of pending tasks together, closest pairs first
+func (api *API) handleOptimizeAssignments(c *gin.Context) {
+	var req OptimizeRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid request body",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+	if req.SortBy == "" {
+		req.SortBy = BatchSortTaskID
+	}
+	if !validBatchSort(req.SortBy) {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid sort_by",
+			Code:    "INVALID_SORT",
+			Message: fmt.Sprintf("sort_by must be %q or %q", BatchSortTaskID, BatchSortDistance),
+		})
+		return
 	}
 
-	// Validate employee data
-	if err := employee.Validate(); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Validation failed",
+	report := newOptimizeReport(api.assigner.OptimizeAssignments(req.TaskIDs), req.SortBy)
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("Assigned %d of %d tasks", report.Assigned, len(report.Results)),
+		Data:    report,
+	})
+}
+
+// handleGetTeamEmployees handles GET /teams/:id/employees
+func (api *API) handleGetTeamEmployees(c *gin.Context) {
+	teamID := normalizeTeamID(c.Param("id"))
+	employees := api.store.EmployeesByTeam(teamID)
+
+	respondCollection(c, http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("Retrieved %d employees in team %s", len(employees), teamID),
+		Data:    employees,
+	})
+}
+
+// handleUpdateEmployeeLocation handles PUT /employees/:id/location
+func (api *API) handleUpdateEmployeeLocation(c *gin.Context) {
+	employeeID := c.Param("id")
+
+	var loc Location
+	if err := c.ShouldBindJSON(&loc); err != nil {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
 			Message: err.Error(),
 		})
 		return
 	}
 
-	if err := api.store.AddEmployee(employee); err != nil {
+	if err := api.store.UpdateEmployeeLocation(employeeID, loc); err != nil {
 		if taskErr, ok := err.(*TaskError); ok {
-			c.JSON(http.StatusConflict, ErrorResponse{
+			respondError(c, http.StatusNotFound, ErrorResponse{
 				Error:   taskErr.Error(),
 				Code:    taskErr.Code,
 				Message: taskErr.Message,
 			})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: err.Error(),
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Message: err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusCreated, SuccessResponse{
-		Message: "Employee created successfully",
-		Data:    employee,
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: "Employee location updated successfully",
+		Data:    loc,
 	})
 }
 
-// handleCreateTask handles POST /tasks
-func (api *API) handleCreateTask(c *gin.Context) {
-	var req CreateTaskRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+// LocationUpdateResult is the outcome of one entry in POST /employees/locations
+type LocationUpdateResult struct {
+	ID      string `json:"id"`
+	Status  int    `json:"status"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// handleBulkUpdateLocations handles POST /employees/locations
+// Applies a batch of GPS positions under one store lock and reports each entry's outcome
+func (api *API) handleBulkUpdateLocations(c *gin.Context) {
+	var updates []LocationUpdate
+	if err := c.ShouldBindJSON(&updates); err != nil {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "Invalid request body",
 			Message: err.Error(),
 		})
 		return
 	}
-
-	// Generate unique ID for the task
-	task := &Task{
-		ID:            uuid.New().String(),
-		Location:      req.Location,
-		RequiredSkill: req.RequiredSkill,
-		Status:        TaskStatusPending,
-	}
-
-	// Validate task data
-	if err := task.Validate(); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Validation failed",
-			Message: err.Error(),
+	if len(updates) == 0 {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: "at least one location update is required",
 		})
 		return
 	}
 
-	// CRITICAL: Submit to queue FIRST to check capacity
-	// This prevents orphaned tasks in store if queue is full
-	if err := api.workerPool.SubmitTask(task); err != nil {
-		// Queue is full, reject request immediately
-		if taskErr, ok := err.(*TaskError); ok && taskErr.Code == "QUEUE_FULL" {
-			c.JSON(http.StatusServiceUnavailable, ErrorResponse{
-				Error:   "System at capacity",
-				Code:    "QUEUE_FULL",
-				Message: "Worker pool is full. Please retry in a few seconds.",
-			})
-			return
+	updated := 0
+	results := make([]LocationUpdateResult, len(updates))
+	for i, err := range api.store.UpdateEmployeeLocations(updates) {
+		result := LocationUpdateResult{ID: updates[i].ID, Status: http.StatusOK}
+		var taskErr *TaskError
+		switch {
+		case err == nil:
+			updated++
+		case errors.As(err, &taskErr):
+			result.Status = http.StatusNotFound
+			result.Code = taskErr.Code
+			result.Message = taskErr.Message
+		default:
+			result.Status = http.StatusBadRequest
+			result.Code = "INVALID_COORDINATES"
+			result.Message = err.Error()
 		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: err.Error(),
-		})
-		return
+		results[i] = result
 	}
 
-	// Only add to store AFTER successful queue submission
-	if err := api.store.AddTask(task); err != nil {
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("Updated %d of %d employee locations", updated, len(updates)),
+		Data:    results,
+	})
+}
+
+// handleGetLocationHistory handles GET /employees/:id/location-history
+func (api *API) handleGetLocationHistory(c *gin.Context) {
+	history, err := api.store.LocationHistory(c.Param("id"))
+	if err != nil {
 		if taskErr, ok := err.(*TaskError); ok {
-			c.JSON(http.StatusConflict, ErrorResponse{
+			respondError(c, http.StatusNotFound, ErrorResponse{
 				Error:   taskErr.Error(),
 				Code:    taskErr.Code,
 				Message: taskErr.Message,
 			})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		respondError(c, http.StatusInternalServerError, ErrorResponse{
 			Error: err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusCreated, SuccessResponse{
-		Message: "Task created and assignment initiated",
-		Data:    task,
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("Retrieved %d location records", len(history)),
+		Data:    history,
 	})
 }
 
-// handleGetTasks handles GET /tasks
-func (api *API) handleGetTasks(c *gin.Context) {
-	tasks := api.store.GetAllTasks()
+// StatsResponse is the payload for GET /stats
+type StatsResponse struct {
+	Employees          int                  `json:"employees"`
+	AvailableEmployees int                  `json:"available_employees"`
+	Tasks              map[TaskStatus]int   `json:"tasks"`
+	QueueDepth         int                  `json:"queue_depth"`
+	SpilloverDepth     int                  `json:"spillover_depth"`
+	ActiveWorkers      int                  `json:"active_workers"`
+	CircuitBreaker     *CircuitBreakerStats `json:"circuit_breaker,omitempty"`
+}
 
-	c.JSON(http.StatusOK, SuccessResponse{
-		Message: fmt.Sprintf("Retrieved %d tasks", len(tasks)),
-		Data:    tasks,
+// collectStats gathers the current store and worker pool figures
+func (api *API) collectStats() StatsResponse {
+	stats := StatsResponse{
+		Tasks:         make(map[TaskStatus]int),
+		QueueDepth:    api.workerPool.taskQueue.Len(),
+		ActiveWorkers: api.workerPool.ActiveWorkers(),
+	}
+	if api.workerPool.spillover != nil {
+		stats.SpilloverDepth = api.workerPool.spillover.Len()
+	}
+
+	api.store.mu.RLock()
+	stats.Employees = len(api.store.employees)
+	now := api.store.clock.Now()
+	for _, emp := range api.store.employees {
+		if emp.assignableAt(now) {
+			stats.AvailableEmployees++
+		}
+	}
+	for _, task := range api.store.tasks {
+		stats.Tasks[task.Status]++
+	}
+	api.store.mu.RUnlock()
+	if api.workerPool.breaker != nil {
+		breaker := api.workerPool.breaker.Stats()
+		stats.CircuitBreaker = &breaker
+	}
+	return stats
+}
+
+// handleGetStats handles GET /stats
+func (api *API) handleGetStats(c *gin.Context) {
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: "Stats retrieved successfully",
+		Data:    api.collectStats(),
 	})
 }
 
-// handleGetTaskByID handles GET /tasks/:id
-func (api *API) handleGetTaskByID(c *gin.Context) {
-	taskID := c.Param("id")
+// SummaryResponse is the payload for GET /summary
+type SummaryResponse struct {
+	Employees          int `json:"employees"`
+	AvailableEmployees int `json:"available_employees"`
+	// Tasks has an entry for every status, zero included
+	Tasks      map[TaskStatus]int `json:"tasks"`
+	TotalTasks int                `json:"total_tasks"`
+	// QueueDepth counts tasks waiting in the primary and spillover queues
+	QueueDepth    int `json:"queue_depth"`
+	ActiveWorkers int `json:"active_workers"`
+	// Processing counts tasks a worker has picked up but not yet resolved
+	Processing int `json:"processing"`
+}
 
-	task, err := api.store.GetTask(taskID)
-	if err != nil {
-		if taskErr, ok := err.(*TaskError); ok {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   taskErr.Error(),
-				Code:    taskErr.Code,
-				Message: taskErr.Message,
+// handleGetSummary handles GET /summary
+// One call for a dashboard: the headline figures from /stats with every status
+// listed, under a single short store read lock
+func (api *API) handleGetSummary(c *gin.Context) {
+	stats := api.collectStats()
+	summary := SummaryResponse{
+		Employees:          stats.Employees,
+		AvailableEmployees: stats.AvailableEmployees,
+		Tasks:              make(map[TaskStatus]int, len(TaskStatuses)),
+		QueueDepth:         stats.QueueDepth + stats.SpilloverDepth,
+		ActiveWorkers:      stats.ActiveWorkers,
+		Processing:         api.workerPool.processingCount(),
+	}
+	for _, status := range TaskStatuses {
+		summary.Tasks[status] = stats.Tasks[status]
+		summary.TotalTasks += stats.Tasks[status]
+	}
+
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: "Summary retrieved successfully",
+		Data:    summary,
+	})
+}
+
+// handleGetMetrics handles GET /metrics
+// Exposes the core counters and gauges in the Prometheus text format
+func (api *API) handleGetMetrics(c *gin.Context) {
+	counters := api.store.TaskCounters()
+	stats := api.collectStats()
+
+	byStatus := make([]promSample, 0, len(TaskStatuses))
+	for _, status := range TaskStatuses {
+		byStatus = append(byStatus, promSample{labels: [][2]string{{"status", string(status)}}, value: float64(stats.Tasks[status])})
+	}
+
+	families := []promFamily{
+		{name: "task_assignment_tasks_created_total", help: "Tasks created since process start.", kind: "counter",
+			samples: []promSample{{value: float64(counters.Created)}}},
+		{name: "task_assignment_tasks_assigned_total", help: "Task assignments committed since process start.", kind: "counter",
+			samples: []promSample{{value: float64(counters.Assigned)}}},
+		{name: "task_assignment_tasks_failed_total", help: "Task failures since process start.", kind: "counter",
+			samples: []promSample{{value: float64(counters.Failed)}}},
+		{name: "task_assignment_tasks", help: "Tasks currently in the store by status.", kind: "gauge",
+			samples: byStatus},
+		{name: "task_assignment_queue_depth", help: "Tasks waiting in the assignment queues.", kind: "gauge",
+			samples: []promSample{
+				{labels: [][2]string{{"queue", "primary"}}, value: float64(stats.QueueDepth)},
+				{labels: [][2]string{{"queue", "spillover"}}, value: float64(stats.SpilloverDepth)},
+			}},
+		{name: "task_assignment_employees", help: "Employees currently in the store.", kind: "gauge",
+			samples: []promSample{{value: float64(stats.Employees)}}},
+		{name: "task_assignment_available_employees", help: "Employees currently available for assignment.", kind: "gauge",
+			samples: []promSample{{value: float64(stats.AvailableEmployees)}}},
+		{name: "task_assignment_active_workers", help: "Running assignment workers.", kind: "gauge",
+			samples: []promSample{{value: float64(stats.ActiveWorkers)}}},
+	}
+
+	c.Header("Content-Type", PrometheusContentType)
+	c.Status(http.StatusOK)
+	if err := writePrometheus(c.Writer, families); err != nil {
+		log.Printf("Failed to write metrics: %v", err)
+	}
+}
+
+// handleGetPendingCentroid handles GET /stats/pending-centroid
+// Returns 204 No Content when there are no pending tasks
+func (api *API) handleGetPendingCentroid(c *gin.Context) {
+	centroid, ok := api.store.PendingCentroid()
+	if !ok {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("Centroid of %d pending tasks", centroid.Count),
+		Data:    centroid,
+	})
+}
+
+// handleGetCoverage handles GET /stats/coverage?skill=...&grid=...&min_lat=...
+// Reports grid cells where pending demand for the skill exceeds available supply
+func (api *API) handleGetCoverage(c *gin.Context) {
+	skill := strings.TrimSpace(c.Query("skill"))
+	if skill == "" {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Missing skill",
+			Code:    "INVALID_SKILL",
+			Message: "skill query parameter is required",
+		})
+		return
+	}
+
+	grid := DefaultCoverageGrid
+	if v := c.Query("grid"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > MaxCoverageGrid {
+			respondError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid grid",
+				Code:    "INVALID_GRID",
+				Message: fmt.Sprintf("grid must be an integer between 1 and %d", MaxCoverageGrid),
 			})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: err.Error(),
+		grid = n
+	}
+
+	box, ok := parseBoundingBox(c)
+	if !ok {
+		return
+	}
+
+	report := api.store.CoverageGaps(skill, box, grid)
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("Found %d under-served cells", len(report.Gaps)),
+		Data:    report,
+	})
+}
+
+// handleGetGraph handles GET /graph?format=json|dot
+// Returns the current employee→task assignments; format=dot renders Graphviz
+func (api *API) handleGetGraph(c *gin.Context) {
+	format := strings.ToLower(c.DefaultQuery("format", "json"))
+	if format != "json" && format != "dot" {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid format",
+			Code:    "INVALID_FORMAT",
+			Message: "format must be json or dot",
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, SuccessResponse{
-		Message: "Task retrieved successfully",
-		Data:    task,
+	graph := api.store.AssignmentGraph()
+	if format == "dot" {
+		c.Data(http.StatusOK, "text/vnd.graphviz; charset=utf-8", []byte(graph.DOT()))
+		return
+	}
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: fmt.Sprintf("Graph has %d nodes and %d edges", len(graph.Nodes), len(graph.Edges)),
+		Data:    graph,
 	})
 }
 
-// handleGetEmployees handles GET /employees
-func (api *API) handleGetEmployees(c *gin.Context) {
-	api.store.mu.RLock()
-	employees := make([]*Employee, 0, len(api.store.employees))
-	for _, emp := range api.store.employees {
-		employees = append(employees, emp)
+// handleGetLatencyStats handles GET /stats/latency
+func (api *API) handleGetLatencyStats(c *gin.Context) {
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: "Transition latency retrieved successfully",
+		Data:    api.store.TransitionLatency(),
+	})
+}
+
+// ValidationResponse is the payload for the POST /validate/* endpoints
+// Normalized previews what would be stored and is only set when Valid
+type ValidationResponse struct {
+	Valid      bool              `json:"valid"`
+	Errors     []ValidationIssue `json:"errors"`
+	Normalized any               `json:"normalized,omitempty"`
+}
+
+// ValidationIssue describes one failed check
+type ValidationIssue struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// newValidationResponse turns a Validate result into a ValidationResponse
+func newValidationResponse(err error, normalized any) ValidationResponse {
+	if err == nil {
+		return ValidationResponse{Valid: true, Errors: []ValidationIssue{}, Normalized: normalized}
 	}
-	api.store.mu.RUnlock()
+	issue := ValidationIssue{Message: err.Error()}
+	var fieldErr *FieldError
+	if errors.As(err, &fieldErr) {
+		issue.Field = fieldErr.Field
+	}
+	return ValidationResponse{Errors: []ValidationIssue{issue}}
+}
 
-	c.JSON(http.StatusOK, SuccessResponse{
-		Message: fmt.Sprintf("Retrieved %d employees", len(employees)),
-		Data:    employees,
+// handleValidateEmployee handles POST /validate/employee
+// Runs employee validation and normalization without storing anything
+func (api *API) handleValidateEmployee(c *gin.Context) {
+	var req CreateEmployeeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	employee := req.toEmployee("")
+	response := newValidationResponse(employee.Validate(), employee)
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: "Employee payload validated",
+		Data:    response,
+	})
+}
+
+// handleValidateTask handles POST /validate/task
+// Runs task validation and normalization without storing or queueing anything
+func (api *API) handleValidateTask(c *gin.Context) {
+	var req CreateTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	task := req.toTask("")
+	response := newValidationResponse(task.Validate(), task)
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: "Task payload validated",
+		Data:    response,
+	})
+}
+
+// DistanceResponse is the payload for GET /distance
+type DistanceResponse struct {
+	From     Location     `json:"from"`
+	To       Location     `json:"to"`
+	Distance float64      `json:"distance"`
+	Unit     DistanceUnit `json:"unit"`
+	// BearingDeg and Direction are only set with ?bearing=true
+	BearingDeg *float64 `json:"bearing_deg,omitempty"`
+	Direction  string   `json:"direction,omitempty"`
+}
+
+// handleGetDistance handles GET /distance?lat1=&lon1=&lat2=&lon2=
+// Returns the Haversine distance between two points in the configured unit;
+// with ?bearing=true also the initial compass bearing from the first to the second
+func (api *API) handleGetDistance(c *gin.Context) {
+	var coords [4]float64
+	for i, name := range []string{"lat1", "lon1", "lat2", "lon2"} {
+		v, err := strconv.ParseFloat(c.Query(name), 64)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid coordinates",
+				Code:    "INVALID_COORDINATES",
+				Message: fmt.Sprintf("%s must be a number", name),
+			})
+			return
+		}
+		coords[i] = v
+	}
+
+	from := Location{Lat: coords[0], Lon: coords[1]}
+	to := Location{Lat: coords[2], Lon: coords[3]}
+	for _, loc := range []Location{from, to} {
+		if err := loc.Validate(); err != nil {
+			respondError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid coordinates",
+				Code:    "INVALID_COORDINATES",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	resp := DistanceResponse{
+		From:     from,
+		To:       to,
+		Distance: api.distanceUnit.FromKm(CalculateDistance(from, to)),
+		Unit:     api.distanceUnit,
+	}
+	if c.Query("bearing") == "true" {
+		bearing := CalculateBearing(from, to)
+		resp.BearingDeg = &bearing
+		resp.Direction = CompassDirection(bearing)
+	}
+
+	respondSuccess(c, http.StatusOK, SuccessResponse{
+		Message: "Distance calculated successfully",
+		Data:    resp,
 	})
 }
 
 // handleHealthCheck handles GET /health
 func (api *API) handleHealthCheck(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
+	writeJSON(c, http.StatusOK, gin.H{
 		"status": "healthy",
 		"time":   time.Now().UTC(),
 	})
 }
 
+// handleSnapshotHealth handles GET /health/snapshot
+// Reports the last successful snapshot; 503 when it is older than SNAPSHOT_MAX_AGE
+func (api *API) handleSnapshotHealth(c *gin.Context) {
+	if api.snapshotDir == "" {
+		writeJSON(c, http.StatusOK, SnapshotHealth{Status: SnapshotDisabled})
+		return
+	}
+
+	health := api.snapshots.health(api.store.clock.Now(), api.startedAt, api.snapshotMaxAge)
+	status := http.StatusOK
+	if health.Status == SnapshotDegraded {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(c, status, health)
+}
+
 // setupRouter configures all routes
 func (api *API) setupRouter() *gin.Engine {
 	router := gin.Default()
@@ -261,17 +2500,88 @@ func (api *API) setupRouter() *gin.Engine {
 		c.Next()
 	})
 
+	// Compression sits outside the deadline so a timeout's 503 goes through it too
+	router.Use(compress(api.config.Compression, api.config.CompressionMinBytes, streamingRoutes...))
+
+	// Per-request deadline; streaming ingest, exports and long-polls manage their own duration
+	router.Use(clearDeadlines(streamingRoutes...))
+	router.Use(requestTimeout(api.config.RequestTimeout, streamingRoutes...))
+
 	// Health check endpoint
 	router.GET("/health", api.handleHealthCheck)
+	router.GET("/health/snapshot", api.handleSnapshotHealth)
 
 	// Employee endpoints
 	router.POST("/employees", api.handleCreateEmployee)
+	router.POST("/employees/batch", api.handleCreateEmployees)
 	router.GET("/employees", api.handleGetEmployees)
+	router.GET("/employees/export.ndjson", api.handleExportEmployees)
+	router.GET("/employees/workload", api.handleGetEmployeeWorkload)
+	router.GET("/employees/busy", api.handleGetBusyEmployees)
+	router.GET("/employees/nearby", api.handleGetNearbyEmployees)
+	router.POST("/employees/locations", api.handleBulkUpdateLocations)
+	router.GET("/employees/:id/metrics", api.handleGetEmployeeMetrics)
+	router.PUT("/employees/:id/location", api.handleUpdateEmployeeLocation)
+	router.GET("/employees/:id/location-history", api.handleGetLocationHistory)
+	router.GET("/employees/:id/eligible-tasks", api.handleGetEligibleTasks)
+	router.POST("/employees/:id/reserve", api.handleReserveEmployee)
+	router.POST("/employees/:id/unreserve", api.handleUnreserveEmployee)
+	router.DELETE("/employees/:id", api.handleDeleteEmployee)
+	router.POST("/employees/:id/restore", api.handleRestoreEmployee)
 
 	// Task endpoints
 	router.POST("/tasks", api.handleCreateTask)
 	router.GET("/tasks", api.handleGetTasks)
+	router.GET("/tasks/export.ndjson", api.handleExportTasks)
+	router.POST("/tasks/reprocess", api.handleReprocessTasks)
+	router.POST("/tasks/cancel", api.handleCancelTasks)
+	router.GET("/tasks/processing", api.handleGetProcessingTasks)
+	router.POST("/tasks/stream", api.handleStreamTasks)
+	router.GET("/tasks/within", api.handleGetTasksWithin)
+	router.GET("/tasks/blocked", api.handleGetBlockedTasks)
 	router.GET("/tasks/:id", api.handleGetTaskByID)
+	router.GET("/tasks/:id/result", api.handleGetTaskResult)
+	router.GET("/tasks/:id/receipt", api.handleGetTaskReceipt)
+	router.GET("/tasks/:id/rationale", api.handleGetTaskRationale)
+	router.GET("/tasks/:id/can-assign/:employee_id", api.handleCanAssign)
+	router.POST("/tasks/:id/hold", api.handleHoldTask)
+	router.POST("/tasks/:id/release", api.handleReleaseTask)
+	router.POST("/tasks/:id/retry", api.handleRetryTask)
+	router.POST("/tasks/:id/fail", api.handleFailTask)
+
+	// Planning endpoints
+	router.POST("/capacity/estimate", api.handleEstimateCapacity)
+	router.POST("/assign/optimize", api.handleOptimizeAssignments)
+
+	// Utility endpoints
+	router.GET("/distance", api.handleGetDistance)
+	router.POST("/validate/employee", api.handleValidateEmployee)
+	router.POST("/validate/task", api.handleValidateTask)
+
+	// Team endpoints
+	router.GET("/teams/:id/employees", api.handleGetTeamEmployees)
+
+	// Stats endpoints
+	router.GET("/stats", api.handleGetStats)
+	router.GET("/summary", api.handleGetSummary)
+	router.GET("/stats/pending-centroid", api.handleGetPendingCentroid)
+	router.GET("/stats/latency", api.handleGetLatencyStats)
+	router.GET("/stats/coverage", api.handleGetCoverage)
+	router.GET("/metrics", api.handleGetMetrics)
+	router.GET("/graph", api.handleGetGraph)
+
+	// Admin endpoints
+	router.POST("/admin/stress", api.handleStressTest)
+	router.POST("/admin/workers", api.handleResizeWorkers)
+	router.POST("/admin/snapshot", api.handleSnapshot)
+	router.POST("/admin/restore", api.handleRestore)
+	router.POST("/admin/replay", api.handleReplay)
+	router.POST("/admin/employees/purge", api.handlePurgeEmployees)
+	router.POST("/admin/rebalance", api.handleRebalance)
+	router.PUT("/admin/strategy", api.handleSetStrategy)
+
+	// Webhook endpoints
+	router.GET("/webhooks/failed", api.handleGetFailedWebhooks)
 
 	return router
 }
@@ -279,20 +2589,28 @@ func (api *API) setupRouter() *gin.Engine {
 // Start starts the API server and worker pool
 func (api *API) Start(port string) error {
 	// Start worker pool
-	api.workerPool.Start(api.workerPoolCtx)
-	log.Println("Worker pool started with 5 workers")
+	if err := api.workerPool.Start(api.workerPoolCtx); err != nil {
+		return fmt.Errorf("failed to start worker pool: %w", err)
+	}
+	log.Printf("Worker pool started with %d workers", api.workerPool.ActiveWorkers())
+
+	if api.notifier != nil {
+		api.notifier.Start(context.Background())
+		log.Println("Webhook notifier started")
+	}
+
+	api.reaper.Start(context.Background())
+
+	if api.snapshotter != nil {
+		api.snapshotter.Start(context.Background())
+		log.Printf("Periodic snapshots enabled every %v", api.snapshotter.interval)
+	}
 
 	// Setup router
 	router := api.setupRouter()
 
 	// Create HTTP server with timeouts
-	srv := &http.Server{
-		Addr:         ":" + port,
-		Handler:      router,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
-	}
+	srv := api.newServer(port, router)
 
 	// Start server in a goroutine
 	go func() {
@@ -317,6 +2635,17 @@ func (api *API) Start(port string) error {
 	api.workerPool.Shutdown()
 	log.Println("Worker pool shutdown complete")
 
+	if api.notifier != nil {
+		api.notifier.Shutdown()
+		log.Println("Webhook notifier stopped")
+	}
+
+	api.reaper.Shutdown()
+
+	if api.snapshotter != nil {
+		api.snapshotter.Shutdown()
+	}
+
 	// Shutdown HTTP server with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -331,15 +2660,18 @@ func (api *API) Start(port string) error {
 }
 
 func main() {
-	// Get port from environment or use default
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	// Invalid settings fall back to their defaults rather than stopping startup
+	cfg, err := LoadConfig()
+	if err != nil {
+		log.Printf("Using defaults for invalid settings:\n%v", err)
 	}
 
 	// Create and start API
-	api := NewAPI()
-	if err := api.Start(port); err != nil {
+	api, err := NewAPIWithConfig(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := api.Start(cfg.Port); err != nil {
 		log.Fatal(err)
 	}
 }
@@ -2,10 +2,18 @@ package main
 
 import (
 	"bytes"
+	"context"
+	"encoding/gob"
 	"encoding/json"
+	"fmt"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -20,6 +28,17 @@ func setupTestAPI() *API {
 	return NewAPI()
 }
 
+// sequentialIDs is a deterministic IDGenerator for tests
+type sequentialIDs struct {
+	prefix string
+	n      int
+}
+
+func (g *sequentialIDs) NewID() string {
+	g.n++
+	return fmt.Sprintf("%s%d", g.prefix, g.n)
+}
+
 // TestHealthCheckHandler tests the health check endpoint
 func TestHealthCheckHandler(t *testing.T) {
 	api := setupTestAPI()
@@ -446,3 +465,1120 @@ func TestFullWorkflow(t *testing.T) {
 		t.Fatalf("Failed to get tasks: %d", tasksW.Code)
 	}
 }
+
+// TestCreateEmployeeUnavailable tests creating an employee that starts offline
+func TestCreateEmployeeUnavailable(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	bodies := []string{
+		`{"name": "Offline", "location": {"lat": 60.1699, "lon": 24.9384}, "skills": ["delivery"], "is_available": false}`,
+		`{"name": "Default", "location": {"lat": 60.1699, "lon": 24.9384}, "skills": ["delivery"]}`,
+	}
+	for _, body := range bodies {
+		req := httptest.NewRequest("POST", "/employees", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d", w.Code)
+		}
+	}
+
+	available := api.store.GetAvailableEmployees("delivery")
+	if len(available) != 1 {
+		t.Fatalf("Expected 1 available employee, got %d", len(available))
+	}
+	if available[0].Name != "Default" {
+		t.Errorf("Expected only the default employee to be available, got %s", available[0].Name)
+	}
+}
+
+// TestReprocessTasksHandler tests that a failed task is assigned after reprocessing
+func TestReprocessTasksHandler(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+	api.workerPool.Start(api.workerPoolCtx)
+	defer api.workerPool.Shutdown()
+
+	// No employees yet, so the task fails
+	task := &Task{
+		ID:            "task1",
+		Location:      Location{Lat: 60.1700, Lon: 24.9400},
+		RequiredSkill: "delivery",
+	}
+	api.store.AddTask(task)
+	api.store.UpdateTask(task.ID, TaskStatusFailed, "")
+
+	api.store.AddEmployee(&Employee{
+		ID:          "emp1",
+		Name:        "Alice",
+		Location:    Location{Lat: 60.1699, Lon: 24.9384},
+		Skills:      []string{"delivery"},
+		IsAvailable: true,
+	})
+
+	req := httptest.NewRequest("POST", "/tasks/reprocess?include_failed=true", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var response struct {
+		Data ReprocessResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Data.Resubmitted != 1 {
+		t.Errorf("Expected 1 resubmitted task, got %d", response.Data.Resubmitted)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		api.store.mu.RLock()
+		status := task.Status
+		api.store.mu.RUnlock()
+		if status == TaskStatusAssigned {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	got, _ := api.store.GetTask(task.ID)
+	api.store.mu.RLock()
+	defer api.store.mu.RUnlock()
+	if got.Status != TaskStatusAssigned || got.AssignedEmployeeID != "emp1" {
+		t.Errorf("Expected task assigned to emp1, got status=%s employee=%s", got.Status, got.AssignedEmployeeID)
+	}
+}
+
+// TestReprocessSkipsQueuedTasks tests that tasks already in the queue aren't submitted twice
+func TestReprocessSkipsQueuedTasks(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	// Workers are not started, so the task stays queued
+	task := &Task{
+		ID:            "task1",
+		Location:      Location{Lat: 60.1700, Lon: 24.9400},
+		RequiredSkill: "delivery",
+	}
+	api.store.AddTask(task)
+	if err := api.workerPool.SubmitTask(task); err != nil {
+		t.Fatalf("SubmitTask failed: %v", err)
+	}
+
+	req := httptest.NewRequest("POST", "/tasks/reprocess", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var response struct {
+		Data ReprocessResponse `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+
+	if response.Data.Resubmitted != 0 || response.Data.AlreadyQueued != 1 {
+		t.Errorf("Expected 0 resubmitted and 1 already queued, got %+v", response.Data)
+	}
+	if api.workerPool.taskQueue.Len() != 1 {
+		t.Errorf("Expected 1 task in queue, got %d", api.workerPool.taskQueue.Len())
+	}
+}
+
+// TestGetTaskByIDETag tests conditional GET support on task resources
+func TestGetTaskByIDETag(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	task := &Task{
+		ID:            "task1",
+		Location:      Location{Lat: 60.1700, Lon: 24.9400},
+		RequiredSkill: "delivery",
+	}
+	api.store.AddTask(task)
+
+	req := httptest.NewRequest("GET", "/tasks/task1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	etag := w.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("Expected ETag header")
+	}
+
+	// Unchanged task returns 304
+	req = httptest.NewRequest("GET", "/tasks/task1", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotModified {
+		t.Errorf("Expected status 304, got %d", w.Code)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("Expected empty body for 304, got %q", w.Body.String())
+	}
+
+	// A status change invalidates the ETag
+	api.store.UpdateTask("task1", TaskStatusAssigned, "emp1")
+
+	req = httptest.NewRequest("GET", "/tasks/task1", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200 after change, got %d", w.Code)
+	}
+	if w.Header().Get("ETag") == etag {
+		t.Error("Expected ETag to change after task update")
+	}
+}
+
+// TestStreamTasksHandler tests NDJSON task ingest with a malformed line
+func TestStreamTasksHandler(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	body := strings.Join([]string{
+		`{"location": {"lat": 60.1700, "lon": 24.9400}, "required_skill": "delivery"}`,
+		`{"location": {"lat": 60.17`,
+		``,
+		`{"location": {"lat": 60.1800, "lon": 24.9500}}`,
+		`{"location": {"lat": 60.1900, "lon": 24.9600}, "required_skill": "cooking"}`,
+	}, "\n")
+
+	req := httptest.NewRequest("POST", "/tasks/stream", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Errorf("Expected application/x-ndjson, got %s", ct)
+	}
+
+	var results []StreamLineResult
+	for _, line := range strings.Split(strings.TrimSpace(w.Body.String()), "\n") {
+		var result StreamLineResult
+		if err := json.Unmarshal([]byte(line), &result); err != nil {
+			t.Fatalf("Failed to parse result line %q: %v", line, err)
+		}
+		results = append(results, result)
+	}
+
+	want := []struct {
+		line   int
+		status int
+	}{
+		{1, http.StatusCreated},
+		{2, http.StatusBadRequest},
+		{4, http.StatusBadRequest},
+		{5, http.StatusCreated},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("Expected %d results, got %d: %s", len(want), len(results), w.Body.String())
+	}
+	for i, wr := range want {
+		if results[i].Line != wr.line || results[i].Status != wr.status {
+			t.Errorf("Result %d = line %d status %d, want line %d status %d",
+				i, results[i].Line, results[i].Status, wr.line, wr.status)
+		}
+	}
+	if results[0].TaskID == "" || results[3].TaskID == "" {
+		t.Error("Expected task IDs for created lines")
+	}
+
+	if tasks := api.store.GetAllTasks(); len(tasks) != 2 {
+		t.Errorf("Expected 2 tasks in store, got %d", len(tasks))
+	}
+}
+
+// TestResponseEnvelopeToggle tests enveloped and bare responses on GET /tasks/:id
+func TestResponseEnvelopeToggle(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	api.store.AddTask(&Task{
+		ID:            "task1",
+		Location:      Location{Lat: 60.1700, Lon: 24.9400},
+		RequiredSkill: "delivery",
+	})
+
+	t.Run("Enveloped by default", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/tasks/task1", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if response["message"] != "Task retrieved successfully" {
+			t.Errorf("Expected envelope message, got %v", response["message"])
+		}
+		data, ok := response["data"].(map[string]interface{})
+		if !ok || data["id"] != "task1" {
+			t.Errorf("Expected task in data field, got %v", response["data"])
+		}
+	})
+
+	t.Run("Bare via header", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/tasks/task1", nil)
+		req.Header.Set("X-Envelope", "false")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if response["id"] != "task1" {
+			t.Errorf("Expected bare task, got %v", response)
+		}
+		if _, ok := response["data"]; ok {
+			t.Error("Bare response should not contain a data wrapper")
+		}
+	})
+
+	t.Run("Bare error via query param", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/tasks/missing?envelope=false", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Fatalf("Expected status 404, got %d", w.Code)
+		}
+		var response ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse error response: %v", err)
+		}
+		if response.Code != "TASK_NOT_FOUND" {
+			t.Errorf("Expected TASK_NOT_FOUND, got %s", response.Code)
+		}
+	})
+}
+
+// TestCreateLocationHeaders tests that 201 responses point at the new resource
+func TestCreateLocationHeaders(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	tests := []struct {
+		path   string
+		body   string
+		prefix string
+	}{
+		{"/employees", `{"name": "John Doe", "location": {"lat": 60.1699, "lon": 24.9384}, "skills": ["delivery"]}`, "/employees/"},
+		{"/tasks", `{"location": {"lat": 60.1700, "lon": 24.9400}, "required_skill": "delivery"}`, "/tasks/"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest("POST", tt.path, bytes.NewBufferString(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusCreated {
+			t.Fatalf("%s: expected status 201, got %d", tt.path, w.Code)
+		}
+		var response struct {
+			Data struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if response.Data.ID == "" {
+			t.Fatalf("%s: expected id in response", tt.path)
+		}
+		if got, want := w.Header().Get("Location"), tt.prefix+response.Data.ID; got != want {
+			t.Errorf("%s: Location = %q, want %q", tt.path, got, want)
+		}
+	}
+}
+
+// TestResizeWorkersHandler tests POST /admin/workers validation and success
+func TestResizeWorkersHandler(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+	api.workerPool.Start(context.Background())
+	defer api.workerPool.Shutdown()
+
+	tests := []struct {
+		body           string
+		expectedStatus int
+	}{
+		{`{"count": 3}`, http.StatusOK},
+		{`{"count": 0}`, http.StatusBadRequest},
+		{`{"count": -2}`, http.StatusBadRequest},
+		{`{"count": 1000}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("POST", "/admin/workers", bytes.NewBufferString(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != tt.expectedStatus {
+			t.Errorf("%s: expected status %d, got %d", tt.body, tt.expectedStatus, w.Code)
+		}
+	}
+}
+
+// TestPrettyJSONResponses tests that pretty output is indented and parses to the same structure
+func TestPrettyJSONResponses(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	api.store.AddTask(&Task{
+		ID:            "task1",
+		Location:      Location{Lat: 60.1700, Lon: 24.9400},
+		RequiredSkill: "delivery",
+	})
+
+	get := func(path string, header string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		if header != "" {
+			req.Header.Set("X-Pretty", header)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	compact := get("/tasks/task1", "")
+	if strings.Contains(compact.Body.String(), "\n") {
+		t.Error("Default response should be compact")
+	}
+
+	for _, w := range []*httptest.ResponseRecorder{get("/tasks/task1?pretty=true", ""), get("/tasks/task1", "true")} {
+		if !strings.Contains(w.Body.String(), "\n    \"") {
+			t.Errorf("Expected indented response, got %s", w.Body.String())
+		}
+
+		var pretty, plain map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &pretty); err != nil {
+			t.Fatalf("Failed to parse pretty response: %v", err)
+		}
+		if err := json.Unmarshal(compact.Body.Bytes(), &plain); err != nil {
+			t.Fatalf("Failed to parse compact response: %v", err)
+		}
+		if !reflect.DeepEqual(pretty, plain) {
+			t.Errorf("Pretty response differs from compact: %v vs %v", pretty, plain)
+		}
+	}
+}
+
+// TestGetStatsHandler tests GET /stats counts and circuit breaker state
+func TestGetStatsHandler(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+	api.workerPool.breaker = NewCircuitBreaker(5, time.Minute, realClock{})
+
+	api.store.AddTask(&Task{ID: "task1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"})
+
+	req := httptest.NewRequest("GET", "/stats", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	var response struct {
+		Data StatsResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Data.Tasks[TaskStatusPending] != 1 {
+		t.Errorf("Expected 1 pending task, got %v", response.Data.Tasks)
+	}
+	if response.Data.CircuitBreaker == nil || response.Data.CircuitBreaker.State != CircuitClosed {
+		t.Errorf("Expected closed circuit breaker in stats, got %+v", response.Data.CircuitBreaker)
+	}
+}
+
+// TestLocationHistoryHandlers tests moving an employee and reading back the trail
+func TestLocationHistoryHandlers(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	api.store.AddEmployee(&Employee{
+		ID:          "emp1",
+		Name:        "John",
+		Location:    Location{Lat: 60.1699, Lon: 24.9384},
+		Skills:      []string{"delivery"},
+		IsAvailable: true,
+	})
+
+	for _, body := range []string{`{"lat": 60.18, "lon": 24.94}`, `{"lat": 60.19, "lon": 24.95}`} {
+		req := httptest.NewRequest("PUT", "/employees/emp1/location", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/employees/emp1/location-history", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	var response struct {
+		Data []LocationRecord `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(response.Data) != 3 || response.Data[2].Location.Lat != 60.19 {
+		t.Errorf("Unexpected history: %+v", response.Data)
+	}
+
+	req = httptest.NewRequest("GET", "/employees/missing/location-history", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown employee, got %d", w.Code)
+	}
+}
+
+// TestGetTaskResultLongPoll tests that the long-poll returns as soon as the task fails
+func TestGetTaskResultLongPoll(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+	api.workerPool.Start(context.Background())
+	defer api.workerPool.Shutdown()
+
+	body := `{"location": {"lat": 60.1700, "lon": 24.9400}, "required_skill": "delivery"}`
+	req := httptest.NewRequest("POST", "/tasks", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d", w.Code)
+	}
+
+	start := time.Now()
+	req = httptest.NewRequest("GET", w.Header().Get("Location")+"/result?wait=5s", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Long-poll took %v, expected it to return when the task failed", elapsed)
+	}
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	var response struct {
+		Data TaskResultResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if !response.Data.Completed || response.Data.Task.Status != TaskStatusFailed {
+		t.Errorf("Expected completed failed task, got %+v", response.Data)
+	}
+
+	req = httptest.NewRequest("GET", "/tasks/missing/result", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown task, got %d", w.Code)
+	}
+}
+
+// TestGetTeamEmployeesHandler tests listing employees by normalized team ID
+func TestGetTeamEmployeesHandler(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	for _, body := range []string{
+		`{"name": "John", "location": {"lat": 60.17, "lon": 24.94}, "skills": ["delivery"], "team_id": "North"}`,
+		`{"name": "Jane", "location": {"lat": 60.18, "lon": 24.95}, "skills": ["delivery"], "team_id": " north "}`,
+		`{"name": "Jack", "location": {"lat": 60.19, "lon": 24.96}, "skills": ["delivery"], "team_id": "south"}`,
+	} {
+		req := httptest.NewRequest("POST", "/employees", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d", w.Code)
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/teams/NORTH/employees", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	var response struct {
+		Data []Employee `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(response.Data) != 2 {
+		t.Fatalf("Expected 2 employees in team north, got %d", len(response.Data))
+	}
+	for _, emp := range response.Data {
+		if emp.TeamID != "north" {
+			t.Errorf("Expected normalized team_id north, got %q", emp.TeamID)
+		}
+	}
+}
+
+// TestGetTaskReceiptHandler tests the receipt for assigned and unassigned tasks
+func TestGetTaskReceiptHandler(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	api.store.AddEmployee(&Employee{
+		ID:          "emp1",
+		Name:        "John Doe",
+		Location:    Location{Lat: 60.1699, Lon: 24.9384},
+		Skills:      []string{"delivery"},
+		IsAvailable: true,
+	})
+	task := &Task{ID: "task1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"}
+	api.store.AddTask(task)
+	api.store.AddTask(&Task{ID: "task2", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"})
+
+	if _, err := api.assigner.AssignTask(context.Background(), task); err != nil {
+		t.Fatalf("AssignTask() unexpected error: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/tasks/task1/receipt", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response struct {
+		Data TaskReceipt `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Data.EmployeeName != "John Doe" {
+		t.Errorf("Expected employee name John Doe, got %q", response.Data.EmployeeName)
+	}
+	want := CalculateDistance(task.Location, Location{Lat: 60.1699, Lon: 24.9384})
+	if math.Abs(response.Data.DistanceKm-want) > 1e-9 {
+		t.Errorf("Expected distance %f, got %f", want, response.Data.DistanceKm)
+	}
+	if response.Data.AssignedAt.IsZero() {
+		t.Error("Expected assigned_at to be set")
+	}
+
+	req = httptest.NewRequest("GET", "/tasks/task2/receipt", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound || !strings.Contains(w.Body.String(), "TASK_NOT_ASSIGNED") {
+		t.Errorf("Expected 404 TASK_NOT_ASSIGNED for pending task, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+// TestInjectedIDGenerator tests that created resources use the API's ID generator
+func TestInjectedIDGenerator(t *testing.T) {
+	api := setupTestAPI()
+	api.ids = &sequentialIDs{prefix: "id-"}
+	router := api.setupRouter()
+
+	tests := []struct {
+		path string
+		body string
+		want string
+	}{
+		{"/employees", `{"name": "John Doe", "location": {"lat": 60.1699, "lon": 24.9384}, "skills": ["delivery"]}`, "id-1"},
+		{"/tasks", `{"location": {"lat": 60.1700, "lon": 24.9400}, "required_skill": "delivery"}`, "id-2"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("POST", tt.path, bytes.NewBufferString(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusCreated {
+			t.Fatalf("%s: expected status 201, got %d", tt.path, w.Code)
+		}
+		var response struct {
+			Data struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if response.Data.ID != tt.want {
+			t.Errorf("%s: expected ID %s, got %s", tt.path, tt.want, response.Data.ID)
+		}
+	}
+
+	if _, err := api.store.GetEmployee("id-1"); err != nil {
+		t.Errorf("Expected employee id-1 in store: %v", err)
+	}
+	if _, err := api.store.GetTask("id-2"); err != nil {
+		t.Errorf("Expected task id-2 in store: %v", err)
+	}
+}
+
+// TestCreateTaskSynchronousWait tests the optional wait for fast and slow assignments
+func TestCreateTaskSynchronousWait(t *testing.T) {
+	body := `{"location": {"lat": 60.1700, "lon": 24.9400}, "required_skill": "delivery"}`
+	create := func(router *gin.Engine, query string) (SuccessResponse, Task) {
+		t.Helper()
+		req := httptest.NewRequest("POST", "/tasks"+query, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d: %s", w.Code, w.Body.String())
+		}
+		var response struct {
+			SuccessResponse
+			Data Task `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return response.SuccessResponse, response.Data
+	}
+
+	t.Run("Resolves quickly", func(t *testing.T) {
+		api := setupTestAPI()
+		router := api.setupRouter()
+		api.workerPool.Start(context.Background())
+		defer api.workerPool.Shutdown()
+
+		// No employees, so the worker fails the task almost immediately
+		resp, task := create(router, "?wait=1s")
+		if task.Status != TaskStatusFailed {
+			t.Errorf("Expected failed status in create response, got %s", task.Status)
+		}
+		if resp.Message != "Task created but assignment failed" {
+			t.Errorf("Unexpected message: %s", resp.Message)
+		}
+	})
+
+	t.Run("Still pending", func(t *testing.T) {
+		api := setupTestAPI()
+		router := api.setupRouter()
+		// Workers not started: the task cannot resolve within the wait
+
+		start := time.Now()
+		resp, task := create(router, "?wait=50ms")
+		if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+			t.Errorf("Expected the handler to wait, returned after %v", elapsed)
+		}
+		if task.Status != TaskStatusPending {
+			t.Errorf("Expected pending status, got %s", task.Status)
+		}
+		if resp.Message != "Task created and assignment initiated" {
+			t.Errorf("Unexpected message: %s", resp.Message)
+		}
+	})
+}
+
+// TestCreateTaskDedupWindow tests that a near-identical double submit is rejected
+func TestCreateTaskDedupWindow(t *testing.T) {
+	api := setupTestAPI()
+	api.dedup = NewTaskDedupIndex(api.store, time.Minute, realClock{})
+	router := api.setupRouter()
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/tasks", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := post(`{"location": {"lat": 60.1700, "lon": 24.9400}, "required_skill": "Delivery"}`)
+	if first.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d", first.Code)
+	}
+	firstID := strings.TrimPrefix(first.Header().Get("Location"), "/tasks/")
+
+	second := post(`{"location": {"lat": 60.170001, "lon": 24.940001}, "required_skill": "delivery "}`)
+	if second.Code != http.StatusConflict {
+		t.Fatalf("Expected status 409, got %d", second.Code)
+	}
+	var response ErrorResponse
+	if err := json.Unmarshal(second.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse error response: %v", err)
+	}
+	if response.Code != "DUPLICATE_TASK_CONTENT" {
+		t.Errorf("Expected DUPLICATE_TASK_CONTENT, got %s", response.Code)
+	}
+	if !strings.Contains(response.Message, firstID) {
+		t.Errorf("Expected message to reference %s, got %s", firstID, response.Message)
+	}
+	if tasks := api.store.GetAllTasks(); len(tasks) != 1 {
+		t.Errorf("Expected 1 stored task, got %d", len(tasks))
+	}
+}
+
+// TestCreateTaskNoWorkers tests that task creation reports NO_WORKERS for a misconfigured pool
+func TestCreateTaskNoWorkers(t *testing.T) {
+	api := setupTestAPI()
+	api.workerPool.numWorkers = 0
+	if err := api.workerPool.Start(context.Background()); err == nil {
+		t.Fatal("Expected Start() to reject zero workers")
+	}
+	defer api.workerPool.Shutdown()
+	router := api.setupRouter()
+
+	body := `{"location": {"lat": 60.1700, "lon": 24.9400}, "required_skill": "delivery"}`
+	req := httptest.NewRequest("POST", "/tasks", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected status 503, got %d", w.Code)
+	}
+	var response ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse error response: %v", err)
+	}
+	if response.Code != "NO_WORKERS" {
+		t.Errorf("Expected NO_WORKERS, got %s", response.Code)
+	}
+}
+
+// TestGetDistanceHandler tests the distance utility endpoint
+func TestGetDistanceHandler(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	req := httptest.NewRequest("GET", "/distance?lat1=51.5074&lon1=-0.1278&lat2=48.8566&lon2=2.3522", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response struct {
+		Data DistanceResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Data.Unit != UnitKilometers {
+		t.Errorf("Expected unit km, got %s", response.Data.Unit)
+	}
+	if diff := math.Abs(response.Data.Distance - 343); diff > 10 {
+		t.Errorf("Expected London to Paris ~343 km, got %.2f", response.Data.Distance)
+	}
+
+	// Miles are reported when configured
+	api.distanceUnit = UnitMiles
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if diff := math.Abs(response.Data.Distance - 213); diff > 6 {
+		t.Errorf("Expected London to Paris ~213 mi, got %.2f", response.Data.Distance)
+	}
+	if response.Data.BearingDeg != nil {
+		t.Errorf("Expected no bearing unless requested, got %v", *response.Data.BearingDeg)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/distance?lat1=51.5074&lon1=-0.1278&lat2=48.8566&lon2=2.3522&bearing=true", nil))
+	response.Data = DistanceResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if b := response.Data.BearingDeg; b == nil || math.Abs(*b-148.1) > 0.5 || response.Data.Direction != "SE" {
+		t.Errorf("Expected London to Paris bearing ~148° SE, got %v %q", b, response.Data.Direction)
+	}
+
+	for _, query := range []string{
+		"lat1=91&lon1=0&lat2=0&lon2=0",
+		"lat1=0&lon1=0&lat2=0&lon2=-181",
+		"lat1=abc&lon1=0&lat2=0&lon2=0",
+		"lat1=0&lon1=0&lat2=0",
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/distance?"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", query, w.Code)
+		}
+	}
+}
+
+// TestValidateHandlers tests payload validation without side effects
+func TestValidateHandlers(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	validate := func(path, body string) (int, ValidationResponse) {
+		req := httptest.NewRequest("POST", path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var response struct {
+			Data ValidationResponse `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return w.Code, response.Data
+	}
+
+	tests := []struct {
+		name      string
+		path      string
+		body      string
+		wantValid bool
+		wantField string
+	}{
+		{"valid employee", "/validate/employee", `{"name": "John", "location": {"lat": 60.17, "lon": 24.94}, "skills": [" Delivery "]}`, true, ""},
+		{"blank name", "/validate/employee", `{"name": " ", "location": {"lat": 60.17, "lon": 24.94}, "skills": ["delivery"]}`, false, "name"},
+		{"bad employee location", "/validate/employee", `{"name": "John", "location": {"lat": 95, "lon": 24.94}, "skills": ["delivery"]}`, false, "location"},
+		{"blank skill", "/validate/employee", `{"name": "John", "location": {"lat": 60.17, "lon": 24.94}, "skills": [""]}`, false, "skills"},
+		{"valid task", "/validate/task", `{"location": {"lat": 60.17, "lon": 24.94}, "required_skill": "Delivery"}`, true, ""},
+		{"bad priority", "/validate/task", `{"location": {"lat": 60.17, "lon": 24.94}, "required_skill": "delivery", "priority": 9}`, false, "priority"},
+		{"min above max", "/validate/task", `{"location": {"lat": 60.17, "lon": 24.94}, "required_skill": "delivery", "max_distance_km": 1, "min_distance_km": 2}`, false, "min_distance_km"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, got := validate(tt.path, tt.body)
+			if code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d", code)
+			}
+			if got.Valid != tt.wantValid {
+				t.Fatalf("Expected valid=%v, got %+v", tt.wantValid, got)
+			}
+			if tt.wantValid {
+				if len(got.Errors) != 0 || got.Normalized == nil {
+					t.Errorf("Expected no errors and a normalized preview, got %+v", got)
+				}
+				return
+			}
+			if len(got.Errors) != 1 || got.Errors[0].Field != tt.wantField || got.Errors[0].Message == "" {
+				t.Errorf("Expected one error on %s, got %+v", tt.wantField, got.Errors)
+			}
+			if got.Normalized != nil {
+				t.Errorf("Expected no normalized preview for invalid payload")
+			}
+		})
+	}
+
+	// The preview shows normalization
+	_, got := validate("/validate/task", `{"location": {"lat": 60.17, "lon": 24.94}, "required_skill": " Delivery "}`)
+	preview, _ := got.Normalized.(map[string]interface{})
+	if preview["required_skill"] != "delivery" || preview["priority"] != float64(PriorityNormal) {
+		t.Errorf("Expected normalized skill and default priority, got %v", preview)
+	}
+
+	if code, _ := validate("/validate/task", `{"location": `); code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for malformed body, got %d", code)
+	}
+
+	// Nothing was stored or queued
+	api.store.mu.RLock()
+	employees := len(api.store.employees)
+	api.store.mu.RUnlock()
+	if n := employees; n != 0 {
+		t.Errorf("Expected no stored employees, got %d", n)
+	}
+	if n := len(api.store.GetAllTasks()); n != 0 {
+		t.Errorf("Expected no stored tasks, got %d", n)
+	}
+	if n := api.workerPool.taskQueue.Len(); n != 0 {
+		t.Errorf("Expected empty queue, got %d", n)
+	}
+}
+
+// TestGetTasksGob tests gob content negotiation on the task list
+func TestGetTasksGob(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	api.store.AddTask(&Task{ID: "task1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery", Priority: PriorityHigh})
+	api.store.AddTask(&Task{ID: "task2", Location: Location{Lat: 60.1800, Lon: 24.9500}, RequiredSkill: "repair"})
+
+	req := httptest.NewRequest("GET", "/tasks", nil)
+	req.Header.Set("Accept", "application/x-gob, application/json;q=0.5")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != MIMEGob {
+		t.Fatalf("Expected Content-Type %s, got %s", MIMEGob, ct)
+	}
+
+	var tasks []Task
+	if err := gob.NewDecoder(w.Body).Decode(&tasks); err != nil {
+		t.Fatalf("Failed to decode gob response: %v", err)
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	if len(tasks) != 2 || tasks[0].ID != "task1" || tasks[1].ID != "task2" {
+		t.Fatalf("Expected [task1 task2], got %+v", tasks)
+	}
+	if tasks[0].Priority != PriorityHigh || tasks[0].Location.Lat != 60.1700 || tasks[1].RequiredSkill != "repair" {
+		t.Errorf("Decoded tasks lost data: %+v", tasks)
+	}
+	if len(tasks[0].History) != 1 || tasks[0].History[0].Status != TaskStatusPending {
+		t.Errorf("Expected decoded history with the pending event, got %+v", tasks[0].History)
+	}
+
+	// JSON stays the default
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks", nil))
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Errorf("Expected JSON by default, got %s", ct)
+	}
+}
+
+// TestEmptyCollectionsEncodeAsArrays tests that list endpoints return [] rather than null
+func TestEmptyCollectionsEncodeAsArrays(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	paths := []string{
+		"/tasks",
+		"/employees",
+		"/employees/workload",
+		"/teams/nobody/employees",
+		"/webhooks/failed",
+	}
+
+	for _, path := range paths {
+		t.Run(path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d", w.Code)
+			}
+
+			var response struct {
+				Data json.RawMessage `json:"data"`
+			}
+			json.Unmarshal(w.Body.Bytes(), &response)
+			if string(response.Data) != "[]" {
+				t.Errorf("Expected data to be [], got %s", response.Data)
+			}
+		})
+	}
+
+	if available := api.store.GetAvailableEmployees("delivery"); available == nil {
+		t.Error("GetAvailableEmployees() returned nil for an empty store")
+	}
+}
+
+// TestCreateTaskStrategyOverride tests that each task is assigned with the strategy it requested
+func TestCreateTaskStrategyOverride(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+	api.workerPool.Start(api.workerPoolCtx)
+	defer api.workerPool.Shutdown()
+
+	api.store.AddEmployee(&Employee{ID: "near", Name: "Near", Location: Location{Lat: 60.1699, Lon: 24.9384}, Skills: []string{"delivery"}, IsAvailable: true, Capacity: 2})
+	api.store.AddEmployee(&Employee{ID: "far", Name: "Far", Location: Location{Lat: 60.4518, Lon: 22.2666}, Skills: []string{"delivery"}, IsAvailable: true, Capacity: 2})
+
+	createTask := func(strategy string) (int, string) {
+		body := `{"location": {"lat": 60.1700, "lon": 24.9400}, "required_skill": "delivery", "strategy": "` + strategy + `"}`
+		req := httptest.NewRequest("POST", "/tasks", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response struct {
+			Data Task `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return w.Code, response.Data.ID
+	}
+
+	tests := []struct {
+		strategy     string
+		wantEmployee string
+	}{
+		{"nearest", "near"},
+		{"Reverse_Distance", "far"},
+		// Both now hold one task, so the nearer wins the tie
+		{"least_loaded", "near"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			code, id := createTask(tt.strategy)
+			if code != http.StatusCreated {
+				t.Fatalf("Expected status 201, got %d", code)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			got, _, err := api.store.WaitForTerminal(ctx, id)
+			if err != nil {
+				t.Fatalf("WaitForTerminal() unexpected error: %v", err)
+			}
+			if got.AssignedEmployeeID != tt.wantEmployee {
+				t.Errorf("Expected %s strategy to pick %s, got %s", tt.strategy, tt.wantEmployee, got.AssignedEmployeeID)
+			}
+		})
+	}
+
+	if code, _ := createTask("bogus"); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown strategy, got %d", code)
+	}
+}
+
+// TestCreateTaskNoCapableWorkforce tests the opt-in synchronous rejection of unservable tasks
+func TestCreateTaskNoCapableWorkforce(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+	api.store.AddEmployee(&Employee{ID: "emp1", Name: "Alice", Location: Location{Lat: 60.1699, Lon: 24.9384}, Skills: []string{"delivery"}, IsAvailable: true})
+
+	createTask := func(skill string) *httptest.ResponseRecorder {
+		body := `{"location": {"lat": 60.1700, "lon": 24.9400}, "required_skill": "` + skill + `"}`
+		req := httptest.NewRequest("POST", "/tasks", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Off by default: the task is accepted and fails later
+	if w := createTask("welding"); w.Code != http.StatusCreated {
+		t.Fatalf("Expected 201 with the option off, got %d", w.Code)
+	}
+
+	api.rejectNoSkill = true
+	w := createTask("welding")
+	if w.Code != http.StatusConflict {
+		t.Fatalf("Expected 409 for an unheld skill, got %d", w.Code)
+	}
+	var response ErrorResponse
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if response.Code != "NO_CAPABLE_WORKFORCE" {
+		t.Errorf("Expected NO_CAPABLE_WORKFORCE, got %s", response.Code)
+	}
+
+	if w := createTask("delivery"); w.Code != http.StatusCreated {
+		t.Errorf("Expected 201 for a held skill, got %d", w.Code)
+	}
+	if tasks := api.store.GetAllTasks(); len(tasks) != 2 {
+		t.Errorf("Expected the rejected task not to be stored, got %d tasks", len(tasks))
+	}
+}
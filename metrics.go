@@ -0,0 +1,74 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// EmployeeMetrics is the assignment rate of one employee
+type EmployeeMetrics struct {
+	EmployeeID          string  `json:"employee_id"`
+	WindowSeconds       float64 `json:"window_seconds"`
+	AssignmentsInWindow int     `json:"assignments_in_window"`
+	TotalAssignments    int     `json:"total_assignments"`
+}
+
+// AssignmentMetrics keeps a rolling per-employee count of successful assignments
+type AssignmentMetrics struct {
+	clock  Clock
+	window time.Duration
+
+	mu     sync.Mutex
+	recent map[string][]time.Time // assignment times inside the window, oldest first
+	totals map[string]int
+}
+
+// NewAssignmentMetrics creates metrics counting assignments over the given window
+func NewAssignmentMetrics(clock Clock, window time.Duration) *AssignmentMetrics {
+	return &AssignmentMetrics{
+		clock:  clock,
+		window: window,
+		recent: make(map[string][]time.Time),
+		totals: make(map[string]int),
+	}
+}
+
+// Record counts one assignment for the employee at the current clock time
+func (m *AssignmentMetrics) Record(employeeID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock.Now()
+	m.recent[employeeID] = append(m.prune(m.recent[employeeID], now), now)
+	m.totals[employeeID]++
+}
+
+// Get returns the current metrics for the employee
+func (m *AssignmentMetrics) Get(employeeID string) EmployeeMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	recent := m.prune(m.recent[employeeID], m.clock.Now())
+	if len(recent) == 0 {
+		delete(m.recent, employeeID)
+	} else {
+		m.recent[employeeID] = recent
+	}
+
+	return EmployeeMetrics{
+		EmployeeID:          employeeID,
+		WindowSeconds:       m.window.Seconds(),
+		AssignmentsInWindow: len(recent),
+		TotalAssignments:    m.totals[employeeID],
+	}
+}
+
+// prune drops timestamps that have fallen out of the window
+func (m *AssignmentMetrics) prune(times []time.Time, now time.Time) []time.Time {
+	cutoff := now.Add(-m.window)
+	i := 0
+	for i < len(times) && !times[i].After(cutoff) {
+		i++
+	}
+	return times[i:]
+}
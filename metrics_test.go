@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestAssignmentMetricsRollingWindow tests that the per-employee count reflects the window
+func TestAssignmentMetricsRollingWindow(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+	clock := newFakeClock()
+	assigner.SetClock(clock)
+
+	store.AddEmployee(&Employee{
+		ID:          "emp1",
+		Location:    Location{Lat: 60.1699, Lon: 24.9384},
+		Skills:      []string{"delivery"},
+		Capacity:    10,
+		IsAvailable: true,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	assign := func(i int) {
+		task := &Task{
+			ID:            fmt.Sprintf("task-%d", i),
+			Location:      Location{Lat: 60.1700, Lon: 24.9400},
+			RequiredSkill: "delivery",
+		}
+		store.AddTask(task)
+		if _, err := assigner.AssignTask(ctx, task); err != nil {
+			t.Fatalf("AssignTask() unexpected error: %v", err)
+		}
+	}
+
+	// Two assignments early, three more 40 minutes later
+	assign(0)
+	assign(1)
+	clock.Advance(40 * time.Minute)
+	assign(2)
+	assign(3)
+	assign(4)
+
+	if m := assigner.Metrics().Get("emp1"); m.AssignmentsInWindow != 5 || m.TotalAssignments != 5 {
+		t.Errorf("Expected 5 in window and 5 total, got %+v", m)
+	}
+
+	// 30 minutes later the first two have left the one hour window
+	clock.Advance(30 * time.Minute)
+	if m := assigner.Metrics().Get("emp1"); m.AssignmentsInWindow != 3 || m.TotalAssignments != 5 {
+		t.Errorf("Expected 3 in window and 5 total, got %+v", m)
+	}
+
+	clock.Advance(time.Hour)
+	if m := assigner.Metrics().Get("emp1"); m.AssignmentsInWindow != 0 {
+		t.Errorf("Expected 0 in window, got %d", m.AssignmentsInWindow)
+	}
+}
+
+// TestGetEmployeeMetricsHandler tests GET /employees/:id/metrics
+func TestGetEmployeeMetricsHandler(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	api.store.AddEmployee(&Employee{ID: "emp1", Skills: []string{"delivery"}, IsAvailable: true})
+	api.assigner.Metrics().Record("emp1")
+
+	req := httptest.NewRequest("GET", "/employees/emp1/metrics", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	var response struct {
+		Data EmployeeMetrics `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Data.AssignmentsInWindow != 1 || response.Data.WindowSeconds != 3600 {
+		t.Errorf("Unexpected metrics: %+v", response.Data)
+	}
+
+	req = httptest.NewRequest("GET", "/employees/missing/metrics", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown employee, got %d", w.Code)
+	}
+}
@@ -5,9 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 // Location represents geographical coordinates
@@ -27,20 +32,93 @@ func (l Location) Validate() error {
 	return nil
 }
 
-// normalizeSkill converts skill to lowercase for case-insensitive matching
+// isUnknown reports whether l is Null Island ({0,0}), which in practice
+// means a device without a GPS fix rather than a real position
+func (l Location) isUnknown() bool {
+	return l.Lat == 0 && l.Lon == 0
+}
+
+// BoundingBox is a lat/lon rectangle; boxes crossing the antimeridian are not supported
+type BoundingBox struct {
+	MinLat float64 `json:"min_lat"`
+	MinLon float64 `json:"min_lon"`
+	MaxLat float64 `json:"max_lat"`
+	MaxLon float64 `json:"max_lon"`
+}
+
+// Validate checks the corners are valid coordinates and correctly ordered
+func (b BoundingBox) Validate() error {
+	if err := (Location{Lat: b.MinLat, Lon: b.MinLon}).Validate(); err != nil {
+		return fmt.Errorf("invalid min corner: %w", err)
+	}
+	if err := (Location{Lat: b.MaxLat, Lon: b.MaxLon}).Validate(); err != nil {
+		return fmt.Errorf("invalid max corner: %w", err)
+	}
+	if b.MinLat > b.MaxLat {
+		return fmt.Errorf("min_lat %.6f is greater than max_lat %.6f", b.MinLat, b.MaxLat)
+	}
+	if b.MinLon > b.MaxLon {
+		return fmt.Errorf("min_lon %.6f is greater than max_lon %.6f", b.MinLon, b.MaxLon)
+	}
+	return nil
+}
+
+// Contains reports whether a location lies inside the box (edges inclusive)
+func (b BoundingBox) Contains(l Location) bool {
+	return l.Lat >= b.MinLat && l.Lat <= b.MaxLat && l.Lon >= b.MinLon && l.Lon <= b.MaxLon
+}
+
+// normalizeSkill converts skill to lowercase for case-insensitive matching,
+// folds accented Latin letters to their base form ("café" -> "cafe") and
+// collapses whitespace, underscore and hyphen runs to the skill separator
+// ("ice cream", "ice-cream" -> "ice_cream")
 func normalizeSkill(skill string) string {
-	return strings.ToLower(strings.TrimSpace(skill))
+	return collapseSkillSeparators(stripLatinDiacritics(strings.ToLower(strings.TrimSpace(skill))))
 }
 
-// normalizeSkills normalizes all skills in a slice
+// stripLatinDiacritics removes combining marks that follow a Latin letter
+// after NFKD decomposition. Marks on other scripts are meaningful (Cyrillic
+// "й", Japanese dakuten, Devanagari vowel signs), so they are kept and
+// recomposed with NFC
+func stripLatinDiacritics(s string) string {
+	decomposed := norm.NFKD.String(s)
+	var b strings.Builder
+	b.Grow(len(decomposed))
+	latinBase := false
+	for _, r := range decomposed {
+		if unicode.Is(unicode.Mn, r) {
+			if latinBase {
+				continue
+			}
+		} else {
+			latinBase = unicode.Is(unicode.Latin, r)
+		}
+		b.WriteRune(r)
+	}
+	return norm.NFC.String(b.String())
+}
+
+// normalizeSkills normalizes all skills in a slice, dropping duplicates
+// that normalize to the same value (first occurrence wins)
 func normalizeSkills(skills []string) []string {
-	normalized := make([]string, len(skills))
-	for i, skill := range skills {
-		normalized[i] = normalizeSkill(skill)
+	normalized := make([]string, 0, len(skills))
+	seen := make(map[string]bool, len(skills))
+	for _, skill := range skills {
+		skill = normalizeSkill(skill)
+		if seen[skill] {
+			continue
+		}
+		seen[skill] = true
+		normalized = append(normalized, skill)
 	}
 	return normalized
 }
 
+// normalizeTeamID trims and lowercases a team ID so lookups are case-insensitive
+func normalizeTeamID(teamID string) string {
+	return strings.ToLower(strings.TrimSpace(teamID))
+}
+
 // validateSkills checks if skills array is valid
 func validateSkills(skills []string) error {
 	if len(skills) == 0 {
@@ -54,6 +132,25 @@ func validateSkills(skills []string) error {
 	return nil
 }
 
+// FieldError is a validation failure attributed to one request field
+type FieldError struct {
+	Field string `json:"field"`
+	Err   error  `json:"-"`
+}
+
+// fieldErrorf builds a FieldError; %w wraps as with fmt.Errorf
+func fieldErrorf(field, format string, args ...any) *FieldError {
+	return &FieldError{Field: field, Err: fmt.Errorf(format, args...)}
+}
+
+func (e *FieldError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
 // Employee represents a worker who can be assigned tasks
 type Employee struct {
 	ID          string   `json:"id" binding:"required"`
@@ -61,21 +158,70 @@ type Employee struct {
 	Location    Location `json:"location" binding:"required"`
 	Skills      []string `json:"skills" binding:"required"`
 	IsAvailable bool     `json:"is_available"`
+	// Capacity is how many tasks the employee can hold at once (0 is treated as 1)
+	Capacity    int `json:"capacity"`
+	ActiveTasks int `json:"active_tasks"`
+	// Tier ranks seniority (0 = base tier); urgent work prefers higher tiers
+	Tier int `json:"tier"`
+	// TeamID optionally groups employees; empty means no team
+	TeamID string `json:"team_id,omitempty"`
+	// MaxRangeKm is how far from their location the employee will travel (0 = unlimited)
+	MaxRangeKm float64 `json:"max_range_km,omitempty"`
+	// Rating is a quality score from 0 (unrated) to MaxRating, used by top_rated
+	Rating float64 `json:"rating,omitempty"`
+	// ReservedUntil holds the employee back from general assignment until it passes
+	ReservedUntil *time.Time `json:"reserved_until,omitempty"`
+	// DeletedAt is set while the employee is soft-deleted (see DeleteEmployee)
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// maxActiveTasks returns the effective capacity of the employee
+func (e *Employee) maxActiveTasks() int {
+	if e.Capacity <= 0 {
+		return 1
+	}
+	return e.Capacity
+}
+
+// withinRange reports whether a task distanceKm away is inside the employee's own range
+func (e *Employee) withinRange(distanceKm float64) bool {
+	return withinRangeKm(e.MaxRangeKm, distanceKm)
+}
+
+// withinRangeKm reports whether distanceKm is inside a range of maxRangeKm (0 = unlimited)
+func withinRangeKm(maxRangeKm, distanceKm float64) bool {
+	return maxRangeKm <= 0 || distanceKm <= maxRangeKm
 }
 
 // Validate validates employee data
 func (e *Employee) Validate() error {
 	if strings.TrimSpace(e.Name) == "" {
-		return errors.New("employee name cannot be empty")
+		return fieldErrorf("name", "employee name cannot be empty")
 	}
 	if err := e.Location.Validate(); err != nil {
-		return fmt.Errorf("invalid location: %w", err)
+		return fieldErrorf("location", "invalid location: %w", err)
 	}
 	if err := validateSkills(e.Skills); err != nil {
-		return fmt.Errorf("invalid skills: %w", err)
+		return fieldErrorf("skills", "invalid skills: %w", err)
+	}
+	if e.Capacity < 0 {
+		return fieldErrorf("capacity", "capacity cannot be negative, got %d", e.Capacity)
+	}
+	if e.Capacity == 0 {
+		e.Capacity = 1
 	}
-	// Normalize skills for case-insensitive comparison
+	if e.Tier < 0 {
+		return fieldErrorf("tier", "tier cannot be negative, got %d", e.Tier)
+	}
+	if e.MaxRangeKm < 0 {
+		return fieldErrorf("max_range_km", "max_range_km cannot be negative, got %g", e.MaxRangeKm)
+	}
+	if e.Rating < 0 || e.Rating > MaxRating {
+		return fieldErrorf("rating", "rating must be between 0 and %g, got %g", float64(MaxRating), e.Rating)
+	}
+	// Normalize skills and team for case-insensitive comparison
 	e.Skills = normalizeSkills(e.Skills)
+	e.TeamID = normalizeTeamID(e.TeamID)
 	return nil
 }
 
@@ -86,6 +232,30 @@ const (
 	TaskStatusPending  TaskStatus = "pending"
 	TaskStatusAssigned TaskStatus = "assigned"
 	TaskStatusFailed   TaskStatus = "failed"
+	// TaskStatusHeld parks a task: workers skip it until it is released
+	TaskStatusHeld TaskStatus = "held"
+	// TaskStatusCancelled is final: the task was withdrawn (see CancelTasks)
+	TaskStatusCancelled TaskStatus = "cancelled"
+)
+
+// TaskStatuses lists every task status
+var TaskStatuses = []TaskStatus{TaskStatusPending, TaskStatusAssigned, TaskStatusFailed, TaskStatusHeld, TaskStatusCancelled}
+
+// ParseTaskStatus validates a status name (case-insensitive)
+func ParseTaskStatus(s string) (TaskStatus, error) {
+	switch status := TaskStatus(strings.ToLower(strings.TrimSpace(s))); status {
+	case TaskStatusPending, TaskStatusAssigned, TaskStatusFailed, TaskStatusHeld, TaskStatusCancelled:
+		return status, nil
+	}
+	return "", fmt.Errorf("unknown task status %q (expected pending, assigned, failed, held or cancelled)", s)
+}
+
+// Task priorities; higher values are more important
+const (
+	PriorityLow    = 1
+	PriorityNormal = 2
+	PriorityHigh   = 3
+	PriorityUrgent = 4
 )
 
 // Task represents a job that needs to be assigned to an employee
@@ -95,18 +265,74 @@ type Task struct {
 	RequiredSkill      string     `json:"required_skill" binding:"required"`
 	Status             TaskStatus `json:"status"`
 	AssignedEmployeeID string     `json:"assigned_employee_id,omitempty"`
+	// MaxDistanceKm limits assignment to employees within this radius (0 = unlimited)
+	MaxDistanceKm float64 `json:"max_distance_km,omitempty"`
+	// MinDistanceKm excludes suspiciously colocated employees closer than this (0 = no minimum)
+	MinDistanceKm float64 `json:"min_distance_km,omitempty"`
+	// Priority is one of the Priority* levels (0 is treated as normal)
+	Priority int `json:"priority"`
+	// TeamID restricts assignment to members of this team (empty = anyone)
+	TeamID string `json:"team_id,omitempty"`
+	// IgnoreDistance assigns the least-loaded eligible employee without computing distances
+	IgnoreDistance bool `json:"ignore_distance,omitempty"`
+	// Strategy overrides the assigner's strategy for this task (empty = default)
+	Strategy string `json:"strategy,omitempty"`
+	// RadiusTiersKm are tried nearest first; the assigner only widens to the
+	// next tier when the current one has no candidate (empty = assigner default)
+	RadiusTiersKm []float64 `json:"radius_tiers_km,omitempty"`
+	// Attempts counts worker passes over the task across requeues
+	Attempts int `json:"attempts"`
+	// CreatedAt is stamped by AddTask from the store clock
+	CreatedAt time.Time `json:"created_at"`
+	// AssignedAt and AssignedDistanceKm record the committed assignment
+	AssignedAt         *time.Time `json:"assigned_at,omitempty"`
+	AssignedDistanceKm float64    `json:"assigned_distance_km,omitempty"`
+	// Rationale explains the committed assignment (see GET /tasks/:id/rationale)
+	Rationale *AssignmentRationale `json:"rationale,omitempty"`
+	// History lists status transitions, oldest first (capped at MaxTaskHistory)
+	History []TaskEvent `json:"history,omitempty"`
 }
 
 // Validate validates task data
 func (t *Task) Validate() error {
 	if err := t.Location.Validate(); err != nil {
-		return fmt.Errorf("invalid location: %w", err)
+		return fieldErrorf("location", "invalid location: %w", err)
 	}
 	if strings.TrimSpace(t.RequiredSkill) == "" {
-		return errors.New("required_skill cannot be empty")
+		return fieldErrorf("required_skill", "required_skill cannot be empty")
+	}
+	if t.MaxDistanceKm < 0 {
+		return fieldErrorf("max_distance_km", "max_distance_km cannot be negative, got %.2f", t.MaxDistanceKm)
 	}
-	// Normalize skill for case-insensitive comparison
+	if t.MinDistanceKm < 0 {
+		return fieldErrorf("min_distance_km", "min_distance_km cannot be negative, got %.2f", t.MinDistanceKm)
+	}
+	if t.MaxDistanceKm > 0 && t.MinDistanceKm > t.MaxDistanceKm {
+		return fieldErrorf("min_distance_km", "min_distance_km %.2f exceeds max_distance_km %.2f", t.MinDistanceKm, t.MaxDistanceKm)
+	}
+	if t.Strategy != "" {
+		if _, err := StrategyByName(t.Strategy); err != nil {
+			return fieldErrorf("strategy", "%w", err)
+		}
+	}
+	if t.IgnoreDistance && (t.MaxDistanceKm > 0 || t.MinDistanceKm > 0) {
+		return fieldErrorf("ignore_distance", "ignore_distance cannot be combined with max_distance_km or min_distance_km")
+	}
+	if err := validateRadiusTiers(t.RadiusTiersKm); err != nil {
+		return fieldErrorf("radius_tiers_km", "%w", err)
+	}
+	if t.IgnoreDistance && len(t.RadiusTiersKm) > 0 {
+		return fieldErrorf("ignore_distance", "ignore_distance cannot be combined with radius_tiers_km")
+	}
+	if t.Priority == 0 {
+		t.Priority = PriorityNormal
+	}
+	if t.Priority < PriorityLow || t.Priority > PriorityUrgent {
+		return fieldErrorf("priority", "priority must be between %d and %d, got %d", PriorityLow, PriorityUrgent, t.Priority)
+	}
+	// Normalize skill and team for case-insensitive comparison
 	t.RequiredSkill = normalizeSkill(t.RequiredSkill)
+	t.TeamID = normalizeTeamID(t.TeamID)
 	return nil
 }
 
@@ -128,6 +354,12 @@ func (e *TaskError) Unwrap() error {
 	return e.Err
 }
 
+// Is matches TaskErrors by code so errors.Is works with the sentinels below
+func (e *TaskError) Is(target error) bool {
+	t, ok := target.(*TaskError)
+	return ok && t.Code == e.Code
+}
+
 // Error codes
 var (
 	ErrNoEligibleEmployee = &TaskError{
@@ -150,10 +382,98 @@ var (
 		Code:    "ASSIGNMENT_TIMEOUT",
 		Message: "Task assignment timed out",
 	}
+	ErrAssignmentVetoed = &TaskError{
+		Code:    "ASSIGNMENT_VETOED",
+		Message: "Every candidate was vetoed by the pre-assign hook",
+	}
 	ErrEmployeeNoLongerAvailable = &TaskError{
 		Code:    "EMPLOYEE_UNAVAILABLE",
 		Message: "Selected employee no longer available (assigned concurrently)",
 	}
+	ErrOutOfServiceArea = &TaskError{
+		Code:    "OUT_OF_SERVICE_AREA",
+		Message: "Task location is outside all configured service areas",
+	}
+	ErrSkillNotAllowedHere = &TaskError{
+		Code:    "SKILL_NOT_ALLOWED_HERE",
+		Message: "Required skill is not permitted at the task location",
+	}
+	ErrNoCapableWorkforce = &TaskError{
+		Code:    "NO_CAPABLE_WORKFORCE",
+		Message: "No available employee has the required skill",
+	}
+	ErrDuplicateSubmission = &TaskError{
+		Code:    "DUPLICATE_SUBMISSION",
+		Message: "Task is already queued or being processed",
+	}
+	ErrInvalidWorkerCount = &TaskError{
+		Code:    "INVALID_WORKER_COUNT",
+		Message: fmt.Sprintf("Worker count must be between 1 and %d", MaxWorkers),
+	}
+	ErrDuplicateTaskContent = &TaskError{
+		Code:    "DUPLICATE_TASK_CONTENT",
+		Message: "An identical pending task was created within the dedup window",
+	}
+	ErrTaskNotPending = &TaskError{
+		Code:    "NOT_PENDING",
+		Message: "Task is not pending",
+	}
+	ErrTaskAlreadyFailed = &TaskError{
+		Code:    "ALREADY_FAILED",
+		Message: "Task has already failed",
+	}
+	ErrTaskCancelled = &TaskError{
+		Code:    "TASK_CANCELLED",
+		Message: "Task has been cancelled",
+	}
+	ErrTaskNotHeld = &TaskError{
+		Code:    "NOT_HELD",
+		Message: "Task is not held",
+	}
+	ErrEmployeeNotReserved = &TaskError{
+		Code:    "NOT_RESERVED",
+		Message: "Employee is not reserved",
+	}
+	ErrEmployeeHasActiveTasks = &TaskError{
+		Code:    "EMPLOYEE_HAS_ACTIVE_TASKS",
+		Message: "Employee still has active tasks and cannot be deleted",
+	}
+	ErrEmployeeNotDeleted = &TaskError{
+		Code:    "EMPLOYEE_NOT_DELETED",
+		Message: "Employee is not deleted",
+	}
+	ErrEmployeeLimitReached = &TaskError{
+		Code:    "EMPLOYEE_LIMIT_REACHED",
+		Message: "Employee limit reached; delete and purge employees before adding more",
+	}
+	ErrTaskNotAssigned = &TaskError{
+		Code:    "TASK_NOT_ASSIGNED",
+		Message: "Task has not been assigned",
+	}
+	ErrCircuitOpen = &TaskError{
+		Code:    "CIRCUIT_OPEN",
+		Message: "Assignment circuit breaker is open after repeated failures",
+	}
+	ErrNoWorkers = &TaskError{
+		Code:    "NO_WORKERS",
+		Message: "No assignment workers are running; check the worker pool configuration",
+	}
+	ErrPoolStopped = &TaskError{
+		Code:    "POOL_STOPPED",
+		Message: "Worker pool has been shut down",
+	}
+	ErrPoolShuttingDown = &TaskError{
+		Code:    "POOL_SHUTTING_DOWN",
+		Message: "Worker pool is shutting down and no longer accepts tasks",
+	}
+	ErrPoolBusy = &TaskError{
+		Code:    "POOL_BUSY",
+		Message: "Tasks are queued or being assigned; retry once the pool is idle",
+	}
+	ErrMaxAttemptsExceeded = &TaskError{
+		Code:    "MAX_ATTEMPTS_EXCEEDED",
+		Message: "Task has used up its assignment attempts and was failed permanently",
+	}
 )
 
 // Store provides thread-safe in-memory storage for employees and tasks
@@ -161,26 +481,94 @@ type Store struct {
 	employees map[string]*Employee
 	tasks     map[string]*Task
 	mu        sync.RWMutex
+	// deletedEmployees holds soft-deleted employees out of every query and
+	// assignment scan until they are restored or purged
+	deletedEmployees map[string]*Employee
+	// skillMatcher is used by every eligibility check; zero value matches exactly
+	skillMatcher SkillMatcher
+	// skillIndex maps each skill to the live employees having it (see skill_index.go)
+	skillIndex map[string]map[string]struct{}
+	// locationHistory is a bounded per-employee trail of location updates
+	locationHistory map[string][]LocationRecord
+	// taskWaiters holds per-task channels closed on the next status change
+	taskWaiters map[string]chan struct{}
+	// clock timestamps task history
+	clock Clock
+	// counters are monotonic task totals since process start (not snapshotted)
+	counters TaskCounters
+	// maxEmployees caps live (not soft-deleted) employees; 0 means unlimited
+	maxEmployees int
 }
 
 // NewStore creates a new Store instance
 func NewStore() *Store {
 	return &Store{
-		employees: make(map[string]*Employee),
-		tasks:     make(map[string]*Task),
+		employees:        make(map[string]*Employee),
+		deletedEmployees: make(map[string]*Employee),
+		skillIndex:       make(map[string]map[string]struct{}),
+		tasks:            make(map[string]*Task),
+		locationHistory:  make(map[string][]LocationRecord),
+		taskWaiters:      make(map[string]chan struct{}),
+		clock:            realClock{},
 	}
 }
 
+// SetSkillMatcher sets how required skills are matched against employee skills
+// Must be called before the store is used concurrently
+func (s *Store) SetSkillMatcher(matcher SkillMatcher) {
+	s.skillMatcher = matcher
+}
+
+// SetMaxEmployees caps how many live employees the store holds; n <= 0 removes the cap
+// Must be called before the store is used concurrently
+func (s *Store) SetMaxEmployees(n int) {
+	s.maxEmployees = n
+}
+
+// atEmployeeLimitLocked reports whether adding one more employee would exceed the cap
+// Caller must hold the store lock
+func (s *Store) atEmployeeLimitLocked() bool {
+	return s.maxEmployees > 0 && len(s.employees) >= s.maxEmployees
+}
+
 // AddEmployee adds a new employee to the store
 func (s *Store) AddEmployee(emp *Employee) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	return s.addEmployeeLocked(emp)
+}
+
+// AddEmployees adds a batch of employees under a single lock; the returned
+// slice holds each employee's error (nil on success), in order
+// Once the employee cap is reached the remaining entries fail with ErrEmployeeLimitReached
+func (s *Store) AddEmployees(emps []*Employee) []error {
+	errs := make([]error, len(emps))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, emp := range emps {
+		errs[i] = s.addEmployeeLocked(emp)
+	}
+	return errs
+}
 
+// addEmployeeLocked adds an employee; caller must hold the store lock
+func (s *Store) addEmployeeLocked(emp *Employee) error {
 	if _, exists := s.employees[emp.ID]; exists {
 		return ErrDuplicateEmployee
 	}
+	// A soft-deleted employee still owns its ID until purged
+	if _, exists := s.deletedEmployees[emp.ID]; exists {
+		return ErrDuplicateEmployee
+	}
+	if s.atEmployeeLimitLocked() {
+		return ErrEmployeeLimitReached
+	}
 
 	s.employees[emp.ID] = emp
+	s.indexEmployeeLocked(emp)
+	s.recordLocationLocked(emp.ID, emp.Location)
 	return nil
 }
 
@@ -196,14 +584,16 @@ func (s *Store) GetEmployee(id string) (*Employee, error) {
 	return emp, nil
 }
 
-// GetAvailableEmployees returns all available employees with a specific skill
+// GetAvailableEmployees returns all available, unreserved employees with a specific skill
+// The result is never nil so it encodes as [] rather than null
 func (s *Store) GetAvailableEmployees(skill string) []*Employee {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	var eligible []*Employee
-	for _, emp := range s.employees {
-		if emp.IsAvailable && hasSkill(emp.Skills, skill) {
+	now := s.clock.Now()
+	eligible := []*Employee{}
+	for _, emp := range s.skillCandidatesLocked(skill) {
+		if emp.assignableAt(now) && s.skillMatcher.Matches(emp.Skills, skill) {
 			eligible = append(eligible, emp)
 		}
 	}
@@ -223,6 +613,53 @@ func (s *Store) UpdateEmployeeAvailability(id string, available bool) error {
 	return nil
 }
 
+// EmployeesByWorkload returns a snapshot of all employees, least busy first
+// Ordered by ActiveTasks, then available before unavailable, then ID
+func (s *Store) EmployeesByWorkload() []Employee {
+	s.mu.RLock()
+	employees := make([]Employee, 0, len(s.employees))
+	for _, emp := range s.employees {
+		snapshot := *emp
+		snapshot.Skills = append([]string(nil), emp.Skills...)
+		employees = append(employees, snapshot)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(employees, func(i, j int) bool {
+		a, b := employees[i], employees[j]
+		if a.ActiveTasks != b.ActiveTasks {
+			return a.ActiveTasks < b.ActiveTasks
+		}
+		if a.IsAvailable != b.IsAvailable {
+			return a.IsAvailable
+		}
+		return a.ID < b.ID
+	})
+	return employees
+}
+
+// EmployeesByTeam returns a snapshot of the team's employees ordered by ID
+func (s *Store) EmployeesByTeam(teamID string) []Employee {
+	teamID = normalizeTeamID(teamID)
+
+	s.mu.RLock()
+	employees := make([]Employee, 0)
+	for _, emp := range s.employees {
+		if emp.TeamID != teamID {
+			continue
+		}
+		snapshot := *emp
+		snapshot.Skills = append([]string(nil), emp.Skills...)
+		employees = append(employees, snapshot)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(employees, func(i, j int) bool {
+		return employees[i].ID < employees[j].ID
+	})
+	return employees
+}
+
 // AddTask adds a new task to the store
 func (s *Store) AddTask(task *Task) error {
 	s.mu.Lock()
@@ -236,7 +673,11 @@ func (s *Store) AddTask(task *Task) error {
 	}
 
 	task.Status = TaskStatusPending
+	task.CreatedAt = s.clock.Now().UTC()
+	task.History = nil
+	s.recordTaskEventLocked(task, "")
 	s.tasks[task.ID] = task
+	s.counters.Created++
 	return nil
 }
 
@@ -252,15 +693,43 @@ func (s *Store) GetTask(id string) (*Task, error) {
 	return task, nil
 }
 
-// GetAllTasks returns all tasks
+// GetAllTasks returns snapshots of all tasks, safe to read without the lock;
+// empty stores yield an empty, non-nil slice
 func (s *Store) GetAllTasks() []*Task {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	tasks := make([]*Task, 0, len(s.tasks))
 	for _, task := range s.tasks {
-		tasks = append(tasks, task)
+		snapshot := task.snapshot()
+		tasks = append(tasks, &snapshot)
+	}
+	return tasks
+}
+
+// TasksCreatedBetween returns snapshots of the tasks created at or after after
+// and before before, ordered by creation time; a zero bound leaves that side open
+func (s *Store) TasksCreatedBetween(after, before time.Time) []*Task {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tasks := []*Task{}
+	for _, task := range s.tasks {
+		if !after.IsZero() && task.CreatedAt.Before(after) {
+			continue
+		}
+		if !before.IsZero() && !task.CreatedAt.Before(before) {
+			continue
+		}
+		snapshot := task.snapshot()
+		tasks = append(tasks, &snapshot)
 	}
+	sort.Slice(tasks, func(i, j int) bool {
+		if !tasks[i].CreatedAt.Equal(tasks[j].CreatedAt) {
+			return tasks[i].CreatedAt.Before(tasks[j].CreatedAt)
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
 	return tasks
 }
 
@@ -269,12 +738,10 @@ func (s *Store) UpdateTask(id string, status TaskStatus, employeeID string) erro
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	task, exists := s.tasks[id]
-	if !exists {
+	if _, exists := s.tasks[id]; !exists {
 		return ErrTaskNotFound
 	}
-	task.Status = status
-	task.AssignedEmployeeID = employeeID
+	s.setTaskStatusLocked(id, status, employeeID)
 	return nil
 }
 
@@ -337,246 +804,864 @@ type AssignmentResult struct {
 	Distance   float64
 	Success    bool
 	Error      error
+	// Diagnostics is set when the assignment failed with NO_ELIGIBLE_EMPLOYEE
+	Diagnostics *EligibilityDiagnostics
+	// Partial is set when the context cancelled mid-scoring and the best
+	// candidate found so far was committed (see SetPartialResultFraction)
+	Partial bool
+}
+
+// Reasons reported by EligibilityDiagnostics
+const (
+	ReasonNoSkilledEmployee = "no_skilled_employee"
+	ReasonAllBusy           = "all_busy"
+	ReasonNoneInRange       = "none_in_range"
+)
+
+// EligibilityDiagnostics breaks down why no employee was eligible for a task
+// Each count is a subset of the previous one
+type EligibilityDiagnostics struct {
+	WithSkill int `json:"with_skill"`
+	Available int `json:"available"`
+	// WithinRadius counts available employees between the task's min and max distance
+	WithinRadius int `json:"within_radius"`
+}
+
+// Reason returns the first filter that eliminated every candidate
+func (d *EligibilityDiagnostics) Reason() string {
+	switch {
+	case d.WithSkill == 0:
+		return ReasonNoSkilledEmployee
+	case d.Available == 0:
+		return ReasonAllBusy
+	default:
+		return ReasonNoneInRange
+	}
+}
+
+func (d *EligibilityDiagnostics) Error() string {
+	return fmt.Sprintf("%s: %d with skill, %d available, %d within radius",
+		d.Reason(), d.WithSkill, d.Available, d.WithinRadius)
 }
 
 // TaskAssigner handles the assignment of tasks to employees
 type TaskAssigner struct {
 	store *Store
+	// strategy is the default strategy; strategyMu lets it be swapped at runtime
+	strategy   AssignmentStrategy
+	strategyMu sync.RWMutex
+	// slots caps concurrent performAssignment runs; nil means unlimited
+	slots   chan struct{}
+	clock   Clock
+	metrics *AssignmentMetrics
+	// maxCandidates caps how many eligible employees get a full distance score (0 = all)
+	maxCandidates int
+	// ignoreDistance skips distance scoring for every task (see SetIgnoreDistance)
+	ignoreDistance bool
+	// partialFraction is the share of candidates that must be scored before a
+	// cancelled assignment may commit its best-so-far choice (0 = never)
+	partialFraction float64
+	// radiusTiers is the default tiering for tasks without RadiusTiersKm
+	radiusTiers []float64
+	// strictLocation excludes employees without a location fix (see SetStrictLocation)
+	strictLocation bool
+	// preAssignHook may veto the chosen candidate (nil = no hook)
+	preAssignHook PreAssignHook
+	// racesLeavePending keeps a CAS-losing task pending for the pool to requeue
+	racesLeavePending bool
 }
 
-// NewTaskAssigner creates a new TaskAssigner
+// NewTaskAssigner creates a new TaskAssigner using the nearest-employee strategy
+// Per-employee assignment metrics use a one hour rolling window
 func NewTaskAssigner(store *Store) *TaskAssigner {
-	return &TaskAssigner{store: store}
+	clock := Clock(realClock{})
+	return &TaskAssigner{
+		store:    store,
+		strategy: NearestStrategy{},
+		clock:    clock,
+		metrics:  NewAssignmentMetrics(clock, time.Hour),
+	}
+}
+
+// SetClock replaces the clock used for time-dependent bookkeeping
+// Must be called before the assigner is used concurrently
+func (ta *TaskAssigner) SetClock(clock Clock) {
+	ta.clock = clock
+	ta.metrics.clock = clock
 }
 
-// AssignTask assigns a task to the closest eligible employee
+// Metrics returns the per-employee assignment metrics
+func (ta *TaskAssigner) Metrics() *AssignmentMetrics {
+	return ta.metrics
+}
+
+// SetStrategy replaces the assignment strategy
+// Safe while assignments run: each one keeps the strategy it started with,
+// and assignments starting afterwards use the new one
+func (ta *TaskAssigner) SetStrategy(strategy AssignmentStrategy) {
+	ta.strategyMu.Lock()
+	ta.strategy = strategy
+	ta.strategyMu.Unlock()
+}
+
+// Strategy returns the current default assignment strategy
+func (ta *TaskAssigner) Strategy() AssignmentStrategy {
+	ta.strategyMu.RLock()
+	defer ta.strategyMu.RUnlock()
+	return ta.strategy
+}
+
+// strategyFor returns the task's requested strategy, or the assigner's default
+// Task.Validate has already rejected unknown names
+func (ta *TaskAssigner) strategyFor(task *Task) AssignmentStrategy {
+	if task.Strategy != "" {
+		if strategy, err := StrategyByName(task.Strategy); err == nil {
+			return strategy
+		}
+	}
+	return ta.Strategy()
+}
+
+// SetMaxConcurrent limits how many assignments may compute at once across all workers
+// This caps CPU usage independently of worker count and queue size; n <= 0 removes the limit
+// Must be called before the assigner is used concurrently
+func (ta *TaskAssigner) SetMaxConcurrent(n int) {
+	if n <= 0 {
+		ta.slots = nil
+		return
+	}
+	ta.slots = make(chan struct{}, n)
+}
+
+// SetMaxCandidates limits full distance scoring to the n eligible employees
+// nearest by a cheap approximate distance; n <= 0 scores everyone
+// This trades a little optimality for speed on very large fleets: strategies
+// only see those n candidates, so e.g. reverse_distance or priority_aware tier
+// preferences cannot reach employees outside them
+// Must be called before the assigner is used concurrently
+func (ta *TaskAssigner) SetMaxCandidates(n int) {
+	ta.maxCandidates = n
+}
+
+// SetIgnoreDistance makes every task take the distance-free fast path, as if
+// each had IgnoreDistance set; tasks with a distance bound still get scored
+// Must be called before the assigner is used concurrently
+func (ta *TaskAssigner) SetIgnoreDistance(ignore bool) {
+	ta.ignoreDistance = ignore
+}
+
+// SetStrictLocation treats employees at {0,0} as having no location and
+// leaves them out of distance-based assignment; ignore_distance tasks can
+// still go to them since their position doesn't matter there
+// Must be called before the assigner is used concurrently
+func (ta *TaskAssigner) SetStrictLocation(strict bool) {
+	ta.strictLocation = strict
+}
+
+// SetPartialResultFraction opts into committing a best-effort assignment when
+// the context cancels during distance scoring, provided at least fraction
+// (0 < fraction <= 1) of the candidates were already scored
+// This trades completeness for responsiveness on large fleets: the chosen
+// employee is the strategy's pick among the scored prefix only
+// fraction <= 0 restores the default of failing the task on cancellation
+// Must be called before the assigner is used concurrently
+func (ta *TaskAssigner) SetPartialResultFraction(fraction float64) {
+	if fraction > 1 {
+		fraction = 1
+	}
+	ta.partialFraction = fraction
+}
+
+// AssignTask assigns a task to the eligible employee chosen by the strategy
 // Uses context for timeout management
 // NOTE: Caller is responsible for running in goroutine if async behavior is needed
 func (ta *TaskAssigner) AssignTask(ctx context.Context, task *Task) (*AssignmentResult, error) {
+	// Only pending tasks may be assigned, however the task got re-queued
+	// This runs before the timeout check so a stale submission never fails an assigned task
+	if status, exists := ta.store.taskStatus(task.ID); exists && status != TaskStatusPending {
+		fmt.Printf("Task %s is %s, not pending; skipping assignment\n", task.ID, status)
+		return &AssignmentResult{
+			TaskID:  task.ID,
+			Success: false,
+			Error:   ErrTaskNotPending,
+		}, ErrTaskNotPending
+	}
+
 	// Check context deadline before attempting assignment
 	select {
 	case <-ctx.Done():
 		// Context already cancelled/timed out
-		ta.store.mu.Lock()
-		if t, exists := ta.store.tasks[task.ID]; exists {
-			t.Status = TaskStatusFailed
-		}
-		ta.store.mu.Unlock()
-		return nil, &TaskError{
-			Code:    ErrAssignmentTimeout.Code,
-			Message: ErrAssignmentTimeout.Message,
-			Err:     ctx.Err(),
-		}
+		return nil, ta.failTimeout(ctx, task)
 	default:
 	}
 
+	// Wait for a concurrency slot, giving up if the context expires first
+	if ta.slots != nil {
+		select {
+		case ta.slots <- struct{}{}:
+			defer func() { <-ta.slots }()
+		case <-ctx.Done():
+			return nil, ta.failTimeout(ctx, task)
+		}
+	}
+
 	// Perform assignment directly (no goroutine)
 	return ta.performAssignment(ctx, task)
 }
 
+// failTimeout marks the task failed and returns an ASSIGNMENT_TIMEOUT error
+func (ta *TaskAssigner) failTimeout(ctx context.Context, task *Task) error {
+	ta.store.mu.Lock()
+	ta.store.setTaskStatusLocked(task.ID, TaskStatusFailed, "")
+	ta.store.mu.Unlock()
+	return &TaskError{
+		Code:    ErrAssignmentTimeout.Code,
+		Message: ErrAssignmentTimeout.Message,
+		Err:     ctx.Err(),
+	}
+}
+
+// ignoresDistance reports whether proximity is irrelevant for the task, so
+// any eligible employee will do
+func (ta *TaskAssigner) ignoresDistance(task *Task) bool {
+	return task.IgnoreDistance ||
+		(ta.ignoreDistance && task.MaxDistanceKm == 0 && task.MinDistanceKm == 0 && len(task.RadiusTiersKm) == 0)
+}
+
 // performAssignment performs the actual assignment logic with two-phase locking
 // Phase 1: Read employees under RLock
-// Phase 2: Calculate distances without lock (CPU-bound work)
+// Phase 2: Calculate distances without lock (CPU-bound work), strategy picks a candidate
 // Phase 3: Atomic compare-and-swap under Lock
 func (ta *TaskAssigner) performAssignment(ctx context.Context, task *Task) (*AssignmentResult, error) {
+	ignoreDistance := ta.ignoresDistance(task)
+
 	// Phase 1: Snapshot eligible employees under read lock
+	// With maxCandidates set only the nearest K (approximate) of those who can
+	// reach the task are kept
+	maxCandidates := ta.maxCandidates
+	if ignoreDistance {
+		maxCandidates = 0
+	}
+	collector := newCandidateCollector(task.Location, maxCandidates)
 	ta.store.mu.RLock()
-	type employeeSnapshot struct {
-		id       string
-		location Location
-	}
-	var eligible []employeeSnapshot
-	for _, emp := range ta.store.employees {
-		if emp.IsAvailable && hasSkill(emp.Skills, task.RequiredSkill) {
-			eligible = append(eligible, employeeSnapshot{
-				id:       emp.ID,
-				location: emp.Location,
+	var diag EligibilityDiagnostics
+	now := ta.store.clock.Now()
+	for _, emp := range ta.store.skillCandidatesLocked(task.RequiredSkill) {
+		// A team filter hides everyone outside the team
+		if !ta.matchesLocked(task, emp) {
+			continue
+		}
+		diag.WithSkill++
+		// Reserved employees are kept for the job they were pre-committed to
+		if emp.assignableAt(now) {
+			diag.Available++
+			if maxCandidates > 0 {
+				// Under a cap, out-of-bounds employees must not take up a slot
+				if _, ok := ta.reaches(task, emp); !ok {
+					continue
+				}
+			} else if ta.strictLocation && !ignoreDistance && emp.Location.isUnknown() {
+				// An employee with no fix can't be ranked by distance; they count as out of range
+				continue
+			}
+			collector.Add(Candidate{
+				EmployeeID:  emp.ID,
+				Location:    emp.Location,
+				ActiveTasks: emp.ActiveTasks,
+				Tier:        emp.Tier,
+				MaxRangeKm:  emp.MaxRangeKm,
+				Rating:      emp.Rating,
 			})
 		}
 	}
 	ta.store.mu.RUnlock()
+	eligible := collector.Candidates()
 
 	if len(eligible) == 0 {
 		// No eligible employees, mark task as failed
-		ta.store.mu.Lock()
-		if t, exists := ta.store.tasks[task.ID]; exists {
-			t.Status = TaskStatusFailed
-			t.AssignedEmployeeID = ""
-		}
-		ta.store.mu.Unlock()
-		return &AssignmentResult{
-			TaskID:  task.ID,
-			Success: false,
-			Error:   ErrNoEligibleEmployee,
-		}, ErrNoEligibleEmployee
+		return ta.failNoEligible(task, diag)
 	}
 
 	// Phase 2: Calculate distances WITHOUT holding lock (expensive CPU work)
 	// BUT check context periodically to avoid wasted work
-	var closestID string
-	minDistance := math.MaxFloat64
-	for i, emp := range eligible {
-		// Check context every 10 employees to catch cancellation
-		if i%10 == 0 {
-			select {
-			case <-ctx.Done():
-				// Context cancelled during calculation, fail immediately
-				ta.store.mu.Lock()
-				if t, exists := ta.store.tasks[task.ID]; exists {
-					t.Status = TaskStatusFailed
-				}
-				ta.store.mu.Unlock()
-				return nil, &TaskError{
-					Code:    ErrAssignmentTimeout.Code,
-					Message: ErrAssignmentTimeout.Message,
-					Err:     ctx.Err(),
-				}
-			default:
+	// The fast path skips scoring and takes the least loaded employee instead
+	// pick and explain are kept so a vetoed choice can be re-made among the rest
+	var candidates []Candidate
+	var pick func([]Candidate) Candidate
+	var explain func([]Candidate, Candidate) *AssignmentRationale
+	partial := false
+	if ignoreDistance {
+		candidates = eligible
+		diag.WithinRadius = len(eligible)
+		pick = leastLoaded
+		explain = func(cs []Candidate, chosen Candidate) *AssignmentRationale {
+			return &AssignmentRationale{
+				Strategy:             "least_loaded",
+				CandidatesConsidered: len(cs),
+				ChosenEmployeeID:     chosen.EmployeeID,
 			}
 		}
-		distance := CalculateDistance(task.Location, emp.location)
-		if distance < minDistance {
-			minDistance = distance
-			closestID = emp.id
+	} else {
+		scored, done, err := ta.scoreCandidates(ctx, task, eligible, &diag)
+		if err != nil {
+			return nil, err
+		}
+		if len(scored) == 0 {
+			// Everyone with the skill is out of range
+			return ta.failNoEligible(task, diag)
+		}
+		partial = !done
+
+		// Only widen past a radius tier when nobody is inside it
+		var tier float64
+		candidates, tier = narrowToTier(scored, ta.radiusTiersFor(task))
+
+		// Let the strategy pick among the in-range candidates
+		strategy := ta.strategyFor(task)
+		pick = func(cs []Candidate) Candidate { return cs[strategy.Select(task, cs)] }
+		explain = func(cs []Candidate, chosen Candidate) *AssignmentRationale {
+			rationale := newRationale(strategy.Name(), cs, chosen)
+			rationale.RadiusTierKm = tier
+			return rationale
 		}
 	}
+	chosen := pick(candidates)
 
 	// Phase 3: Atomic CAS - re-check availability and assign
 	ta.store.mu.Lock()
 	defer ta.store.mu.Unlock()
 
 	// Final context check before committing assignment
-	select {
-	case <-ctx.Done():
-		if t, exists := ta.store.tasks[task.ID]; exists {
-			t.Status = TaskStatusFailed
-		}
-		return nil, &TaskError{
-			Code:    ErrAssignmentTimeout.Code,
-			Message: ErrAssignmentTimeout.Message,
-			Err:     ctx.Err(),
+	// A partial result already knows the context is done and commits anyway
+	if !partial {
+		select {
+		case <-ctx.Done():
+			ta.store.setTaskStatusLocked(task.ID, TaskStatusFailed, "")
+			return nil, &TaskError{
+				Code:    ErrAssignmentTimeout.Code,
+				Message: ErrAssignmentTimeout.Message,
+				Err:     ctx.Err(),
+			}
+		default:
 		}
-	default:
 	}
 
-	// Re-check that the closest employee is still available (CAS)
-	emp, exists := ta.store.employees[closestID]
-	if !exists || !emp.IsAvailable {
-		// Employee was assigned to another task concurrently
-		// This is NOT "no eligible employee" - it's a CAS race condition
-		if t, exists := ta.store.tasks[task.ID]; exists {
-			t.Status = TaskStatusFailed
-			t.AssignedEmployeeID = ""
-		}
+	// A dispatcher may have held or failed the task while distances were computed
+	if t, exists := ta.store.tasks[task.ID]; exists && t.Status != TaskStatusPending {
 		return &AssignmentResult{
 			TaskID:  task.ID,
 			Success: false,
-			Error:   ErrEmployeeNoLongerAvailable,
-		}, ErrEmployeeNoLongerAvailable
+			Error:   ErrTaskNotPending,
+		}, ErrTaskNotPending
 	}
 
-	// Atomically assign task and mark employee unavailable
-	emp.IsAvailable = false
+	// Re-check that the chosen employee is still available and unreserved (CAS),
+	// then let the hook veto them; a veto falls through to the next-best
+	// candidate, skipping any who became unavailable meanwhile
+	var vetoed []string
+	var lastVeto error
+	var emp *Employee
+	now = ta.store.clock.Now()
+	for {
+		var exists bool
+		emp, exists = ta.store.employees[chosen.EmployeeID]
+		if exists && emp.assignableAt(now) {
+			veto := ta.vetoLocked(task, emp)
+			if veto == nil {
+				break
+			}
+			vetoed = append(vetoed, chosen.EmployeeID)
+			lastVeto = veto
+		} else if len(vetoed) == 0 {
+			// Employee was assigned to or reserved for another task concurrently
+			// This is NOT "no eligible employee" - it's a CAS race condition
+			if !ta.racesLeavePending {
+				ta.store.setTaskStatusLocked(task.ID, TaskStatusFailed, "")
+			}
+			return &AssignmentResult{
+				TaskID:  task.ID,
+				Success: false,
+				Error:   ErrEmployeeNoLongerAvailable,
+			}, ErrEmployeeNoLongerAvailable
+		}
+
+		candidates = withoutCandidate(candidates, chosen.EmployeeID)
+		if len(candidates) == 0 {
+			ta.store.setTaskStatusLocked(task.ID, TaskStatusFailed, "")
+			err := &TaskError{Code: ErrAssignmentVetoed.Code, Message: ErrAssignmentVetoed.Message, Err: lastVeto}
+			return &AssignmentResult{
+				TaskID:  task.ID,
+				Success: false,
+				Error:   err,
+			}, err
+		}
+		chosen = pick(candidates)
+	}
+	rationale := explain(candidates, chosen)
+	rationale.VetoedEmployeeIDs = vetoed
+
+	// Atomically assign task; the employee stays available until at capacity
+	emp.ActiveTasks++
+	emp.IsAvailable = emp.ActiveTasks < emp.maxActiveTasks()
+	ta.metrics.Record(emp.ID)
+	ta.store.setTaskStatusLocked(task.ID, TaskStatusAssigned, chosen.EmployeeID)
 	if t, exists := ta.store.tasks[task.ID]; exists {
-		t.Status = TaskStatusAssigned
-		t.AssignedEmployeeID = closestID
+		assignedAt := ta.clock.Now().UTC()
+		t.AssignedAt = &assignedAt
+		t.AssignedDistanceKm = chosen.Distance
+		t.Rationale = rationale
 	}
 
 	return &AssignmentResult{
 		TaskID:     task.ID,
-		EmployeeID: closestID,
-		Distance:   minDistance,
+		EmployeeID: chosen.EmployeeID,
+		Distance:   chosen.Distance,
 		Success:    true,
+		Partial:    partial,
 	}, nil
 }
 
+// scoreCandidates computes each candidate's distance and keeps those within the
+// task's distance bounds, counting them in diag.WithinRadius
+// done is false when the context cancelled and the partial-result option let
+// the scored prefix stand; a cancellation that doesn't qualify fails the task
+func (ta *TaskAssigner) scoreCandidates(ctx context.Context, task *Task, eligible []Candidate, diag *EligibilityDiagnostics) (candidates []Candidate, done bool, err error) {
+	candidates = eligible[:0]
+	for i, emp := range eligible {
+		// Check context every 10 employees to catch cancellation
+		if i%10 == 0 {
+			select {
+			case <-ctx.Done():
+				// With the option on, keep the work done so far if enough was scored
+				if ta.partialFraction > 0 && len(candidates) > 0 &&
+					float64(i) >= ta.partialFraction*float64(len(eligible)) {
+					return candidates, false, nil
+				}
+				// Context cancelled during calculation, fail immediately
+				ta.store.mu.Lock()
+				ta.store.setTaskStatusLocked(task.ID, TaskStatusFailed, "")
+				ta.store.mu.Unlock()
+				return nil, false, &TaskError{
+					Code:    ErrAssignmentTimeout.Code,
+					Message: ErrAssignmentTimeout.Message,
+					Err:     ctx.Err(),
+				}
+			default:
+			}
+		}
+		// Employees beyond the task radius or their own range are not eligible,
+		// and ones closer than the minimum are likely bad data (e.g. colocated with the task)
+		distance, ok := ta.reachesFrom(task, emp.Location, emp.MaxRangeKm)
+		if !ok {
+			continue
+		}
+		emp.Distance = distance
+		diag.WithinRadius++
+		candidates = append(candidates, emp)
+	}
+	return candidates, true, nil
+}
+
+// leastLoaded returns the candidate with the fewest active tasks (first wins ties)
+func leastLoaded(candidates []Candidate) Candidate {
+	best := candidates[0]
+	for _, c := range candidates[1:] {
+		if c.ActiveTasks < best.ActiveTasks {
+			best = c
+		}
+	}
+	return best
+}
+
+// failNoEligible marks the task failed and returns a NO_ELIGIBLE_EMPLOYEE result
+// The returned error is the ErrNoEligibleEmployee sentinel; AssignmentResult.Error
+// wraps the diagnostic breakdown explaining why nobody qualified
+func (ta *TaskAssigner) failNoEligible(task *Task, diag EligibilityDiagnostics) (*AssignmentResult, error) {
+	ta.store.mu.Lock()
+	ta.store.setTaskStatusLocked(task.ID, TaskStatusFailed, "")
+	ta.store.mu.Unlock()
+
+	return &AssignmentResult{
+		TaskID:  task.ID,
+		Success: false,
+		Error: &TaskError{
+			Code:    ErrNoEligibleEmployee.Code,
+			Message: ErrNoEligibleEmployee.Message,
+			Err:     &diag,
+		},
+		Diagnostics: &diag,
+	}, ErrNoEligibleEmployee
+}
+
+// MaxWorkers is the upper bound for the worker pool size
+const MaxWorkers = 100
+
 // AssignmentWorkerPool manages concurrent task assignments
 type AssignmentWorkerPool struct {
-	assigner   *TaskAssigner
-	taskQueue  chan *Task
-	numWorkers int
-	timeout    time.Duration
-	wg         sync.WaitGroup
+	assigner  *TaskAssigner
+	taskQueue *TaskQueue
+	spillover *TaskQueue // optional overflow queue, nil rejects with QUEUE_FULL
+	// maxAttempts caps worker passes per task across requeues (0 = unlimited)
+	maxAttempts int
+	numWorkers  int
+	timeout     time.Duration
+	// skillTimeouts overrides timeout for tasks requiring the mapped skills
+	skillTimeouts map[string]time.Duration
+	wg            sync.WaitGroup
+	notifier      *WebhookNotifier // optional, nil disables webhooks
+	breaker       *CircuitBreaker  // optional, nil disables short-circuiting
+
+	// casRaceAction decides what happens to a task that lost a CAS race;
+	// raceRequeues counts requeues per task (guarded by queuedMu)
+	casRaceAction   CASRaceAction
+	maxRaceRequeues int
+	raceRequeues    map[string]int
+
+	// strictFIFO serializes pop+assign under fifoMu so commits follow submission order
+	strictFIFO bool
+	fifoMu     sync.Mutex
+
+	// queued tracks task IDs that are in the queue or being processed;
+	// processing holds the subset a worker has picked up
+	queued     map[string]struct{}
+	processing map[string]processingEntry
+	queuedMu   sync.Mutex
+	// drainTimeout bounds Shutdown's wait for workers (0 = wait indefinitely)
+	drainTimeout time.Duration
+
+	// workers holds one quit channel per running worker (guarded by workersMu)
+	workersMu    sync.Mutex
+	workers      []chan struct{}
+	nextWorkerID int
+	ctx          context.Context
+	cancel       context.CancelFunc
+	started      bool
+	stopped      bool
+	active       atomic.Int32
 }
 
 // NewAssignmentWorkerPool creates a new worker pool
 func NewAssignmentWorkerPool(assigner *TaskAssigner, numWorkers int, timeout time.Duration) *AssignmentWorkerPool {
 	return &AssignmentWorkerPool{
 		assigner:   assigner,
-		taskQueue:  make(chan *Task, 100),
+		taskQueue:  NewTaskQueue(DefaultQueueSize, DefaultQueueAgingInterval, assigner.clock),
 		numWorkers: numWorkers,
 		timeout:    timeout,
+		queued:     make(map[string]struct{}),
+		processing: make(map[string]processingEntry),
+
+		casRaceAction:   CASRaceFail,
+		maxRaceRequeues: DefaultMaxRaceRequeues,
+		raceRequeues:    make(map[string]int),
 	}
 }
 
+// SetQueueSize replaces the primary queue with an empty one of the given capacity
+// Must be called before the pool is started
+func (pool *AssignmentWorkerPool) SetQueueSize(capacity int) {
+	pool.taskQueue = NewTaskQueue(capacity, pool.taskQueue.agingInterval, pool.assigner.clock)
+	pool.taskQueue.SetFIFO(pool.strictFIFO)
+}
+
 // Start starts the worker pool
-func (pool *AssignmentWorkerPool) Start(ctx context.Context) {
+// A non-positive worker count is rejected; the pool then refuses submissions
+// with NO_WORKERS instead of silently queueing tasks that nobody will process
+func (pool *AssignmentWorkerPool) Start(ctx context.Context) error {
+	pool.workersMu.Lock()
+	defer pool.workersMu.Unlock()
+
+	pool.started = true
+	// The pool's own cancel lets a timed-out drain abort in-flight assignments
+	pool.ctx, pool.cancel = context.WithCancel(ctx)
+	if pool.numWorkers < 1 {
+		return ErrInvalidWorkerCount
+	}
 	for i := 0; i < pool.numWorkers; i++ {
+		pool.spawnLocked()
+	}
+	if pool.spillover != nil {
 		pool.wg.Add(1)
-		go pool.worker(ctx, i)
+		go pool.spilloverWorker()
 	}
+	return nil
+}
+
+// Resize grows or shrinks the running pool to n workers
+// Surplus workers finish their current task before exiting
+func (pool *AssignmentWorkerPool) Resize(n int) error {
+	if n < 1 || n > MaxWorkers {
+		return ErrInvalidWorkerCount
+	}
+
+	pool.workersMu.Lock()
+	defer pool.workersMu.Unlock()
+
+	if pool.stopped {
+		return ErrPoolStopped
+	}
+	pool.numWorkers = n
+	if pool.ctx == nil {
+		// Not started yet; Start will spawn n workers
+		return nil
+	}
+	for len(pool.workers) < n {
+		pool.spawnLocked()
+	}
+	for len(pool.workers) > n {
+		last := len(pool.workers) - 1
+		close(pool.workers[last])
+		pool.workers = pool.workers[:last]
+	}
+	return nil
+}
+
+// noWorkers reports whether the pool was started but has no running workers
+// A pool that was never started still accepts tasks so they can queue up
+func (pool *AssignmentWorkerPool) noWorkers() bool {
+	pool.workersMu.Lock()
+	started := pool.started && !pool.stopped
+	pool.workersMu.Unlock()
+	return started && pool.ActiveWorkers() == 0
+}
+
+// ActiveWorkers returns the number of worker goroutines currently running
+func (pool *AssignmentWorkerPool) ActiveWorkers() int {
+	return int(pool.active.Load())
+}
+
+// spawnLocked starts one worker; caller must hold workersMu
+func (pool *AssignmentWorkerPool) spawnLocked() {
+	quit := make(chan struct{})
+	pool.workers = append(pool.workers, quit)
+	pool.wg.Add(1)
+	pool.active.Add(1)
+	go pool.worker(pool.ctx, pool.nextWorkerID, quit)
+	pool.nextWorkerID++
 }
 
 // worker processes tasks from the queue
-// Shutdown is triggered by closing taskQueue channel (not context)
+// Shutdown is triggered by closing taskQueue (not context);
+// closing quit retires this worker alone when the pool is resized down
 // Context is only used for per-task timeouts
-func (pool *AssignmentWorkerPool) worker(ctx context.Context, workerID int) {
+func (pool *AssignmentWorkerPool) worker(ctx context.Context, workerID int, quit <-chan struct{}) {
 	defer pool.wg.Done()
+	defer pool.active.Add(-1)
 
-	// Single shutdown mechanism: closed channel
-	for task := range pool.taskQueue {
-		// Nil-safety: should never happen, but defensive check
-		if task == nil {
-			fmt.Printf("Worker %d: Received nil task, skipping\n", workerID)
-			continue
-		}
-
-		// Check if shutdown context is cancelled (for graceful drain)
+	for {
 		select {
-		case <-ctx.Done():
-			// Context cancelled but channel not closed yet
-			// Fail remaining tasks quickly
-			fmt.Printf("Worker %d: Context cancelled, failing task %s\n", workerID, task.ID)
-			pool.assigner.store.mu.Lock()
-			if t, exists := pool.assigner.store.tasks[task.ID]; exists {
-				t.Status = TaskStatusFailed
+		case <-quit:
+			fmt.Printf("Worker %d: Retired by resize, exiting\n", workerID)
+			return
+		case _, ok := <-pool.taskQueue.Ready():
+			if !ok {
+				fmt.Printf("Worker %d: Queue closed, exiting\n", workerID)
+				return
 			}
-			pool.assigner.store.mu.Unlock()
-			continue
-		default:
 		}
+		pool.popAndProcess(ctx, fmt.Sprintf("Worker %d", workerID), pool.taskQueue)
+	}
+}
 
-		// Normal processing with per-task timeout
-		assignCtx, cancel := context.WithTimeout(ctx, pool.timeout)
-		_, err := pool.assigner.AssignTask(assignCtx, task)
-		if err != nil {
-			fmt.Printf("Worker %d: Failed to assign task %s: %v\n", workerID, task.ID, err)
-		} else {
-			fmt.Printf("Worker %d: Successfully assigned task %s\n", workerID, task.ID)
-		}
+// popAndProcess takes the next task from q and processes it
+// In strict FIFO mode both steps run under fifoMu, so tasks are committed in
+// the order they were popped, one at a time
+func (pool *AssignmentWorkerPool) popAndProcess(ctx context.Context, name string, q *TaskQueue) {
+	if pool.strictFIFO {
+		pool.fifoMu.Lock()
+		defer pool.fifoMu.Unlock()
+	}
+
+	// Nil-safety: should never happen, but defensive check
+	task := q.Pop()
+	if task == nil {
+		fmt.Printf("%s: Received nil task, skipping\n", name)
+		return
+	}
+	pool.process(ctx, name, task)
+}
+
+// process runs one dequeued task through the checks and the assigner
+// name labels log lines (e.g. "Worker 3")
+func (pool *AssignmentWorkerPool) process(ctx context.Context, name string, task *Task) {
+	pool.markProcessing(task.ID, name)
+	defer pool.clearProcessing(task.ID)
+
+	// Only pending tasks are processed: held tasks are parked until released
+	// (which re-queues them), anything else was re-queued after it finished
+	if status, exists := pool.assigner.store.taskStatus(task.ID); exists && status != TaskStatusPending {
+		fmt.Printf("%s: Task %s is %s, skipping\n", name, task.ID, status)
+		pool.clearQueued(task.ID)
+		return
+	}
+
+	pool.assigner.store.recordAttempt(task.ID)
+
+	// Check if shutdown context is cancelled (for graceful drain)
+	select {
+	case <-ctx.Done():
+		// Context cancelled but channel not closed yet
+		// Fail remaining tasks quickly
+		fmt.Printf("%s: Context cancelled, failing task %s\n", name, task.ID)
+		pool.assigner.store.mu.Lock()
+		pool.assigner.store.setTaskStatusLocked(task.ID, TaskStatusFailed, "")
+		pool.assigner.store.mu.Unlock()
+		pool.notify(task.ID, nil, ctx.Err())
+		pool.clearQueued(task.ID)
+		return
+	default:
+	}
+
+	// Short-circuit while the breaker is open instead of running a futile search
+	if pool.breaker != nil && !pool.breaker.Allow() {
+		fmt.Printf("%s: Circuit open, failing task %s\n", name, task.ID)
+		pool.assigner.store.mu.Lock()
+		pool.assigner.store.transitionTaskLocked(task.ID, TaskStatusFailed, "", ErrCircuitOpen.Code)
+		pool.assigner.store.mu.Unlock()
+		pool.notify(task.ID, nil, ErrCircuitOpen)
+		pool.clearQueued(task.ID)
+		return
+	}
+
+	// Normal processing with per-task timeout
+	assignCtx, cancel := context.WithTimeout(ctx, pool.timeoutFor(task))
+	result, err := pool.assigner.AssignTask(assignCtx, task)
+	pool.recordOutcome(err)
+	if errors.Is(err, ErrEmployeeNoLongerAvailable) && pool.casRaceAction == CASRaceRequeue {
 		cancel()
+		if err = pool.requeueAfterRace(name, task); err == nil {
+			return
+		}
+	}
+	if err != nil {
+		// Prefer the result's error, which carries failure diagnostics
+		if result != nil && result.Error != nil {
+			err = result.Error
+		}
+		fmt.Printf("%s: Failed to assign task %s: %v\n", name, task.ID, err)
+	} else {
+		fmt.Printf("%s: Successfully assigned task %s\n", name, task.ID)
 	}
+	pool.notify(task.ID, result, err)
+	cancel()
+	pool.clearQueued(task.ID)
+}
 
-	fmt.Printf("Worker %d: Queue closed, exiting\n", workerID)
+// recordOutcome feeds an assignment result into the circuit breaker
+// A CAS race or a task held or failed mid-assignment means an eligible employee existed,
+// so both count as a success
+func (pool *AssignmentWorkerPool) recordOutcome(err error) {
+	if pool.breaker == nil {
+		return
+	}
+	if err == nil || errors.Is(err, ErrEmployeeNoLongerAvailable) || errors.Is(err, ErrTaskNotPending) {
+		pool.breaker.RecordSuccess()
+		return
+	}
+	pool.breaker.RecordFailure()
+}
+
+// notify sends the assignment outcome to the webhook notifier, if configured
+func (pool *AssignmentWorkerPool) notify(taskID string, result *AssignmentResult, err error) {
+	if pool.notifier == nil {
+		return
+	}
+	event := WebhookEvent{
+		Type:   WebhookEventTaskAssigned,
+		TaskID: taskID,
+		Status: TaskStatusAssigned,
+	}
+	if err != nil {
+		event.Type = WebhookEventTaskFailed
+		event.Status = TaskStatusFailed
+		event.Error = err.Error()
+	} else if result != nil {
+		event.EmployeeID = result.EmployeeID
+	}
+	pool.notifier.Notify(event)
 }
 
 // SubmitTask submits a task to the worker pool (non-blocking)
-// Returns error if queue is full
+// Returns error if the queue (and spillover, if any) is full, no workers are running,
+// the pool is shutting down, the task is already queued or being processed, or it
+// has used up its attempts (in which case it is failed permanently)
 func (pool *AssignmentWorkerPool) SubmitTask(task *Task) error {
-	select {
-	case pool.taskQueue <- task:
-		return nil
-	default:
+	if pool.shuttingDown() {
+		return ErrPoolShuttingDown
+	}
+	if pool.noWorkers() {
+		return ErrNoWorkers
+	}
+	// A task that keeps bouncing back is failed for good instead of requeued forever
+	if pool.exhausted(task.ID) {
+		return ErrMaxAttemptsExceeded
+	}
+
+	pool.queuedMu.Lock()
+	if _, exists := pool.queued[task.ID]; exists {
+		pool.queuedMu.Unlock()
+		return ErrDuplicateSubmission
+	}
+	pool.queued[task.ID] = struct{}{}
+	pool.queuedMu.Unlock()
+
+	// Overflow goes to the spillover queue, if configured, before rejecting
+	var pushed bool
+	if pool.strictFIFO && pool.spillover != nil && pool.spillover.Len() > 0 {
+		// Queue behind the overflow so nothing newer overtakes it
+		pushed = pool.spillover.Push(task)
+	} else {
+		pushed = pool.taskQueue.Push(task) || (pool.spillover != nil && pool.spillover.Push(task))
+	}
+	if !pushed {
+		pool.clearQueued(task.ID)
+		// Shutdown marks the pool stopped before closing the queues, so a push
+		// refused by a closed queue always sees the flag here
+		if pool.shuttingDown() {
+			return ErrPoolShuttingDown
+		}
 		return &TaskError{
 			Code:    "QUEUE_FULL",
 			Message: "Worker pool queue is full, please try again later",
 		}
 	}
+	return nil
+}
+
+// shuttingDown reports whether Shutdown has been called
+func (pool *AssignmentWorkerPool) shuttingDown() bool {
+	pool.workersMu.Lock()
+	defer pool.workersMu.Unlock()
+	return pool.stopped
+}
+
+// IsQueued reports whether a task is waiting in the queue or being processed
+func (pool *AssignmentWorkerPool) IsQueued(taskID string) bool {
+	pool.queuedMu.Lock()
+	defer pool.queuedMu.Unlock()
+
+	_, ok := pool.queued[taskID]
+	return ok
+}
+
+// clearQueued removes a task from the in-queue set
+func (pool *AssignmentWorkerPool) clearQueued(taskID string) {
+	pool.queuedMu.Lock()
+	delete(pool.queued, taskID)
+	delete(pool.raceRequeues, taskID)
+	pool.queuedMu.Unlock()
 }
 
 // Shutdown gracefully shuts down the worker pool
-// Closes the queue and waits for all workers to finish
+// Closes the queue and waits for all workers to finish, for at most the
+// drain timeout if one is set (see SetDrainTimeout)
 func (pool *AssignmentWorkerPool) Shutdown() {
-	close(pool.taskQueue)
-	pool.wg.Wait()
+	pool.workersMu.Lock()
+	pool.stopped = true
+	pool.workersMu.Unlock()
+
+	pool.taskQueue.Close()
+	if pool.spillover != nil {
+		pool.spillover.Close()
+	}
+	pool.waitDrained()
 }
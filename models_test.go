@@ -2,8 +2,12 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -529,6 +533,85 @@ func TestSkillNormalization(t *testing.T) {
 	}
 }
 
+// TestSkillDeduplication tests that skills differing only in case or spacing collapse to one entry
+func TestSkillDeduplication(t *testing.T) {
+	emp := &Employee{
+		ID:          "emp1",
+		Name:        "Alice",
+		Location:    Location{Lat: 60.1699, Lon: 24.9384},
+		Skills:      []string{"delivery", "Driving", "Delivery", " DELIVERY ", "driving"},
+		IsAvailable: true,
+	}
+
+	if err := emp.Validate(); err != nil {
+		t.Fatalf("Validation failed: %v", err)
+	}
+
+	want := []string{"delivery", "driving"}
+	if !reflect.DeepEqual(emp.Skills, want) {
+		t.Errorf("Skills = %v, want %v", emp.Skills, want)
+	}
+}
+
+// TestSkillDiacriticFolding tests that accented Latin skills match their
+// unaccented form while other scripts keep their marks
+func TestSkillDiacriticFolding(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"café_service", "cafe_service"},
+		{"Cafe\u0301_Service", "cafe_service"},
+		{"Ñoño", "nono"},
+		{"ＤＥＬＩＶＥＲＹ", "delivery"},
+		{"ﬁtting", "fitting"},
+		{"доставка", "доставка"},
+		{"йога", "йога"},
+		{"配達", "配達"},
+		{"ガス", "ガス"},
+		{"डिलीवरी", "डिलीवरी"},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeSkill(tt.input); got != tt.want {
+			t.Errorf("normalizeSkill(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	store := NewStore()
+	emp := &Employee{
+		ID:          "emp1",
+		Name:        "Alice",
+		Location:    Location{Lat: 60.1699, Lon: 24.9384},
+		Skills:      []string{"Café_Service", "cafe_service", "йога"},
+		IsAvailable: true,
+	}
+	if err := emp.Validate(); err != nil {
+		t.Fatalf("Validation failed: %v", err)
+	}
+	if want := []string{"cafe_service", "йога"}; !reflect.DeepEqual(emp.Skills, want) {
+		t.Errorf("Skills = %v, want %v", emp.Skills, want)
+	}
+	store.AddEmployee(emp)
+
+	for _, skill := range []string{"cafe_service", "café_service", "CAFÉ_SERVICE", "ЙОГА"} {
+		if !hasSkill(emp.Skills, skill) || len(store.GetAvailableEmployees(skill)) != 1 {
+			t.Errorf("Expected employee to match %q", skill)
+		}
+	}
+	if hasSkill(emp.Skills, "иога") {
+		t.Error("Cyrillic й should not fold to и")
+	}
+
+	task := &Task{ID: "task1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "Café_Service"}
+	if err := task.Validate(); err != nil {
+		t.Fatalf("Task validation failed: %v", err)
+	}
+	if task.RequiredSkill != "cafe_service" {
+		t.Errorf("RequiredSkill = %q, want %q", task.RequiredSkill, "cafe_service")
+	}
+}
+
 // TestEmptySkillsValidation tests empty skills array validation
 func TestEmptySkillsValidation(t *testing.T) {
 	emp := &Employee{
@@ -680,3 +763,769 @@ func BenchmarkAssignTask(b *testing.B) {
 		cancel()
 	}
 }
+
+// TestNoEligibleEmployeeDiagnostics tests the breakdown attached to NO_ELIGIBLE_EMPLOYEE failures
+func TestNoEligibleEmployeeDiagnostics(t *testing.T) {
+	tests := []struct {
+		name      string
+		employees []*Employee
+		radiusKm  float64
+		want      EligibilityDiagnostics
+		reason    string
+	}{
+		{
+			name: "Nobody has the skill",
+			employees: []*Employee{
+				{ID: "emp1", Location: Location{Lat: 60.1699, Lon: 24.9384}, Skills: []string{"cooking"}, IsAvailable: true},
+			},
+			want:   EligibilityDiagnostics{WithSkill: 0, Available: 0, WithinRadius: 0},
+			reason: ReasonNoSkilledEmployee,
+		},
+		{
+			name: "Everyone is busy",
+			employees: []*Employee{
+				{ID: "emp1", Location: Location{Lat: 60.1699, Lon: 24.9384}, Skills: []string{"delivery"}, IsAvailable: false},
+				{ID: "emp2", Location: Location{Lat: 60.1741, Lon: 24.9416}, Skills: []string{"delivery"}, IsAvailable: false},
+			},
+			want:   EligibilityDiagnostics{WithSkill: 2, Available: 0, WithinRadius: 0},
+			reason: ReasonAllBusy,
+		},
+		{
+			name: "Everyone is out of range",
+			employees: []*Employee{
+				{ID: "emp1", Location: Location{Lat: 60.2055, Lon: 24.6559}, Skills: []string{"delivery"}, IsAvailable: true}, // Espoo, ~16 km
+				{ID: "emp2", Location: Location{Lat: 60.1741, Lon: 24.9416}, Skills: []string{"delivery"}, IsAvailable: false},
+			},
+			radiusKm: 5,
+			want:     EligibilityDiagnostics{WithSkill: 2, Available: 1, WithinRadius: 0},
+			reason:   ReasonNoneInRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewStore()
+			assigner := NewTaskAssigner(store)
+			for _, emp := range tt.employees {
+				store.AddEmployee(emp)
+			}
+
+			task := &Task{
+				ID:            "task1",
+				Location:      Location{Lat: 60.1700, Lon: 24.9400},
+				RequiredSkill: "delivery",
+				MaxDistanceKm: tt.radiusKm,
+			}
+			store.AddTask(task)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			result, err := assigner.AssignTask(ctx, task)
+			if err != ErrNoEligibleEmployee {
+				t.Fatalf("Expected ErrNoEligibleEmployee, got %v", err)
+			}
+			if result == nil || result.Diagnostics == nil {
+				t.Fatal("Expected diagnostics on the assignment result")
+			}
+			if *result.Diagnostics != tt.want {
+				t.Errorf("Diagnostics = %+v, want %+v", *result.Diagnostics, tt.want)
+			}
+			if result.Diagnostics.Reason() != tt.reason {
+				t.Errorf("Reason = %s, want %s", result.Diagnostics.Reason(), tt.reason)
+			}
+			if !errors.Is(result.Error, ErrNoEligibleEmployee) {
+				t.Errorf("Expected result error to match ErrNoEligibleEmployee, got %v", result.Error)
+			}
+			if !strings.Contains(result.Error.Error(), tt.reason) {
+				t.Errorf("Expected result error to mention %s, got %v", tt.reason, result.Error)
+			}
+		})
+	}
+}
+
+// concurrencyProbeStrategy records how many Select calls run at the same time
+type concurrencyProbeStrategy struct {
+	mu      sync.Mutex
+	current int
+	max     int
+}
+
+func (p *concurrencyProbeStrategy) Name() string { return "probe" }
+
+func (p *concurrencyProbeStrategy) Select(task *Task, candidates []Candidate) int {
+	p.mu.Lock()
+	p.current++
+	if p.current > p.max {
+		p.max = p.current
+	}
+	p.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	p.mu.Lock()
+	p.current--
+	p.mu.Unlock()
+	return 0
+}
+
+// TestAssignerMaxConcurrent tests that no more than N assignments compute at once
+func TestAssignerMaxConcurrent(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+	probe := &concurrencyProbeStrategy{}
+	assigner.SetStrategy(probe)
+	assigner.SetMaxConcurrent(2)
+
+	const numTasks = 12
+	for i := 0; i < numTasks; i++ {
+		store.AddEmployee(&Employee{
+			ID:          fmt.Sprintf("emp-%d", i),
+			Location:    Location{Lat: 60.1699, Lon: 24.9384},
+			Skills:      []string{"delivery"},
+			IsAvailable: true,
+		})
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < numTasks; i++ {
+		task := &Task{
+			ID:            fmt.Sprintf("task-%d", i),
+			Location:      Location{Lat: 60.1700, Lon: 24.9400},
+			RequiredSkill: "delivery",
+		}
+		store.AddTask(task)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			assigner.AssignTask(ctx, task)
+		}()
+	}
+	wg.Wait()
+
+	if probe.max > 2 {
+		t.Errorf("Expected at most 2 concurrent assignments, observed %d", probe.max)
+	}
+	if probe.max < 1 {
+		t.Error("Expected assignments to run")
+	}
+}
+
+// TestAssignerMaxConcurrentRespectsContext tests that waiting for a slot honors cancellation
+func TestAssignerMaxConcurrentRespectsContext(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+	assigner.SetMaxConcurrent(1)
+
+	// Occupy the only slot
+	assigner.slots <- struct{}{}
+	defer func() { <-assigner.slots }()
+
+	task := &Task{
+		ID:            "task1",
+		Location:      Location{Lat: 60.1700, Lon: 24.9400},
+		RequiredSkill: "delivery",
+	}
+	store.AddTask(task)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := assigner.AssignTask(ctx, task)
+	if !errors.Is(err, ErrAssignmentTimeout) {
+		t.Errorf("Expected ErrAssignmentTimeout while waiting for a slot, got %v", err)
+	}
+	if task.Status != TaskStatusFailed {
+		t.Errorf("Task status = %s, want %s", task.Status, TaskStatusFailed)
+	}
+}
+
+// TestEmployeesByWorkload tests that employees are sorted least busy first
+func TestEmployeesByWorkload(t *testing.T) {
+	store := NewStore()
+
+	employees := []*Employee{
+		{ID: "busy", Skills: []string{"delivery"}, Capacity: 3, ActiveTasks: 2, IsAvailable: true},
+		{ID: "full", Skills: []string{"delivery"}, Capacity: 1, ActiveTasks: 1, IsAvailable: false},
+		{ID: "idle", Skills: []string{"delivery"}, Capacity: 2, ActiveTasks: 0, IsAvailable: true},
+		{ID: "offline", Skills: []string{"delivery"}, Capacity: 1, ActiveTasks: 0, IsAvailable: false},
+	}
+	for _, emp := range employees {
+		store.AddEmployee(emp)
+	}
+
+	got := store.EmployeesByWorkload()
+	want := []string{"idle", "offline", "full", "busy"}
+	// full and busy differ in ActiveTasks (1 vs 2), so full comes first
+	if len(got) != len(want) {
+		t.Fatalf("EmployeesByWorkload() returned %d employees, want %d", len(got), len(want))
+	}
+	for i, id := range want {
+		if got[i].ID != id {
+			t.Errorf("Position %d = %s, want %s", i, got[i].ID, id)
+		}
+	}
+}
+
+// TestAssignmentRespectsCapacity tests that an employee stays available until at capacity
+func TestAssignmentRespectsCapacity(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+
+	emp := &Employee{
+		ID:          "emp1",
+		Location:    Location{Lat: 60.1699, Lon: 24.9384},
+		Skills:      []string{"delivery"},
+		Capacity:    2,
+		IsAvailable: true,
+	}
+	store.AddEmployee(emp)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for i := 0; i < 2; i++ {
+		task := &Task{
+			ID:            fmt.Sprintf("task-%d", i),
+			Location:      Location{Lat: 60.1700, Lon: 24.9400},
+			RequiredSkill: "delivery",
+		}
+		store.AddTask(task)
+		if _, err := assigner.AssignTask(ctx, task); err != nil {
+			t.Fatalf("AssignTask() #%d unexpected error: %v", i, err)
+		}
+		wantAvailable := i == 0
+		if emp.IsAvailable != wantAvailable {
+			t.Errorf("After %d assignments IsAvailable = %v, want %v", i+1, emp.IsAvailable, wantAvailable)
+		}
+	}
+	if emp.ActiveTasks != 2 {
+		t.Errorf("ActiveTasks = %d, want 2", emp.ActiveTasks)
+	}
+}
+
+// TestSubmitTaskDuplicate tests that a task already queued or processing can't be submitted again
+func TestSubmitTaskDuplicate(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+	pool := NewAssignmentWorkerPool(assigner, 1, 5*time.Second)
+
+	task := &Task{
+		ID:            "task1",
+		Location:      Location{Lat: 60.1700, Lon: 24.9400},
+		RequiredSkill: "delivery",
+	}
+	store.AddTask(task)
+
+	if err := pool.SubmitTask(task); err != nil {
+		t.Fatalf("First SubmitTask() unexpected error: %v", err)
+	}
+
+	err := pool.SubmitTask(task)
+	if !errors.Is(err, ErrDuplicateSubmission) {
+		t.Fatalf("Second SubmitTask() expected DUPLICATE_SUBMISSION, got %v", err)
+	}
+	if pool.taskQueue.Len() != 1 {
+		t.Errorf("Expected 1 task in queue, got %d", pool.taskQueue.Len())
+	}
+
+	// Once the worker has finished, the task may be submitted again
+	pool.Start(context.Background())
+	deadline := time.Now().Add(2 * time.Second)
+	for pool.IsQueued(task.ID) && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if err := pool.SubmitTask(task); err != nil {
+		t.Errorf("SubmitTask() after processing unexpected error: %v", err)
+	}
+	pool.Shutdown()
+}
+
+// TestWorkerPoolResize tests growing and shrinking the running pool
+func TestWorkerPoolResize(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+	pool := NewAssignmentWorkerPool(assigner, 2, 5*time.Second)
+	pool.Start(context.Background())
+	defer pool.Shutdown()
+
+	waitForWorkers := func(want int) {
+		t.Helper()
+		deadline := time.Now().Add(time.Second)
+		for time.Now().Before(deadline) && pool.ActiveWorkers() != want {
+			time.Sleep(time.Millisecond)
+		}
+		if got := pool.ActiveWorkers(); got != want {
+			t.Fatalf("ActiveWorkers() = %d, want %d", got, want)
+		}
+	}
+
+	waitForWorkers(2)
+
+	if err := pool.Resize(6); err != nil {
+		t.Fatalf("Resize(6) unexpected error: %v", err)
+	}
+	waitForWorkers(6)
+
+	if err := pool.Resize(1); err != nil {
+		t.Fatalf("Resize(1) unexpected error: %v", err)
+	}
+	waitForWorkers(1)
+
+	for _, n := range []int{0, -1, MaxWorkers + 1} {
+		if err := pool.Resize(n); !errors.Is(err, ErrInvalidWorkerCount) {
+			t.Errorf("Resize(%d) expected INVALID_WORKER_COUNT, got %v", n, err)
+		}
+	}
+
+	// The remaining worker still processes tasks
+	store.AddEmployee(&Employee{
+		ID:          "emp1",
+		Name:        "John",
+		Location:    Location{Lat: 60.1699, Lon: 24.9384},
+		Skills:      []string{"delivery"},
+		IsAvailable: true,
+	})
+	task := &Task{ID: "task1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"}
+	store.AddTask(task)
+	if err := pool.SubmitTask(task); err != nil {
+		t.Fatalf("SubmitTask() unexpected error: %v", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		store.mu.RLock()
+		status := store.tasks["task1"].Status
+		store.mu.RUnlock()
+		if status == TaskStatusAssigned {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Error("Task was not assigned after resizing down")
+}
+
+// TestTaskAssignmentTeamFilter tests that a team filter ignores eligible employees from other teams
+func TestTaskAssignmentTeamFilter(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+
+	// emp1 is closest but in another team; emp2 is further away but in the right team
+	store.AddEmployee(&Employee{
+		ID:          "emp1",
+		Name:        "John",
+		Location:    Location{Lat: 60.1700, Lon: 24.9400},
+		Skills:      []string{"delivery"},
+		IsAvailable: true,
+		TeamID:      "north",
+	})
+	store.AddEmployee(&Employee{
+		ID:          "emp2",
+		Name:        "Jane",
+		Location:    Location{Lat: 60.2000, Lon: 25.0000},
+		Skills:      []string{"delivery"},
+		IsAvailable: true,
+		TeamID:      "south",
+	})
+
+	task := &Task{
+		ID:            "task1",
+		Location:      Location{Lat: 60.1700, Lon: 24.9400},
+		RequiredSkill: "delivery",
+		TeamID:        " South ",
+	}
+	if err := task.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	store.AddTask(task)
+
+	result, err := assigner.AssignTask(context.Background(), task)
+	if err != nil {
+		t.Fatalf("AssignTask() unexpected error: %v", err)
+	}
+	if result.EmployeeID != "emp2" {
+		t.Errorf("Expected team member emp2, got %s", result.EmployeeID)
+	}
+
+	// No available member left in the team: other teams are still ignored
+	task2 := &Task{ID: "task2", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery", TeamID: "south"}
+	store.AddTask(task2)
+	if _, err := assigner.AssignTask(context.Background(), task2); err != ErrNoEligibleEmployee {
+		t.Errorf("Expected ErrNoEligibleEmployee, got %v", err)
+	}
+}
+
+// TestWorkerPoolZeroWorkers tests that a zero-worker pool fails loudly instead of queueing forever
+func TestWorkerPoolZeroWorkers(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+	pool := NewAssignmentWorkerPool(assigner, 0, 5*time.Second)
+
+	if err := pool.Start(context.Background()); !errors.Is(err, ErrInvalidWorkerCount) {
+		t.Fatalf("Start() expected INVALID_WORKER_COUNT, got %v", err)
+	}
+	defer pool.Shutdown()
+
+	task := &Task{ID: "task1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"}
+	if err := pool.SubmitTask(task); !errors.Is(err, ErrNoWorkers) {
+		t.Fatalf("SubmitTask() expected NO_WORKERS, got %v", err)
+	}
+	if pool.taskQueue.Len() != 0 {
+		t.Errorf("Expected nothing queued, got %d", pool.taskQueue.Len())
+	}
+
+	// Resizing recovers the pool
+	if err := pool.Resize(1); err != nil {
+		t.Fatalf("Resize(1) unexpected error: %v", err)
+	}
+	if err := pool.SubmitTask(task); err != nil {
+		t.Errorf("SubmitTask() after resize unexpected error: %v", err)
+	}
+}
+
+// TestTaskAssignmentMinDistance tests that colocated candidates are excluded by MinDistanceKm
+func TestTaskAssignmentMinDistance(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+
+	loc := Location{Lat: 60.1700, Lon: 24.9400}
+	store.AddEmployee(&Employee{
+		ID:          "emp1",
+		Name:        "Alice",
+		Location:    loc,
+		Skills:      []string{"delivery"},
+		IsAvailable: true,
+	})
+
+	// Default: a distance-0 match is fine
+	task1 := &Task{ID: "task1", Location: loc, RequiredSkill: "delivery"}
+	store.AddTask(task1)
+	if _, err := assigner.AssignTask(context.Background(), task1); err != nil {
+		t.Fatalf("AssignTask() without minimum unexpected error: %v", err)
+	}
+	store.FailTask("task1", "")
+
+	task2 := &Task{ID: "task2", Location: loc, RequiredSkill: "delivery", MinDistanceKm: 0.01}
+	if err := task2.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	store.AddTask(task2)
+	result, err := assigner.AssignTask(context.Background(), task2)
+	if err != ErrNoEligibleEmployee {
+		t.Fatalf("AssignTask() expected ErrNoEligibleEmployee, got %v", err)
+	}
+	if result.Diagnostics.Reason() != ReasonNoneInRange {
+		t.Errorf("Expected reason %s, got %s", ReasonNoneInRange, result.Diagnostics.Reason())
+	}
+
+	bad := &Task{ID: "task3", Location: loc, RequiredSkill: "delivery", MinDistanceKm: 5, MaxDistanceKm: 1}
+	if err := bad.Validate(); err == nil {
+		t.Error("Expected min_distance_km above max_distance_km to be rejected")
+	}
+}
+
+// cancelAfterChecksCtx reports Done as closed once Done has been polled n times
+// This cancels performAssignment at a deterministic point in the scoring loop
+type cancelAfterChecksCtx struct {
+	context.Context
+	checks int
+	n      int
+	closed chan struct{}
+}
+
+func newCancelAfterChecksCtx(n int) *cancelAfterChecksCtx {
+	closed := make(chan struct{})
+	close(closed)
+	return &cancelAfterChecksCtx{Context: context.Background(), n: n, closed: closed}
+}
+
+func (c *cancelAfterChecksCtx) Done() <-chan struct{} {
+	c.checks++
+	if c.checks > c.n {
+		return c.closed
+	}
+	return nil
+}
+
+func (c *cancelAfterChecksCtx) Err() error {
+	if c.checks > c.n {
+		return context.DeadlineExceeded
+	}
+	return nil
+}
+
+// TestTaskAssignmentPartialResult tests best-effort commits on mid-scoring cancellation
+func TestTaskAssignmentPartialResult(t *testing.T) {
+	setup := func(fraction float64) (*Store, *TaskAssigner, *Task) {
+		store := NewStore()
+		for i := 0; i < 100; i++ {
+			store.AddEmployee(&Employee{
+				ID:          fmt.Sprintf("emp%03d", i),
+				Name:        "Worker",
+				Location:    Location{Lat: 60.1700 + float64(i)*0.001, Lon: 24.9400},
+				Skills:      []string{"delivery"},
+				IsAvailable: true,
+			})
+		}
+		task := &Task{ID: "task1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"}
+		store.AddTask(task)
+		assigner := NewTaskAssigner(store)
+		assigner.SetPartialResultFraction(fraction)
+		return store, assigner, task
+	}
+
+	// Cancel on the 6th check, i.e. after 50 of 100 candidates were scored
+	t.Run("enabled commits best so far", func(t *testing.T) {
+		store, assigner, task := setup(0.5)
+		result, err := assigner.performAssignment(newCancelAfterChecksCtx(5), task)
+		if err != nil {
+			t.Fatalf("performAssignment() unexpected error: %v", err)
+		}
+		if !result.Success || !result.Partial {
+			t.Fatalf("Expected a successful partial result, got %+v", result)
+		}
+		store.mu.RLock()
+		defer store.mu.RUnlock()
+		if got := store.tasks["task1"]; got.Status != TaskStatusAssigned || got.AssignedEmployeeID != result.EmployeeID {
+			t.Errorf("Expected task assigned to %s, got status %s to %s", result.EmployeeID, got.Status, got.AssignedEmployeeID)
+		}
+	})
+
+	t.Run("below minimum fraction fails", func(t *testing.T) {
+		store, assigner, task := setup(0.9)
+		if _, err := assigner.performAssignment(newCancelAfterChecksCtx(5), task); !errors.Is(err, ErrAssignmentTimeout) {
+			t.Fatalf("Expected ErrAssignmentTimeout, got %v", err)
+		}
+		store.mu.RLock()
+		defer store.mu.RUnlock()
+		if got := store.tasks["task1"].Status; got != TaskStatusFailed {
+			t.Errorf("Expected task failed, got %s", got)
+		}
+	})
+
+	t.Run("disabled fails", func(t *testing.T) {
+		_, assigner, task := setup(0)
+		if _, err := assigner.performAssignment(newCancelAfterChecksCtx(5), task); !errors.Is(err, ErrAssignmentTimeout) {
+			t.Fatalf("Expected ErrAssignmentTimeout, got %v", err)
+		}
+	})
+}
+
+// TestAssignTaskNotPending tests that a re-queued assigned task never consumes a second employee
+func TestAssignTaskNotPending(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+
+	for _, id := range []string{"emp1", "emp2"} {
+		store.AddEmployee(&Employee{
+			ID:          id,
+			Name:        "Worker",
+			Location:    Location{Lat: 60.1699, Lon: 24.9384},
+			Skills:      []string{"delivery"},
+			IsAvailable: true,
+		})
+	}
+	task := &Task{ID: "task1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"}
+	store.AddTask(task)
+
+	first, err := assigner.AssignTask(context.Background(), task)
+	if err != nil {
+		t.Fatalf("First AssignTask() unexpected error: %v", err)
+	}
+
+	result, err := assigner.AssignTask(context.Background(), task)
+	if !errors.Is(err, ErrTaskNotPending) {
+		t.Fatalf("Second AssignTask() expected NOT_PENDING, got %v", err)
+	}
+	if result == nil || result.Success {
+		t.Fatalf("Expected an unsuccessful NOT_PENDING result, got %+v", result)
+	}
+
+	// Re-queueing through the pool is skipped as well, even with an expired deadline
+	pool := NewAssignmentWorkerPool(assigner, 1, time.Nanosecond)
+	pool.Start(context.Background())
+	if err := pool.SubmitTask(task); err != nil {
+		t.Fatalf("SubmitTask() unexpected error: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for pool.IsQueued(task.ID) && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	pool.Shutdown()
+
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	busy := 0
+	for _, emp := range store.employees {
+		busy += emp.ActiveTasks
+	}
+	if busy != 1 {
+		t.Errorf("Expected exactly 1 active task across employees, got %d", busy)
+	}
+	if got := store.tasks["task1"]; got.Status != TaskStatusAssigned || got.AssignedEmployeeID != first.EmployeeID {
+		t.Errorf("Expected task to stay assigned to %s, got %s/%s", first.EmployeeID, got.Status, got.AssignedEmployeeID)
+	}
+}
+
+// TestTaskAssignmentIgnoreDistance tests the distance-free fast path
+func TestTaskAssignmentIgnoreDistance(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+
+	taskLoc := Location{Lat: 60.1700, Lon: 24.9400}
+	store.AddEmployee(&Employee{
+		ID:          "near",
+		Name:        "Busy",
+		Location:    taskLoc,
+		Skills:      []string{"support"},
+		IsAvailable: true,
+		ActiveTasks: 2,
+		Capacity:    5,
+	})
+	store.AddEmployee(&Employee{
+		ID:          "far",
+		Name:        "Idle",
+		Location:    Location{Lat: 35.6762, Lon: 139.6503}, // Tokyo
+		Skills:      []string{"support"},
+		IsAvailable: true,
+	})
+
+	task := &Task{ID: "task1", Location: taskLoc, RequiredSkill: "support", IgnoreDistance: true}
+	if err := task.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	store.AddTask(task)
+
+	result, err := assigner.AssignTask(context.Background(), task)
+	if err != nil {
+		t.Fatalf("AssignTask() unexpected error: %v", err)
+	}
+	// The nearest strategy would pick "near"; the fast path takes the least loaded
+	if result.EmployeeID != "far" {
+		t.Errorf("Expected least-loaded employee far, got %s", result.EmployeeID)
+	}
+	if result.Distance != 0 {
+		t.Errorf("Expected no distance computed, got %.2f km", result.Distance)
+	}
+
+	// The global switch applies to tasks without a distance bound
+	assigner.SetIgnoreDistance(true)
+	task2 := &Task{ID: "task2", Location: taskLoc, RequiredSkill: "support"}
+	store.AddTask(task2)
+	if result, err = assigner.AssignTask(context.Background(), task2); err != nil {
+		t.Fatalf("AssignTask() in global mode unexpected error: %v", err)
+	}
+	if result.Distance != 0 {
+		t.Errorf("Expected no distance computed in global mode, got %.2f km", result.Distance)
+	}
+
+	bad := &Task{ID: "task3", Location: taskLoc, RequiredSkill: "support", IgnoreDistance: true, MaxDistanceKm: 10}
+	if err := bad.Validate(); err == nil {
+		t.Error("Expected ignore_distance with max_distance_km to be rejected")
+	}
+}
+
+// TestTaskAssignmentStrictLocation tests that a Null Island employee wins a
+// nearby task by default but is left out of distance-based assignment in strict mode
+func TestTaskAssignmentStrictLocation(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+
+	// Gulf of Guinea, ~50 km from {0,0}
+	taskLoc := Location{Lat: 0.3, Lon: 0.3}
+	store.AddEmployee(&Employee{
+		ID:          "nofix",
+		Name:        "No Fix",
+		Location:    Location{Lat: 0, Lon: 0},
+		Skills:      []string{"delivery"},
+		IsAvailable: true,
+		Capacity:    5,
+	})
+	store.AddEmployee(&Employee{
+		ID:          "accra",
+		Name:        "Accra",
+		Location:    Location{Lat: 5.6037, Lon: -0.1870},
+		Skills:      []string{"delivery"},
+		IsAvailable: true,
+		Capacity:    5,
+	})
+
+	assign := func(id string, ignoreDistance bool) string {
+		task := &Task{ID: id, Location: taskLoc, RequiredSkill: "delivery", IgnoreDistance: ignoreDistance}
+		store.AddTask(task)
+		result, err := assigner.AssignTask(context.Background(), task)
+		if err != nil {
+			t.Fatalf("AssignTask(%s) unexpected error: %v", id, err)
+		}
+		return result.EmployeeID
+	}
+
+	if got := assign("task1", false); got != "nofix" {
+		t.Errorf("Default mode: expected nofix, got %s", got)
+	}
+
+	assigner.SetStrictLocation(true)
+	if got := assign("task2", false); got != "accra" {
+		t.Errorf("Strict mode: expected accra, got %s", got)
+	}
+	// Without distance scoring the missing fix doesn't matter; nofix is less loaded
+	store.FailTask("task1", "")
+	if got := assign("task3", true); got != "nofix" {
+		t.Errorf("Strict mode with ignore_distance: expected nofix, got %s", got)
+	}
+
+	// With only Null Island employees left, nobody is in range
+	store.UpdateEmployeeAvailability("accra", false)
+	task := &Task{ID: "task4", Location: taskLoc, RequiredSkill: "delivery"}
+	store.AddTask(task)
+	result, err := assigner.AssignTask(context.Background(), task)
+	if err != ErrNoEligibleEmployee {
+		t.Fatalf("AssignTask() expected ErrNoEligibleEmployee, got %v", err)
+	}
+	if result.Diagnostics.Reason() != ReasonNoneInRange {
+		t.Errorf("Expected reason %s, got %s", ReasonNoneInRange, result.Diagnostics.Reason())
+	}
+}
+
+// TestTaskAssignmentEmployeeRange tests that an employee is skipped when the
+// task lies beyond their own max_range_km, even if the task allows any distance
+func TestTaskAssignmentEmployeeRange(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+
+	// ~1.1 km and ~5.5 km from the task
+	store.AddEmployee(&Employee{
+		ID:          "homebody",
+		Name:        "Homebody",
+		Location:    Location{Lat: 60.1799, Lon: 24.9384},
+		Skills:      []string{"delivery"},
+		IsAvailable: true,
+		Capacity:    5,
+		MaxRangeKm:  1,
+	})
+	store.AddEmployee(&Employee{
+		ID:          "rover",
+		Name:        "Rover",
+		Location:    Location{Lat: 60.2199, Lon: 24.9384},
+		Skills:      []string{"delivery"},
+		IsAvailable: true,
+		Capacity:    5,
+		MaxRangeKm:  10,
+	})
+
+	task := &Task{ID: "task1", Location: Location{Lat: 60.1699, Lon: 24.9384}, RequiredSkill: "delivery"}
+	store.AddTask(task)
+	result, err := assigner.AssignTask(context.Background(), task)
+	if err != nil {
+		t.Fatalf("AssignTask() unexpected error: %v", err)
+	}
+	if result.EmployeeID != "rover" {
+		t.Errorf("Expected rover (homebody is out of their own range), got %s", result.EmployeeID)
+	}
+
+	if err := (&Employee{Name: "Bad", Location: task.Location, Skills: []string{"delivery"}, MaxRangeKm: -1}).Validate(); err == nil {
+		t.Error("Expected a negative max_range_km to be rejected")
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"sort"
+	"time"
+)
+
+// DefaultNearbyLimit caps GET /employees/nearby when no limit is given
+const DefaultNearbyLimit = 10
+
+// NearbyEmployee is one employee in a nearest-first search
+type NearbyEmployee struct {
+	EmployeeID string  `json:"employee_id"`
+	Name       string  `json:"name"`
+	DistanceKm float64 `json:"distance_km"`
+	Available  bool    `json:"available"`
+	// FreeAt is when a reservation lapses; unset for employees only at capacity
+	FreeAt *time.Time `json:"free_at,omitempty"`
+}
+
+// NearbyEmployees returns up to limit employees with the skill (any skill
+// when empty), nearest to origin first
+// Unavailable or reserved employees are left out unless includeUnavailable,
+// in which case they are listed with Available false
+func (s *Store) NearbyEmployees(origin Location, skill string, limit int, includeUnavailable bool) []NearbyEmployee {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := s.clock.Now()
+	nearby := []NearbyEmployee{}
+	for _, emp := range s.employees {
+		if skill != "" && !s.skillMatcher.Matches(emp.Skills, skill) {
+			continue
+		}
+		reserved := emp.isReserved(now)
+		available := emp.IsAvailable && !reserved
+		if !available && !includeUnavailable {
+			continue
+		}
+		entry := NearbyEmployee{
+			EmployeeID: emp.ID,
+			Name:       emp.Name,
+			DistanceKm: CalculateDistance(origin, emp.Location),
+			Available:  available,
+		}
+		if reserved {
+			freeAt := *emp.ReservedUntil
+			entry.FreeAt = &freeAt
+		}
+		nearby = append(nearby, entry)
+	}
+
+	sort.Slice(nearby, func(i, j int) bool {
+		if nearby[i].DistanceKm != nearby[j].DistanceKm {
+			return nearby[i].DistanceKm < nearby[j].DistanceKm
+		}
+		return nearby[i].EmployeeID < nearby[j].EmployeeID
+	})
+	if limit > 0 && len(nearby) > limit {
+		nearby = nearby[:limit]
+	}
+	return nearby
+}
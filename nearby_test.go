@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestNearbyEmployeesIncludeUnavailable tests that busy and reserved employees
+// are omitted by default and listed flagged with include_unavailable=true
+func TestNearbyEmployeesIncludeUnavailable(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	api.store.AddEmployee(&Employee{ID: "far", Name: "Far", Location: Location{Lat: 60.2000, Lon: 24.9700}, Skills: []string{"delivery"}, IsAvailable: true})
+	api.store.AddEmployee(&Employee{ID: "busy", Name: "Busy", Location: Location{Lat: 60.1700, Lon: 24.9390}, Skills: []string{"delivery"}, IsAvailable: false})
+	api.store.AddEmployee(&Employee{ID: "reserved", Name: "Reserved", Location: Location{Lat: 60.1710, Lon: 24.9400}, Skills: []string{"delivery"}, IsAvailable: true})
+	api.store.AddEmployee(&Employee{ID: "welder", Name: "Welder", Location: Location{Lat: 60.1699, Lon: 24.9384}, Skills: []string{"welding"}, IsAvailable: true})
+	until, err := api.store.ReserveEmployee("reserved", time.Hour)
+	if err != nil {
+		t.Fatalf("ReserveEmployee() unexpected error: %v", err)
+	}
+
+	get := func(query string) []NearbyEmployee {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/employees/nearby?lat=60.1699&lon=24.9384&skill=delivery"+query, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var response struct {
+			Data []NearbyEmployee `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return response.Data
+	}
+
+	if nearby := get(""); len(nearby) != 1 || nearby[0].EmployeeID != "far" || !nearby[0].Available {
+		t.Errorf("Expected only the available employee by default, got %+v", nearby)
+	}
+
+	nearby := get("&include_unavailable=true")
+	if len(nearby) != 3 {
+		t.Fatalf("Expected 3 employees with include_unavailable, got %+v", nearby)
+	}
+	for i, want := range []string{"busy", "reserved", "far"} {
+		if nearby[i].EmployeeID != want {
+			t.Errorf("Position %d = %s, want %s (nearest first)", i, nearby[i].EmployeeID, want)
+		}
+	}
+	if nearby[0].Available || nearby[0].FreeAt != nil {
+		t.Errorf("Expected busy flagged unavailable without a free time, got %+v", nearby[0])
+	}
+	if nearby[1].Available || nearby[1].FreeAt == nil || !nearby[1].FreeAt.Equal(until) {
+		t.Errorf("Expected reserved flagged unavailable until %v, got %+v", until, nearby[1])
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/employees/nearby?lat=north&lon=24.9", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for bad coordinates, got %d", w.Code)
+	}
+}
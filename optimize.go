@@ -0,0 +1,167 @@
+package main
+
+import "sort"
+
+// Orders accepted for batch assignment results
+const (
+	BatchSortTaskID   = "task_id"
+	BatchSortDistance = "distance"
+)
+
+// BatchAssignmentResult is the outcome for one task in a batch assignment
+type BatchAssignmentResult struct {
+	TaskID     string  `json:"task_id"`
+	Assigned   bool    `json:"assigned"`
+	EmployeeID string  `json:"employee_id,omitempty"`
+	DistanceKm float64 `json:"distance_km"`
+	// Code says why the task was left unassigned (e.g. NO_ELIGIBLE_EMPLOYEE)
+	Code string `json:"code,omitempty"`
+}
+
+// OptimizeReport summarizes a batch assignment
+type OptimizeReport struct {
+	SortBy     string                  `json:"sort_by"`
+	Assigned   int                     `json:"assigned"`
+	Unassigned int                     `json:"unassigned"`
+	Results    []BatchAssignmentResult `json:"results"`
+}
+
+// validBatchSort reports whether sortBy names a supported result order
+func validBatchSort(sortBy string) bool {
+	return sortBy == BatchSortTaskID || sortBy == BatchSortDistance
+}
+
+// sortBatchResults orders results by task ID, or by descending distance so
+// the worst matches come first; unassigned tasks go last either way
+func sortBatchResults(results []BatchAssignmentResult, sortBy string) {
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if sortBy == BatchSortDistance {
+			if a.Assigned != b.Assigned {
+				return a.Assigned
+			}
+			if a.DistanceKm != b.DistanceKm {
+				return a.DistanceKm > b.DistanceKm
+			}
+		}
+		return a.TaskID < b.TaskID
+	})
+}
+
+// eligibleLocked reports whether emp could take task right now under the
+// assigner's rules, and how far away they are; caller must hold the store lock
+func (ta *TaskAssigner) eligibleLocked(task *Task, emp *Employee, room int) (float64, bool) {
+	if room <= 0 {
+		return 0, false
+	}
+	if !ta.matchesLocked(task, emp) {
+		return 0, false
+	}
+	return ta.reaches(task, emp)
+}
+
+// OptimizeAssignments assigns the given pending tasks together (every pending
+// task when taskIDs is empty), committing the closest task-employee pairs
+// first across the whole batch instead of task by task; distances come from
+// one DistanceMatrix built up front
+// Employees take at most their spare capacity; the pre-assign hook can veto
+// pairs. Tasks nobody can take stay pending. All of it runs under one store lock
+func (ta *TaskAssigner) OptimizeAssignments(taskIDs []string) []BatchAssignmentResult {
+	s := ta.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(taskIDs) == 0 {
+		for id, task := range s.tasks {
+			if task.Status == TaskStatusPending {
+				taskIDs = append(taskIDs, id)
+			}
+		}
+	}
+
+	now := s.clock.Now()
+	room := make(map[string]int)
+	for id, emp := range s.employees {
+		if emp.IsAvailable && !emp.isReserved(now) {
+			room[id] = emp.maxActiveTasks() - emp.ActiveTasks
+		}
+	}
+
+	results := make(map[string]*BatchAssignmentResult, len(taskIDs))
+	var batch []*Task
+	for _, id := range taskIDs {
+		if _, seen := results[id]; seen {
+			continue
+		}
+		result := &BatchAssignmentResult{TaskID: id}
+		results[id] = result
+		task, exists := s.tasks[id]
+		switch {
+		case !exists:
+			result.Code = ErrTaskNotFound.Code
+			continue
+		case task.Status != TaskStatusPending:
+			result.Code = ErrTaskNotPending.Code
+			continue
+		}
+		result.Code = ErrNoEligibleEmployee.Code
+		batch = append(batch, task)
+	}
+	var candidates []*Employee
+	for id, emp := range s.employees {
+		if room[id] > 0 {
+			candidates = append(candidates, emp)
+		}
+	}
+	matrix := NewDistanceMatrix(batch, candidates, func(task *Task, emp *Employee) (float64, bool) {
+		return ta.eligibleLocked(task, emp, room[emp.ID])
+	})
+
+	for _, p := range matrix.Pairs() {
+		result := results[p.Task.ID]
+		if result.Assigned || room[p.Employee.ID] == 0 {
+			continue
+		}
+		if veto := ta.vetoLocked(p.Task, p.Employee); veto != nil {
+			result.Code = ErrAssignmentVetoed.Code
+			continue
+		}
+		room[p.Employee.ID]--
+
+		p.Employee.ActiveTasks++
+		p.Employee.IsAvailable = p.Employee.ActiveTasks < p.Employee.maxActiveTasks()
+		ta.metrics.Record(p.Employee.ID)
+		s.transitionTaskLocked(p.Task.ID, TaskStatusAssigned, p.Employee.ID, "batch optimize")
+		assignedAt := ta.clock.Now().UTC()
+		p.Task.AssignedAt = &assignedAt
+		p.Task.AssignedDistanceKm = p.DistanceKm
+		p.Task.Rationale = &AssignmentRationale{
+			Strategy:             "optimize",
+			CandidatesConsidered: matrix.EligibleCount(p.Task.ID),
+			ChosenEmployeeID:     p.Employee.ID,
+			ChosenDistanceKm:     p.DistanceKm,
+		}
+
+		*result = BatchAssignmentResult{TaskID: p.Task.ID, Assigned: true, EmployeeID: p.Employee.ID, DistanceKm: p.DistanceKm}
+	}
+
+	list := make([]BatchAssignmentResult, 0, len(results))
+	for _, result := range results {
+		list = append(list, *result)
+	}
+	return list
+}
+
+// newOptimizeReport sorts results and counts the outcomes
+func newOptimizeReport(results []BatchAssignmentResult, sortBy string) OptimizeReport {
+	sortBatchResults(results, sortBy)
+	report := OptimizeReport{SortBy: sortBy, Results: results}
+	for _, result := range results {
+		if result.Assigned {
+			report.Assigned++
+		} else {
+			report.Unassigned++
+		}
+	}
+	return report
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestOptimizeAssignmentsSortBy tests that batch results come back farthest
+// first with sort_by=distance, and by task ID otherwise
+func TestOptimizeAssignmentsSortBy(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	// Each task sits a different distance north of its only skilled employee
+	for _, tc := range []struct {
+		skill  string
+		offset float64
+	}{{"a", 0.01}, {"b", 0.05}, {"c", 0.02}} {
+		api.store.AddEmployee(&Employee{ID: "emp-" + tc.skill, Name: "Courier", Location: Location{Lat: 60.1, Lon: 24.9}, Skills: []string{tc.skill}, IsAvailable: true})
+		api.store.AddTask(&Task{ID: "task-" + tc.skill, Location: Location{Lat: 60.1 + tc.offset, Lon: 24.9}, RequiredSkill: tc.skill})
+	}
+	api.store.AddTask(&Task{ID: "task-z", Location: Location{Lat: 60.1, Lon: 24.9}, RequiredSkill: "welding"})
+
+	post := func(body string) (int, OptimizeReport) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/assign/optimize", bytes.NewBufferString(body)))
+		var response struct {
+			Data OptimizeReport `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return w.Code, response.Data
+	}
+
+	if code, _ := post(`{"sort_by": "rating"}`); code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an unknown sort_by, got %d", code)
+	}
+
+	code, report := post(`{"sort_by": "distance"}`)
+	if code != http.StatusOK || report.Assigned != 3 || report.Unassigned != 1 {
+		t.Fatalf("Expected 3 assigned and 1 unassigned, got %d %+v", code, report)
+	}
+	for i, want := range []string{"task-b", "task-c", "task-a", "task-z"} {
+		if report.Results[i].TaskID != want {
+			t.Errorf("Position %d = %s, want %s (descending distance, unassigned last)", i, report.Results[i].TaskID, want)
+		}
+	}
+	for i := 1; i < 3; i++ {
+		if report.Results[i].DistanceKm > report.Results[i-1].DistanceKm {
+			t.Errorf("Results not in descending distance: %+v", report.Results)
+		}
+	}
+	if last := report.Results[3]; last.Assigned || last.Code != ErrNoEligibleEmployee.Code {
+		t.Errorf("Expected task-z unassigned with NO_ELIGIBLE_EMPLOYEE, got %+v", last)
+	}
+	if task, _ := api.store.GetTask("task-b"); task.Status != TaskStatusAssigned || task.AssignedEmployeeID != "emp-b" {
+		t.Errorf("Expected task-b committed to emp-b, got %+v", task)
+	}
+
+	// Already assigned tasks are reported, not re-assigned; default order is by ID
+	_, report = post(`{"task_ids": ["task-c", "task-a"]}`)
+	if report.SortBy != BatchSortTaskID || len(report.Results) != 2 || report.Results[0].TaskID != "task-a" || report.Results[1].Code != ErrTaskNotPending.Code {
+		t.Errorf("Unexpected default-sorted report %+v", report)
+	}
+}
+
+// TestOptimizeAssignmentsIgnoreDistance tests that the global ignore-distance
+// mode lets batch optimization use the same employees a single assignment would
+func TestOptimizeAssignmentsIgnoreDistance(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+	assigner.SetIgnoreDistance(true)
+	assigner.SetStrictLocation(true)
+
+	helsinki := Location{Lat: 60.1699, Lon: 24.9384}
+	store.AddEmployee(&Employee{ID: "no-fix", Name: "Alice", Skills: []string{"delivery"}, IsAvailable: true})
+	store.AddEmployee(&Employee{ID: "short-range", Name: "Bob", Location: Location{Lat: 60.4518, Lon: 22.2666}, Skills: []string{"delivery"}, IsAvailable: true, MaxRangeKm: 1})
+	store.AddTask(&Task{ID: "task1", Location: helsinki, RequiredSkill: "delivery"})
+	store.AddTask(&Task{ID: "task2", Location: helsinki, RequiredSkill: "delivery"})
+
+	results := assigner.OptimizeAssignments(nil)
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %+v", results)
+	}
+	used := map[string]bool{}
+	for _, r := range results {
+		if !r.Assigned {
+			t.Errorf("Task %s left unassigned (%s) with distance ignored", r.TaskID, r.Code)
+		}
+		used[r.EmployeeID] = true
+	}
+	if !used["no-fix"] || !used["short-range"] {
+		t.Errorf("Expected both employees used, got %+v", results)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultMaxPageSize caps how many items one list response carries
+const DefaultMaxPageSize = 1000
+
+// parsePage reads ?offset= and ?limit= for a list endpoint; limit defaults to
+// and is clamped at maxSize (0 = no cap). Invalid values answer 400
+func parsePage(c *gin.Context, maxSize int) (offset, limit int, ok bool) {
+	limit = maxSize
+	for _, param := range []struct {
+		name, rule string
+		dst        *int
+		min        int
+	}{{"offset", "a non-negative", &offset, 0}, {"limit", "a positive", &limit, 1}} {
+		v := c.Query(param.name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < param.min {
+			respondError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid pagination",
+				Code:    "INVALID_PAGINATION",
+				Message: fmt.Sprintf("%s must be %s integer", param.name, param.rule),
+			})
+			return 0, 0, false
+		}
+		*param.dst = n
+	}
+	if maxSize > 0 && (limit <= 0 || limit > maxSize) {
+		limit = maxSize
+	}
+	return offset, limit, true
+}
+
+// paginate returns the page of items starting at offset, at most limit long
+// (0 = everything after offset), and the offset of the next page, or nil when
+// the page reaches the end
+func paginate[T any](items []T, offset, limit int) ([]T, *int) {
+	if offset >= len(items) {
+		return items[:0], nil
+	}
+	items = items[offset:]
+	if limit <= 0 || len(items) <= limit {
+		return items, nil
+	}
+	next := offset + limit
+	return items[:limit], &next
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestMaxPageSize tests that list endpoints never return more than
+// MAX_PAGE_SIZE items and that next_offset walks through the rest
+func TestMaxPageSize(t *testing.T) {
+	api := setupTestAPI()
+	api.config.MaxPageSize = 3
+	router := api.setupRouter()
+
+	loc := Location{Lat: 60.1699, Lon: 24.9384}
+	for i := 0; i < 7; i++ {
+		api.store.AddEmployee(&Employee{ID: fmt.Sprintf("emp%d", i), Name: "Courier", Location: loc, Skills: []string{"delivery"}, IsAvailable: true})
+		api.store.AddTask(&Task{ID: fmt.Sprintf("task%d", i), Location: loc, RequiredSkill: "delivery"})
+	}
+
+	type page struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+		NextOffset *int `json:"next_offset"`
+	}
+	get := func(path string) page {
+		t.Helper()
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("GET %s: expected status 200, got %d: %s", path, w.Code, w.Body.String())
+		}
+		var response page
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return response
+	}
+
+	for _, list := range []string{"/employees", "/tasks"} {
+		var seen []string
+		path := list
+		for pages := 0; ; pages++ {
+			if pages > 3 {
+				t.Fatalf("%s: next_offset never ran out", list)
+			}
+			resp := get(path)
+			if len(resp.Data) > 3 {
+				t.Fatalf("%s: got %d items, want at most 3", path, len(resp.Data))
+			}
+			for _, item := range resp.Data {
+				seen = append(seen, item.ID)
+			}
+			if resp.NextOffset == nil {
+				break
+			}
+			if *resp.NextOffset != len(seen) {
+				t.Errorf("%s: next_offset = %d, want %d", path, *resp.NextOffset, len(seen))
+			}
+			path = fmt.Sprintf("%s?offset=%d", list, *resp.NextOffset)
+		}
+		if len(seen) != 7 {
+			t.Errorf("%s: paged through %v, want all 7 items once", list, seen)
+		}
+		for i := 1; i < len(seen); i++ {
+			if seen[i-1] >= seen[i] {
+				t.Errorf("%s: items out of order: %v", list, seen)
+				break
+			}
+		}
+	}
+
+	// limit can shrink a page but not exceed the cap
+	if resp := get("/tasks?limit=2"); len(resp.Data) != 2 || resp.NextOffset == nil || *resp.NextOffset != 2 {
+		t.Errorf("limit=2: got %d items, next_offset %v", len(resp.Data), resp.NextOffset)
+	}
+	if resp := get("/tasks?limit=50"); len(resp.Data) != 3 {
+		t.Errorf("limit=50: got %d items, want the cap of 3", len(resp.Data))
+	}
+	if resp := get("/tasks?offset=20"); len(resp.Data) != 0 || resp.NextOffset != nil {
+		t.Errorf("offset past the end: got %d items, next_offset %v", len(resp.Data), resp.NextOffset)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/employees?envelope=false", nil))
+	if got := w.Header().Get("X-Next-Offset"); got != "3" {
+		t.Errorf("Bare response: X-Next-Offset = %q, want 3", got)
+	}
+
+	for _, bad := range []string{"/tasks?offset=-1", "/employees?limit=0", "/employees?offset=abc"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", bad, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("GET %s: expected status 400, got %d", bad, w.Code)
+		}
+	}
+}
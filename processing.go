@@ -0,0 +1,66 @@
+package main
+
+import (
+	"sort"
+	"time"
+)
+
+// processingEntry records which worker picked up a task and when
+type processingEntry struct {
+	workerID  string
+	startedAt time.Time
+}
+
+// processingCount returns how many tasks workers are processing
+func (pool *AssignmentWorkerPool) processingCount() int {
+	pool.queuedMu.Lock()
+	defer pool.queuedMu.Unlock()
+	return len(pool.processing)
+}
+
+// ProcessingTask is a task a worker is assigning right now
+type ProcessingTask struct {
+	TaskID    string    `json:"task_id"`
+	WorkerID  string    `json:"worker_id"`
+	StartedAt time.Time `json:"started_at"`
+	ElapsedMs int64     `json:"elapsed_ms"`
+}
+
+// markProcessing records that a worker picked up the task
+func (pool *AssignmentWorkerPool) markProcessing(taskID, workerID string) {
+	entry := processingEntry{workerID: workerID, startedAt: pool.assigner.clock.Now()}
+	pool.queuedMu.Lock()
+	pool.processing[taskID] = entry
+	pool.queuedMu.Unlock()
+}
+
+// clearProcessing records that a worker is done with the task
+func (pool *AssignmentWorkerPool) clearProcessing(taskID string) {
+	pool.queuedMu.Lock()
+	delete(pool.processing, taskID)
+	pool.queuedMu.Unlock()
+}
+
+// Processing lists the tasks workers are currently assigning, longest running first
+func (pool *AssignmentWorkerPool) Processing() []ProcessingTask {
+	now := pool.assigner.clock.Now()
+	pool.queuedMu.Lock()
+	tasks := make([]ProcessingTask, 0, len(pool.processing))
+	for id, entry := range pool.processing {
+		tasks = append(tasks, ProcessingTask{
+			TaskID:    id,
+			WorkerID:  entry.workerID,
+			StartedAt: entry.startedAt.UTC(),
+			ElapsedMs: now.Sub(entry.startedAt).Milliseconds(),
+		})
+	}
+	pool.queuedMu.Unlock()
+
+	sort.Slice(tasks, func(i, j int) bool {
+		if !tasks[i].StartedAt.Equal(tasks[j].StartedAt) {
+			return tasks[i].StartedAt.Before(tasks[j].StartedAt)
+		}
+		return tasks[i].TaskID < tasks[j].TaskID
+	})
+	return tasks
+}
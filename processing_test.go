@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestGetProcessingTasks tests that a task a worker is stuck on shows up in
+// /tasks/processing with its worker, and drops out once it is assigned
+func TestGetProcessingTasks(t *testing.T) {
+	api := setupTestAPI()
+	strategy := blockingStrategy{picked: make(chan struct{}), release: make(chan struct{})}
+	api.assigner.SetStrategy(strategy)
+	router := api.setupRouter()
+
+	api.store.AddEmployee(&Employee{ID: "emp1", Name: "Alice", Location: Location{Lat: 60.17, Lon: 24.94}, Skills: []string{"delivery"}, IsAvailable: true})
+	task := &Task{ID: "slow", Location: Location{Lat: 60.17, Lon: 24.94}, RequiredSkill: "delivery"}
+	api.store.AddTask(task)
+	if err := api.workerPool.Start(context.Background()); err != nil {
+		t.Fatalf("Start() unexpected error: %v", err)
+	}
+	defer api.workerPool.Shutdown()
+	if err := api.workerPool.SubmitTask(task); err != nil {
+		t.Fatalf("SubmitTask() unexpected error: %v", err)
+	}
+	<-strategy.picked
+
+	get := func() []ProcessingTask {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/processing", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+		var response struct {
+			Data []ProcessingTask `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return response.Data
+	}
+
+	processing := get()
+	if len(processing) != 1 || processing[0].TaskID != "slow" || processing[0].WorkerID == "" || processing[0].ElapsedMs < 0 {
+		t.Fatalf("Expected the stuck task in the processing list, got %+v", processing)
+	}
+
+	close(strategy.release)
+	deadline := time.Now().Add(time.Second)
+	for len(get()) > 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if processing := get(); len(processing) != 0 {
+		t.Errorf("Expected an empty list once the assignment finished, got %+v", processing)
+	}
+	if status, _ := api.store.taskStatus("slow"); status != TaskStatusAssigned {
+		t.Errorf("Task status = %s, want %s", status, TaskStatusAssigned)
+	}
+}
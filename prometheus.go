@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// PrometheusContentType is the media type of the text exposition format
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// TaskCounters are monotonic task totals since process start
+type TaskCounters struct {
+	Created  uint64 `json:"created"`
+	Assigned uint64 `json:"assigned"`
+	Failed   uint64 `json:"failed"`
+}
+
+// TaskCounters returns the store's task totals
+func (s *Store) TaskCounters() TaskCounters {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.counters
+}
+
+// promSample is one sample line; labels are rendered in the given order
+type promSample struct {
+	labels [][2]string
+	value  float64
+}
+
+// promFamily is a metric family: its HELP and TYPE lines and its samples
+type promFamily struct {
+	name    string
+	help    string
+	kind    string // "counter" or "gauge"
+	samples []promSample
+}
+
+// writePrometheus renders families in the Prometheus text exposition format
+func writePrometheus(w io.Writer, families []promFamily) error {
+	var b strings.Builder
+	for _, f := range families {
+		fmt.Fprintf(&b, "# HELP %s %s\n", f.name, escapePromHelp(f.help))
+		fmt.Fprintf(&b, "# TYPE %s %s\n", f.name, f.kind)
+		for _, sample := range f.samples {
+			b.WriteString(f.name)
+			if len(sample.labels) > 0 {
+				b.WriteByte('{')
+				for i, label := range sample.labels {
+					if i > 0 {
+						b.WriteByte(',')
+					}
+					fmt.Fprintf(&b, "%s=\"%s\"", label[0], escapePromLabel(label[1]))
+				}
+				b.WriteByte('}')
+			}
+			b.WriteByte(' ')
+			b.WriteString(strconv.FormatFloat(sample.value, 'g', -1, 64))
+			b.WriteByte('\n')
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// escapePromHelp escapes backslashes and newlines in HELP text
+func escapePromHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+// escapePromLabel escapes backslashes, quotes and newlines in label values
+func escapePromLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var (
+	promCommentLine = regexp.MustCompile(`^# (HELP|TYPE) ([a-zA-Z_:][a-zA-Z0-9_:]*) (.+)$`)
+	promSampleLine  = regexp.MustCompile(`^([a-zA-Z_:][a-zA-Z0-9_:]*)(\{[a-zA-Z_][a-zA-Z0-9_]*="(?:[^"\\]|\\.)*"(?:,[a-zA-Z_][a-zA-Z0-9_]*="(?:[^"\\]|\\.)*")*\})? (\S+)$`)
+)
+
+// parsePromText checks body against the text exposition format and returns
+// sample values keyed by name plus labels (e.g. `queue_depth{queue="primary"}`)
+func parsePromText(t *testing.T, body string) map[string]float64 {
+	t.Helper()
+
+	samples := make(map[string]float64)
+	typed := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if m := promCommentLine.FindStringSubmatch(text); m != nil {
+			if m[1] == "TYPE" {
+				if m[3] != "counter" && m[3] != "gauge" {
+					t.Fatalf("line %d: unexpected metric type %q", line, m[3])
+				}
+				if _, dup := typed[m[2]]; dup {
+					t.Fatalf("line %d: duplicate TYPE for %s", line, m[2])
+				}
+				typed[m[2]] = m[3]
+			}
+			continue
+		}
+		m := promSampleLine.FindStringSubmatch(text)
+		if m == nil {
+			t.Fatalf("line %d: not a valid sample: %q", line, text)
+		}
+		kind, ok := typed[m[1]]
+		if !ok {
+			t.Fatalf("line %d: sample for %s appears before its TYPE line", line, m[1])
+		}
+		if kind == "counter" && !strings.HasSuffix(m[1], "_total") {
+			t.Errorf("line %d: counter %s should end in _total", line, m[1])
+		}
+		value, err := strconv.ParseFloat(m[3], 64)
+		if err != nil {
+			t.Fatalf("line %d: invalid value %q", line, m[3])
+		}
+		samples[m[1]+m[2]] = value
+	}
+	return samples
+}
+
+// TestPrometheusMetrics tests that /metrics is valid exposition text and tracks counters
+func TestPrometheusMetrics(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	scrape := func() map[string]float64 {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+		if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+			t.Errorf("Unexpected Content-Type %q", ct)
+		}
+		return parsePromText(t, w.Body.String())
+	}
+
+	before := scrape()
+	if before["task_assignment_tasks_created_total"] != 0 {
+		t.Fatalf("Expected no created tasks yet, got %v", before["task_assignment_tasks_created_total"])
+	}
+
+	api.store.AddEmployee(&Employee{ID: "emp1", Name: "Alice", Location: Location{Lat: 60.1699, Lon: 24.9384}, Skills: []string{"delivery"}, IsAvailable: true})
+	assigned := &Task{ID: "task1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"}
+	api.store.AddTask(assigned)
+	api.assigner.AssignTask(context.Background(), assigned)
+	api.store.AddTask(&Task{ID: "task2", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "welding"})
+	api.store.FailTask("task2", "")
+	api.store.AddTask(&Task{ID: "task3", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"})
+
+	after := scrape()
+	want := map[string]float64{
+		"task_assignment_tasks_created_total":          3,
+		"task_assignment_tasks_assigned_total":         1,
+		"task_assignment_tasks_failed_total":           1,
+		`task_assignment_tasks{status="pending"}`:      1,
+		`task_assignment_tasks{status="assigned"}`:     1,
+		`task_assignment_queue_depth{queue="primary"}`: 0,
+		"task_assignment_employees":                    1,
+		"task_assignment_available_employees":          0,
+	}
+	for key, value := range want {
+		got, ok := after[key]
+		if !ok {
+			t.Errorf("Missing sample %s", key)
+			continue
+		}
+		if got != value {
+			t.Errorf("%s = %v, want %v", key, got, value)
+		}
+	}
+}
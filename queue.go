@@ -0,0 +1,132 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultQueueAgingInterval is how long a task waits to gain one priority level
+const DefaultQueueAgingInterval = 10 * time.Second
+
+// DefaultQueueSize is the capacity of the primary task queue
+const DefaultQueueSize = 100
+
+// queuedTask is a task waiting in a TaskQueue
+type queuedTask struct {
+	task       *Task
+	enqueuedAt time.Time
+	seq        uint64
+}
+
+// TaskQueue is a bounded priority queue with aging
+// A task's effective priority grows by one level per agingInterval waited, so
+// low-priority work is eventually served under constant high-priority inflow.
+// Equal effective priorities are served in submission order.
+type TaskQueue struct {
+	mu            sync.Mutex
+	items         []queuedTask
+	capacity      int
+	agingInterval time.Duration
+	clock         Clock
+	seq           uint64
+	closed        bool
+	// fifo ignores priority and aging: tasks are popped in submission order
+	fifo bool
+
+	// ready holds one token per queued item; closed by Close
+	ready chan struct{}
+}
+
+// NewTaskQueue creates a queue holding at most capacity tasks
+func NewTaskQueue(capacity int, agingInterval time.Duration, clock Clock) *TaskQueue {
+	return &TaskQueue{
+		capacity:      capacity,
+		agingInterval: agingInterval,
+		clock:         clock,
+		ready:         make(chan struct{}, capacity),
+	}
+}
+
+// Push enqueues a task, returning false if the queue is full or closed
+func (q *TaskQueue) Push(task *Task) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed || len(q.items) >= q.capacity {
+		return false
+	}
+	q.seq++
+	q.items = append(q.items, queuedTask{task: task, enqueuedAt: q.clock.Now(), seq: q.seq})
+	q.ready <- struct{}{}
+	return true
+}
+
+// SetFIFO switches the queue to strict submission order (no priorities or aging)
+// Must be called before the queue is used concurrently
+func (q *TaskQueue) SetFIFO(fifo bool) {
+	q.fifo = fifo
+}
+
+// Ready returns a channel yielding one value per queued task
+// It is closed by Close once remaining tokens are drained; pair each receive with Pop
+func (q *TaskQueue) Ready() <-chan struct{} {
+	return q.ready
+}
+
+// Pop removes and returns the task with the highest effective priority
+// Effective priority is recomputed from wait time on every pop; in FIFO mode
+// the oldest submission is returned instead
+func (q *TaskQueue) Pop() *Task {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.items) == 0 {
+		return nil
+	}
+	// Items are appended in seq order and removal preserves it
+	if q.fifo {
+		task := q.items[0].task
+		q.items = q.items[1:]
+		return task
+	}
+
+	now := q.clock.Now()
+	best, bestPriority := 0, q.effectivePriority(q.items[0], now)
+	for i := 1; i < len(q.items); i++ {
+		p := q.effectivePriority(q.items[i], now)
+		if p > bestPriority || (p == bestPriority && q.items[i].seq < q.items[best].seq) {
+			best, bestPriority = i, p
+		}
+	}
+
+	task := q.items[best].task
+	q.items = append(q.items[:best], q.items[best+1:]...)
+	return task
+}
+
+// effectivePriority is the task's priority plus one level per aging interval waited
+func (q *TaskQueue) effectivePriority(item queuedTask, now time.Time) int {
+	priority := item.task.Priority
+	if q.agingInterval > 0 {
+		priority += int(now.Sub(item.enqueuedAt) / q.agingInterval)
+	}
+	return priority
+}
+
+// Len returns the number of queued tasks
+func (q *TaskQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
+
+// Close stops accepting tasks; queued tasks can still be popped
+func (q *TaskQueue) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !q.closed {
+		q.closed = true
+		close(q.ready)
+	}
+}
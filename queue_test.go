@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestTaskQueuePriorityOrder tests that higher priorities are served first, FIFO within a level
+func TestTaskQueuePriorityOrder(t *testing.T) {
+	q := NewTaskQueue(10, time.Minute, newFakeClock())
+
+	q.Push(&Task{ID: "normal1", Priority: PriorityNormal})
+	q.Push(&Task{ID: "urgent", Priority: PriorityUrgent})
+	q.Push(&Task{ID: "normal2", Priority: PriorityNormal})
+	q.Push(&Task{ID: "low", Priority: PriorityLow})
+
+	want := []string{"urgent", "normal1", "normal2", "low"}
+	for _, id := range want {
+		<-q.Ready()
+		if got := q.Pop(); got.ID != id {
+			t.Fatalf("Pop() = %s, want %s", got.ID, id)
+		}
+	}
+	if q.Len() != 0 {
+		t.Errorf("Expected empty queue, got %d", q.Len())
+	}
+}
+
+// TestTaskQueueAgingPreventsStarvation tests that an old low-priority task beats a flood of urgent ones
+func TestTaskQueueAgingPreventsStarvation(t *testing.T) {
+	clock := newFakeClock()
+	q := NewTaskQueue(10, time.Second, clock)
+
+	q.Push(&Task{ID: "old-low", Priority: PriorityLow})
+
+	// Keep the queue topped up with fresh urgent tasks, popping one per tick
+	for i := 0; i < 20; i++ {
+		q.Push(&Task{ID: fmt.Sprintf("urgent-%d", i), Priority: PriorityUrgent})
+		clock.Advance(time.Second)
+
+		<-q.Ready()
+		if q.Pop().ID == "old-low" {
+			// Low (1) must outwait a fresh urgent (4) by 3 aging intervals to be served
+			if i < 3 {
+				t.Fatalf("Low-priority task served too early at tick %d", i)
+			}
+			return
+		}
+	}
+	t.Fatal("Low-priority task starved under constant urgent inflow")
+}
+
+// TestTaskQueueCapacityAndClose tests bounded pushes and draining after close
+func TestTaskQueueCapacityAndClose(t *testing.T) {
+	q := NewTaskQueue(2, 0, newFakeClock())
+
+	if !q.Push(&Task{ID: "a"}) || !q.Push(&Task{ID: "b"}) {
+		t.Fatal("Expected pushes within capacity to succeed")
+	}
+	if q.Push(&Task{ID: "c"}) {
+		t.Fatal("Expected push beyond capacity to fail")
+	}
+
+	q.Close()
+	if q.Push(&Task{ID: "d"}) {
+		t.Fatal("Expected push after close to fail")
+	}
+
+	drained := 0
+	for range q.Ready() {
+		if q.Pop() != nil {
+			drained++
+		}
+	}
+	if drained != 2 {
+		t.Errorf("Expected 2 tasks drained after close, got %d", drained)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// validateRadiusTiers checks that tiers are positive and strictly increasing
+func validateRadiusTiers(tiers []float64) error {
+	for i, tier := range tiers {
+		if tier <= 0 {
+			return fmt.Errorf("radius tiers must be positive, got %.2f", tier)
+		}
+		if i > 0 && tier <= tiers[i-1] {
+			return fmt.Errorf("radius tiers must be strictly increasing, got %.2f after %.2f", tier, tiers[i-1])
+		}
+	}
+	return nil
+}
+
+// ParseRadiusTiers parses a comma-separated list of radii in km (e.g. "2,10")
+func ParseRadiusTiers(s string) ([]float64, error) {
+	var tiers []float64
+	for _, part := range strings.Split(s, ",") {
+		tier, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid radius tier %q", part)
+		}
+		tiers = append(tiers, tier)
+	}
+	if err := validateRadiusTiers(tiers); err != nil {
+		return nil, err
+	}
+	return tiers, nil
+}
+
+// SetRadiusTiers sets the default radius tiers for tasks without their own
+// Must be called before the assigner is used concurrently
+func (ta *TaskAssigner) SetRadiusTiers(tiers []float64) {
+	ta.radiusTiers = tiers
+}
+
+// radiusTiersFor returns the task's radius tiers, or the assigner's default
+func (ta *TaskAssigner) radiusTiersFor(task *Task) []float64 {
+	if len(task.RadiusTiersKm) > 0 {
+		return task.RadiusTiersKm
+	}
+	return ta.radiusTiers
+}
+
+// narrowToTier keeps the scored candidates within the first tier that has
+// any, returning that tier's radius
+// When every tier is empty all candidates are kept and the radius is 0
+func narrowToTier(candidates []Candidate, tiers []float64) ([]Candidate, float64) {
+	for _, tier := range tiers {
+		var inTier []Candidate
+		for _, c := range candidates {
+			if c.Distance <= tier {
+				inTier = append(inTier, c)
+			}
+		}
+		if len(inTier) > 0 {
+			return inTier, tier
+		}
+	}
+	return candidates, 0
+}
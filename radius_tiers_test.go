@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+// TestRadiusTiers tests that assignment widens to the next tier only when the
+// current one is empty, and records the tier used
+func TestRadiusTiers(t *testing.T) {
+	taskLoc := Location{Lat: 60.1700, Lon: 24.9400}
+
+	tests := []struct {
+		name     string
+		tiers    []float64
+		wantEmp  string
+		wantTier float64
+	}{
+		// reverse_distance would pick the farthest employee without tiering
+		{"Untiered", nil, "far", 0},
+		{"First tier empty, second matches", []float64{2, 10}, "mid", 10},
+		{"Every tier empty", []float64{1, 2}, "far", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewStore()
+			assigner := NewTaskAssigner(store)
+			assigner.SetStrategy(ReverseDistanceStrategy{})
+			// ~5 km and ~30 km north of the task
+			store.AddEmployee(&Employee{ID: "mid", Name: "Mid", Location: Location{Lat: 60.2150, Lon: 24.9400}, Skills: []string{"delivery"}, IsAvailable: true})
+			store.AddEmployee(&Employee{ID: "far", Name: "Far", Location: Location{Lat: 60.4400, Lon: 24.9400}, Skills: []string{"delivery"}, IsAvailable: true})
+
+			task := &Task{ID: "task1", Location: taskLoc, RequiredSkill: "delivery", RadiusTiersKm: tt.tiers}
+			if err := task.Validate(); err != nil {
+				t.Fatalf("Validate() unexpected error: %v", err)
+			}
+			store.AddTask(task)
+			result, err := assigner.AssignTask(context.Background(), task)
+			if err != nil {
+				t.Fatalf("AssignTask() unexpected error: %v", err)
+			}
+			if result.EmployeeID != tt.wantEmp {
+				t.Errorf("Assigned %s, want %s", result.EmployeeID, tt.wantEmp)
+			}
+
+			rationale, err := store.TaskRationale("task1")
+			if err != nil {
+				t.Fatalf("TaskRationale() unexpected error: %v", err)
+			}
+			if rationale.RadiusTierKm != tt.wantTier {
+				t.Errorf("RadiusTierKm = %v, want %v", rationale.RadiusTierKm, tt.wantTier)
+			}
+		})
+	}
+}
+
+// TestParseRadiusTiers tests parsing and validation of tier lists
+func TestParseRadiusTiers(t *testing.T) {
+	tiers, err := ParseRadiusTiers("2, 10,50")
+	if err != nil {
+		t.Fatalf("ParseRadiusTiers() unexpected error: %v", err)
+	}
+	if len(tiers) != 3 || tiers[0] != 2 || tiers[1] != 10 || tiers[2] != 50 {
+		t.Errorf("ParseRadiusTiers() = %v, want [2 10 50]", tiers)
+	}
+
+	for _, input := range []string{"", "2,abc", "0,5", "10,2", "5,5"} {
+		if _, err := ParseRadiusTiers(input); err == nil {
+			t.Errorf("ParseRadiusTiers(%q) expected error", input)
+		}
+	}
+}
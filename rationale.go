@@ -0,0 +1,56 @@
+package main
+
+// AssignmentRationale explains why the assigned employee was chosen
+type AssignmentRationale struct {
+	Strategy string `json:"strategy"`
+	// CandidatesConsidered counts the in-range candidates handed to the strategy
+	CandidatesConsidered int     `json:"candidates_considered"`
+	ChosenEmployeeID     string  `json:"chosen_employee_id"`
+	ChosenDistanceKm     float64 `json:"chosen_distance_km"`
+	// The runner-up is the closest candidate other than the chosen one
+	RunnerUpEmployeeID string   `json:"runner_up_employee_id,omitempty"`
+	RunnerUpDistanceKm *float64 `json:"runner_up_distance_km,omitempty"`
+	// RadiusTierKm is the radius tier the candidates came from (0 = untiered,
+	// or no tier had a candidate)
+	RadiusTierKm float64 `json:"radius_tier_km,omitempty"`
+	// VetoedEmployeeIDs were chosen first but rejected by the pre-assign hook, in order
+	VetoedEmployeeIDs []string `json:"vetoed_employee_ids,omitempty"`
+}
+
+// newRationale summarizes a strategy's pick among scored candidates
+func newRationale(strategy string, candidates []Candidate, chosen Candidate) *AssignmentRationale {
+	rationale := &AssignmentRationale{
+		Strategy:             strategy,
+		CandidatesConsidered: len(candidates),
+		ChosenEmployeeID:     chosen.EmployeeID,
+		ChosenDistanceKm:     chosen.Distance,
+	}
+	for _, c := range candidates {
+		if c.EmployeeID == chosen.EmployeeID {
+			continue
+		}
+		if rationale.RunnerUpDistanceKm == nil || c.Distance < *rationale.RunnerUpDistanceKm {
+			distance := c.Distance
+			rationale.RunnerUpEmployeeID = c.EmployeeID
+			rationale.RunnerUpDistanceKm = &distance
+		}
+	}
+	return rationale
+}
+
+// TaskRationale returns the recorded rationale of an assigned task
+// Returns ErrTaskNotAssigned if the task exists but has no committed assignment
+func (s *Store) TaskRationale(id string) (*AssignmentRationale, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	task, exists := s.tasks[id]
+	if !exists {
+		return nil, ErrTaskNotFound
+	}
+	if task.Status != TaskStatusAssigned || task.Rationale == nil {
+		return nil, ErrTaskNotAssigned
+	}
+	rationale := *task.Rationale
+	return &rationale, nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestGetTaskRationale tests that the rationale reports the winner and the runner-up
+func TestGetTaskRationale(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	taskLoc := Location{Lat: 60.1700, Lon: 24.9400}
+	near := Location{Lat: 60.1699, Lon: 24.9384}
+	second := Location{Lat: 60.1841, Lon: 24.9501}
+	api.store.AddEmployee(&Employee{ID: "near", Name: "Near", Location: near, Skills: []string{"delivery"}, IsAvailable: true})
+	api.store.AddEmployee(&Employee{ID: "second", Name: "Second", Location: second, Skills: []string{"delivery"}, IsAvailable: true})
+	api.store.AddEmployee(&Employee{ID: "far", Name: "Far", Location: Location{Lat: 60.4518, Lon: 22.2666}, Skills: []string{"delivery"}, IsAvailable: true})
+
+	get := func(id string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/"+id+"/rationale", nil))
+		return w
+	}
+
+	task := &Task{ID: "task1", Location: taskLoc, RequiredSkill: "delivery"}
+	api.store.AddTask(task)
+	if w := get("task1"); w.Code != http.StatusConflict {
+		t.Errorf("Expected 409 before assignment, got %d", w.Code)
+	}
+	if _, err := api.assigner.AssignTask(context.Background(), task); err != nil {
+		t.Fatalf("AssignTask() unexpected error: %v", err)
+	}
+
+	w := get("task1")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response struct {
+		Data AssignmentRationale `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	rationale := response.Data
+
+	if rationale.Strategy != "nearest" || rationale.CandidatesConsidered != 3 {
+		t.Errorf("Expected nearest over 3 candidates, got %+v", rationale)
+	}
+	if rationale.ChosenEmployeeID != "near" || math.Abs(rationale.ChosenDistanceKm-CalculateDistance(taskLoc, near)) > 1e-9 {
+		t.Errorf("Expected near to win at %.4f km, got %s at %.4f km", CalculateDistance(taskLoc, near), rationale.ChosenEmployeeID, rationale.ChosenDistanceKm)
+	}
+	if rationale.RunnerUpEmployeeID != "second" || rationale.RunnerUpDistanceKm == nil ||
+		math.Abs(*rationale.RunnerUpDistanceKm-CalculateDistance(taskLoc, second)) > 1e-9 {
+		t.Errorf("Expected second as runner-up at %.4f km, got %+v", CalculateDistance(taskLoc, second), rationale)
+	}
+
+	if w := get("missing"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown task, got %d", w.Code)
+	}
+}
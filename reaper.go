@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// DefaultReaperInterval is how often the reaper sweeps by default
+const DefaultReaperInterval = time.Second
+
+// Reaper periodically runs housekeeping sweeps against the store
+// (currently: releasing expired employee reservations)
+type Reaper struct {
+	store    *Store
+	interval time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewReaper creates a reaper sweeping the store every interval
+func NewReaper(store *Store, interval time.Duration) *Reaper {
+	if interval <= 0 {
+		interval = DefaultReaperInterval
+	}
+	return &Reaper{store: store, interval: interval}
+}
+
+// Start starts the sweep loop
+func (r *Reaper) Start(ctx context.Context) {
+	ctx, r.cancel = context.WithCancel(ctx)
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.Sweep()
+			}
+		}
+	}()
+}
+
+// Shutdown stops the sweep loop and waits for it to exit
+func (r *Reaper) Shutdown() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+}
+
+// Sweep runs every housekeeping pass once
+func (r *Reaper) Sweep() {
+	for _, id := range r.store.ReapExpiredReservations() {
+		log.Printf("Reservation for employee %s expired, released", id)
+	}
+}
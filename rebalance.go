@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DefaultRebalanceThreshold is the load above which an employee counts as overloaded
+const DefaultRebalanceThreshold = 1
+
+// RebalanceMove is one task moved from an overloaded employee to an idle one
+type RebalanceMove struct {
+	TaskID         string  `json:"task_id"`
+	FromEmployeeID string  `json:"from_employee_id"`
+	ToEmployeeID   string  `json:"to_employee_id"`
+	FromDistanceKm float64 `json:"from_distance_km"`
+	ToDistanceKm   float64 `json:"to_distance_km"`
+	// AddedDistanceKm is ToDistanceKm - FromDistanceKm (negative when the move shortens the trip)
+	AddedDistanceKm float64 `json:"added_distance_km"`
+}
+
+// RebalanceReport lists the moves made by a rebalance, in the order applied
+type RebalanceReport struct {
+	Threshold int             `json:"threshold"`
+	Moves     []RebalanceMove `json:"moves"`
+}
+
+// Rebalance moves assigned tasks off employees holding more than threshold
+// onto employees that were idle, until nobody is over threshold or no idle
+// employee can take a remaining task
+// Moves are picked greedily by least added distance; a receiving employee is
+// filled at most up to threshold (or their capacity, if lower). Targets must
+// satisfy the task's skill, team and distance bounds like a fresh assignment
+// Only tasks in the assigned status move; all moves happen under one store lock
+func (ta *TaskAssigner) Rebalance(threshold int) RebalanceReport {
+	report := RebalanceReport{Threshold: threshold, Moves: []RebalanceMove{}}
+
+	s := ta.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	excess := make(map[string]int)
+	room := make(map[string]int)
+	var idle []*Employee
+	for _, emp := range s.employees {
+		switch {
+		case emp.ActiveTasks > threshold:
+			excess[emp.ID] = emp.ActiveTasks - threshold
+		case emp.ActiveTasks == 0 && emp.IsAvailable && !emp.isReserved(now):
+			room[emp.ID] = min(threshold, emp.maxActiveTasks())
+			idle = append(idle, emp)
+		}
+	}
+	if len(excess) == 0 || len(idle) == 0 {
+		return report
+	}
+
+	type option struct {
+		task     *Task
+		from, to *Employee
+		fromKm   float64
+		toKm     float64
+	}
+	var options []option
+	for _, task := range s.tasks {
+		if task.Status != TaskStatusAssigned || excess[task.AssignedEmployeeID] == 0 {
+			continue
+		}
+		from := s.employees[task.AssignedEmployeeID]
+		fromKm := CalculateDistance(from.Location, task.Location)
+		for _, to := range idle {
+			if !ta.matchesLocked(task, to) {
+				continue
+			}
+			toKm, ok := ta.reaches(task, to)
+			if !ok {
+				continue
+			}
+			options = append(options, option{task: task, from: from, to: to, fromKm: fromKm, toKm: toKm})
+		}
+	}
+	// Added distance doesn't depend on earlier moves, so one sorted pass is the greedy order
+	sort.Slice(options, func(i, j int) bool {
+		a, b := options[i], options[j]
+		if addedA, addedB := a.toKm-a.fromKm, b.toKm-b.fromKm; addedA != addedB {
+			return addedA < addedB
+		}
+		if a.task.ID != b.task.ID {
+			return a.task.ID < b.task.ID
+		}
+		return a.to.ID < b.to.ID
+	})
+
+	moved := make(map[string]bool)
+	for _, opt := range options {
+		if moved[opt.task.ID] || excess[opt.from.ID] == 0 || room[opt.to.ID] == 0 {
+			continue
+		}
+		moved[opt.task.ID] = true
+		excess[opt.from.ID]--
+		room[opt.to.ID]--
+
+		opt.from.ActiveTasks--
+		opt.from.IsAvailable = opt.from.ActiveTasks < opt.from.maxActiveTasks()
+		opt.to.ActiveTasks++
+		opt.to.IsAvailable = opt.to.ActiveTasks < opt.to.maxActiveTasks()
+		ta.metrics.Record(opt.to.ID)
+
+		// Not a new assignment, so the assigned counter is left alone
+		assignedAt := ta.clock.Now().UTC()
+		opt.task.AssignedEmployeeID = opt.to.ID
+		opt.task.AssignedAt = &assignedAt
+		opt.task.AssignedDistanceKm = opt.toKm
+		opt.task.Rationale = &AssignmentRationale{
+			Strategy:             "rebalance",
+			CandidatesConsidered: len(idle),
+			ChosenEmployeeID:     opt.to.ID,
+			ChosenDistanceKm:     opt.toKm,
+		}
+		s.recordTaskEventLocked(opt.task, fmt.Sprintf("rebalanced from %s", opt.from.ID))
+
+		report.Moves = append(report.Moves, RebalanceMove{
+			TaskID:          opt.task.ID,
+			FromEmployeeID:  opt.from.ID,
+			ToEmployeeID:    opt.to.ID,
+			FromDistanceKm:  opt.fromKm,
+			ToDistanceKm:    opt.toKm,
+			AddedDistanceKm: opt.toKm - opt.fromKm,
+		})
+	}
+	return report
+}
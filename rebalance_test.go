@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestRebalance tests that tasks move off an overloaded employee onto idle
+// ones within their bounds, and that failed tasks stay put
+func TestRebalance(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	helsinki := Location{Lat: 60.1699, Lon: 24.9384}
+	api.store.AddEmployee(&Employee{ID: "busy", Name: "Busy", Location: helsinki, Skills: []string{"delivery"}, IsAvailable: true, Capacity: 5})
+	tasks := map[string]*Task{
+		"task1": {ID: "task1", Location: Location{Lat: 60.1710, Lon: 24.9410}, RequiredSkill: "delivery"},
+		"task2": {ID: "task2", Location: Location{Lat: 60.1650, Lon: 24.9300}, RequiredSkill: "delivery"},
+		"task3": {ID: "task3", Location: Location{Lat: 60.1800, Lon: 24.9500}, RequiredSkill: "delivery", MaxDistanceKm: 5},
+		"task4": {ID: "task4", Location: helsinki, RequiredSkill: "delivery"},
+	}
+	for _, id := range []string{"task1", "task2", "task3", "task4"} {
+		api.store.AddTask(tasks[id])
+		if _, err := api.assigner.AssignTask(context.Background(), tasks[id]); err != nil {
+			t.Fatalf("AssignTask(%s) unexpected error: %v", id, err)
+		}
+	}
+	api.store.FailTask("task4", "cancelled")
+
+	// Added after the assignments so everything landed on "busy"
+	api.store.AddEmployee(&Employee{ID: "near", Name: "Near", Location: Location{Lat: 60.1750, Lon: 24.9450}, Skills: []string{"delivery"}, IsAvailable: true, Capacity: 3})
+	api.store.AddEmployee(&Employee{ID: "far", Name: "Far", Location: Location{Lat: 60.4518, Lon: 22.2666}, Skills: []string{"delivery"}, IsAvailable: true})
+	api.store.AddEmployee(&Employee{ID: "welder", Name: "Welder", Location: helsinki, Skills: []string{"welding"}, IsAvailable: true})
+
+	post := func() RebalanceReport {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/admin/rebalance", bytes.NewBufferString(`{"threshold": 1}`)))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var response struct {
+			Data RebalanceReport `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return response.Data
+	}
+
+	// busy holds 3 live tasks, 2 over the threshold; near takes up to 1 (the
+	// threshold), far is out of range for task3, welder lacks the skill
+	report := post()
+	if len(report.Moves) != 2 {
+		t.Fatalf("Expected 2 moves, got %+v", report.Moves)
+	}
+	received := map[string]string{}
+	for _, move := range report.Moves {
+		if move.FromEmployeeID != "busy" {
+			t.Errorf("Unexpected move source %+v", move)
+		}
+		if move.AddedDistanceKm != move.ToDistanceKm-move.FromDistanceKm {
+			t.Errorf("Inconsistent added distance %+v", move)
+		}
+		received[move.ToEmployeeID] = move.TaskID
+	}
+	if received["near"] == "" || received["far"] == "" {
+		t.Fatalf("Expected one task each for near and far, got %+v", report.Moves)
+	}
+	if received["far"] == "task3" {
+		t.Error("task3 moved beyond its max_distance_km")
+	}
+	if report.Moves[0].AddedDistanceKm > report.Moves[1].AddedDistanceKm {
+		t.Errorf("Expected moves in order of added distance, got %+v", report.Moves)
+	}
+
+	for id, want := range map[string]int{"busy": 1, "near": 1, "far": 1, "welder": 0} {
+		emp, _ := api.store.GetEmployee(id)
+		if emp.ActiveTasks != want {
+			t.Errorf("%s has %d active tasks, want %d", id, emp.ActiveTasks, want)
+		}
+	}
+	for to, taskID := range received {
+		task, _ := api.store.GetTask(taskID)
+		last := task.History[len(task.History)-1]
+		if task.Status != TaskStatusAssigned || task.AssignedEmployeeID != to || last.Reason != "rebalanced from busy" {
+			t.Errorf("Unexpected moved task %+v", task)
+		}
+	}
+	if task, _ := api.store.GetTask("task4"); task.Status != TaskStatusFailed || task.AssignedEmployeeID != "" {
+		t.Errorf("Failed task was touched: %+v", task)
+	}
+
+	// Balanced now: nothing else moves
+	if report := post(); len(report.Moves) != 0 {
+		t.Errorf("Expected no moves on a second pass, got %+v", report.Moves)
+	}
+}
+
+// TestRebalanceIgnoreDistance tests that a task ignoring distance can move to
+// an idle employee it is out of range for, as a fresh assignment could
+func TestRebalanceIgnoreDistance(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+	assigner.SetIgnoreDistance(true)
+
+	helsinki := Location{Lat: 60.1699, Lon: 24.9384}
+	store.AddEmployee(&Employee{ID: "busy", Name: "Busy", Location: helsinki, Skills: []string{"delivery"}, IsAvailable: true, Capacity: 2})
+	for _, id := range []string{"task1", "task2"} {
+		task := &Task{ID: id, Location: helsinki, RequiredSkill: "delivery"}
+		store.AddTask(task)
+		if _, err := assigner.AssignTask(context.Background(), task); err != nil {
+			t.Fatalf("AssignTask(%s) unexpected error: %v", id, err)
+		}
+	}
+	store.AddEmployee(&Employee{ID: "far", Name: "Far", Location: Location{Lat: 60.4518, Lon: 22.2666}, Skills: []string{"delivery"}, IsAvailable: true, MaxRangeKm: 1})
+
+	report := assigner.Rebalance(1)
+	if len(report.Moves) != 1 || report.Moves[0].ToEmployeeID != "far" {
+		t.Errorf("Expected one move to far, got %+v", report.Moves)
+	}
+}
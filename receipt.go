@@ -0,0 +1,47 @@
+package main
+
+import "time"
+
+// TaskReceipt is a proof-of-dispatch document for an assigned task
+type TaskReceipt struct {
+	TaskID           string    `json:"task_id"`
+	RequiredSkill    string    `json:"required_skill"`
+	TaskLocation     Location  `json:"task_location"`
+	Priority         int       `json:"priority"`
+	EmployeeID       string    `json:"employee_id"`
+	EmployeeName     string    `json:"employee_name"`
+	EmployeeLocation Location  `json:"employee_location"`
+	DistanceKm       float64   `json:"distance_km"`
+	AssignedAt       time.Time `json:"assigned_at"`
+}
+
+// TaskReceipt joins an assigned task with its employee under a single read lock
+// Returns ErrTaskNotAssigned if the task exists but has no committed assignment
+func (s *Store) TaskReceipt(id string) (*TaskReceipt, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	task, exists := s.tasks[id]
+	if !exists {
+		return nil, ErrTaskNotFound
+	}
+	if task.Status != TaskStatusAssigned || task.AssignedAt == nil {
+		return nil, ErrTaskNotAssigned
+	}
+	emp, exists := s.employees[task.AssignedEmployeeID]
+	if !exists {
+		return nil, ErrEmployeeNotFound
+	}
+
+	return &TaskReceipt{
+		TaskID:           task.ID,
+		RequiredSkill:    task.RequiredSkill,
+		TaskLocation:     task.Location,
+		Priority:         task.Priority,
+		EmployeeID:       emp.ID,
+		EmployeeName:     emp.Name,
+		EmployeeLocation: emp.Location,
+		DistanceKm:       task.AssignedDistanceKm,
+		AssignedAt:       *task.AssignedAt,
+	}, nil
+}
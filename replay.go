@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// AssignmentLogEntry records one committed assignment together with the task
+// fields needed to re-create it
+type AssignmentLogEntry struct {
+	TaskID        string    `json:"task_id" binding:"required"`
+	Location      Location  `json:"location"`
+	RequiredSkill string    `json:"required_skill" binding:"required"`
+	MaxDistanceKm float64   `json:"max_distance_km,omitempty"`
+	MinDistanceKm float64   `json:"min_distance_km,omitempty"`
+	Priority      int       `json:"priority,omitempty"`
+	TeamID        string    `json:"team_id,omitempty"`
+	EmployeeID    string    `json:"employee_id"`
+	AssignedAt    time.Time `json:"assigned_at"`
+}
+
+// AssignmentLog returns the store's committed assignments in the order they
+// were made (by AssignedAt, then task ID)
+func (s *Store) AssignmentLog() []AssignmentLogEntry {
+	s.mu.RLock()
+	entries := []AssignmentLogEntry{}
+	for _, task := range s.tasks {
+		if task.AssignedAt == nil || task.AssignedEmployeeID == "" {
+			continue
+		}
+		entries = append(entries, AssignmentLogEntry{
+			TaskID:        task.ID,
+			Location:      task.Location,
+			RequiredSkill: task.RequiredSkill,
+			MaxDistanceKm: task.MaxDistanceKm,
+			MinDistanceKm: task.MinDistanceKm,
+			Priority:      task.Priority,
+			TeamID:        task.TeamID,
+			EmployeeID:    task.AssignedEmployeeID,
+			AssignedAt:    *task.AssignedAt,
+		})
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].AssignedAt.Equal(entries[j].AssignedAt) {
+			return entries[i].AssignedAt.Before(entries[j].AssignedAt)
+		}
+		return entries[i].TaskID < entries[j].TaskID
+	})
+	return entries
+}
+
+// ReplayDifference is a logged assignment the replay did not reproduce
+type ReplayDifference struct {
+	TaskID             string `json:"task_id"`
+	OriginalEmployeeID string `json:"original_employee_id"`
+	ReplayedEmployeeID string `json:"replayed_employee_id,omitempty"`
+	Error              string `json:"error,omitempty"`
+}
+
+// ReplayReport summarizes a replay run
+type ReplayReport struct {
+	Strategy    string             `json:"strategy"`
+	Replayed    int                `json:"replayed"`
+	Matched     int                `json:"matched"`
+	Differences []ReplayDifference `json:"differences"`
+}
+
+// ReplayAssignments re-runs the logged task creations, in order, against a
+// throwaway copy of the given employees and reports which assignments differ
+// Employees start with no active tasks so the replay sees the same empty
+// workload the original run did; anyone unavailable only because they were
+// at capacity is made available again
+// Assignments stop once ctx is done; the remaining entries are not replayed
+func ReplayAssignments(ctx context.Context, employees []Employee, entries []AssignmentLogEntry, strategy AssignmentStrategy, matcher SkillMatcher) ReplayReport {
+	store := NewStore()
+	store.SetSkillMatcher(matcher)
+	for i := range employees {
+		emp := employees[i]
+		// Snapshots carry soft-deleted employees, who never take work
+		if emp.DeletedAt != nil {
+			continue
+		}
+		emp.Skills = append([]string(nil), emp.Skills...)
+		if emp.ActiveTasks >= emp.maxActiveTasks() {
+			emp.IsAvailable = true
+		}
+		emp.ActiveTasks = 0
+		emp.ReservedUntil = nil
+		store.AddEmployee(&emp)
+	}
+	assigner := NewTaskAssigner(store)
+	assigner.SetStrategy(strategy)
+
+	report := ReplayReport{Strategy: strategy.Name(), Differences: []ReplayDifference{}}
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			break
+		}
+		task := &Task{
+			ID:            entry.TaskID,
+			Location:      entry.Location,
+			RequiredSkill: entry.RequiredSkill,
+			MaxDistanceKm: entry.MaxDistanceKm,
+			MinDistanceKm: entry.MinDistanceKm,
+			Priority:      entry.Priority,
+			TeamID:        entry.TeamID,
+		}
+		report.Replayed++
+
+		if err := store.AddTask(task); err != nil {
+			report.Differences = append(report.Differences, ReplayDifference{
+				TaskID:             entry.TaskID,
+				OriginalEmployeeID: entry.EmployeeID,
+				Error:              err.Error(),
+			})
+			continue
+		}
+
+		taskCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		result, err := assigner.AssignTask(taskCtx, task)
+		cancel()
+
+		switch {
+		case err != nil:
+			report.Differences = append(report.Differences, ReplayDifference{
+				TaskID:             entry.TaskID,
+				OriginalEmployeeID: entry.EmployeeID,
+				Error:              err.Error(),
+			})
+		case result.EmployeeID != entry.EmployeeID:
+			report.Differences = append(report.Differences, ReplayDifference{
+				TaskID:             entry.TaskID,
+				OriginalEmployeeID: entry.EmployeeID,
+				ReplayedEmployeeID: result.EmployeeID,
+			})
+		default:
+			report.Matched++
+		}
+	}
+	return report
+}
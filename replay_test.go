@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestReplayUnderDifferentStrategy tests that replaying the assignment log under
+// another strategy reports the assignments that changed
+func TestReplayUnderDifferentStrategy(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	api.store.AddEmployee(&Employee{ID: "near", Name: "Near", Location: Location{Lat: 60.1699, Lon: 24.9384}, Skills: []string{"delivery"}, IsAvailable: true})
+	api.store.AddEmployee(&Employee{ID: "far", Name: "Far", Location: Location{Lat: 60.4518, Lon: 22.2666}, Skills: []string{"delivery"}, IsAvailable: true})
+
+	for _, id := range []string{"task1", "task2"} {
+		task := &Task{ID: id, Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"}
+		api.store.AddTask(task)
+		if _, err := api.assigner.AssignTask(context.Background(), task); err != nil {
+			t.Fatalf("AssignTask(%s) unexpected error: %v", id, err)
+		}
+	}
+	if log := api.store.AssignmentLog(); len(log) != 2 || log[0].EmployeeID != "near" {
+		t.Fatalf("Expected task1 logged first against near, got %+v", log)
+	}
+
+	tests := []struct {
+		name        string
+		strategy    string
+		wantMatched int
+		wantDiffs   int
+	}{
+		{"Same strategy reproduces the log", "nearest", 2, 0},
+		{"Reverse distance swaps both assignments", "reverse_distance", 0, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := `{"strategy": "` + tt.strategy + `"}`
+			req := httptest.NewRequest("POST", "/admin/replay", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			var response struct {
+				Data ReplayReport `json:"data"`
+			}
+			json.Unmarshal(w.Body.Bytes(), &response)
+
+			report := response.Data
+			if report.Replayed != 2 || report.Matched != tt.wantMatched || len(report.Differences) != tt.wantDiffs {
+				t.Fatalf("Expected %d matched and %d differences, got %+v", tt.wantMatched, tt.wantDiffs, report)
+			}
+			if tt.wantDiffs > 0 {
+				diff := report.Differences[0]
+				if diff.TaskID != "task1" || diff.OriginalEmployeeID != "near" || diff.ReplayedEmployeeID != "far" {
+					t.Errorf("Unexpected first difference: %+v", diff)
+				}
+			}
+		})
+	}
+
+	// The replay runs in a throwaway store
+	if task, _ := api.store.GetTask("task1"); task.AssignedEmployeeID != "near" {
+		t.Errorf("Replay modified the live store: task1 assigned to %s", task.AssignedEmployeeID)
+	}
+
+	req := httptest.NewRequest("POST", "/admin/replay", bytes.NewBufferString(`{"strategy": "bogus"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown strategy, got %d", w.Code)
+	}
+}
+
+// TestReplaySkipsDeletedEmployees tests that soft-deleted employees in the
+// snapshot don't take replayed work
+func TestReplaySkipsDeletedEmployees(t *testing.T) {
+	store := NewStore()
+	store.AddEmployee(&Employee{ID: "closest", Name: "Closest", Location: Location{Lat: 60.1700, Lon: 24.9401}, Skills: []string{"delivery"}, IsAvailable: true})
+	store.AddEmployee(&Employee{ID: "near", Name: "Near", Location: Location{Lat: 60.1699, Lon: 24.9384}, Skills: []string{"delivery"}, IsAvailable: true})
+	if _, err := store.DeleteEmployee("closest"); err != nil {
+		t.Fatalf("DeleteEmployee() unexpected error: %v", err)
+	}
+
+	entries := []AssignmentLogEntry{{TaskID: "task1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery", EmployeeID: "near"}}
+	report := ReplayAssignments(context.Background(), store.Snapshot().Employees, entries, NearestStrategy{}, SkillMatcher{})
+	if report.Matched != 1 {
+		t.Errorf("Expected the replay to match the log, got %+v", report)
+	}
+}
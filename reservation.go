@@ -0,0 +1,67 @@
+package main
+
+import "time"
+
+// MaxReservation caps how far ahead an employee can be reserved
+const MaxReservation = 24 * time.Hour
+
+// isReserved reports whether the employee's reservation is still active at now
+// An expired reservation no longer excludes the employee even before the
+// reaper clears it; caller must hold the store lock
+func (e *Employee) isReserved(now time.Time) bool {
+	return e.ReservedUntil != nil && now.Before(*e.ReservedUntil)
+}
+
+// assignableAt reports whether the employee is available and not reserved at
+// now; caller must hold the store lock
+func (e *Employee) assignableAt(now time.Time) bool {
+	return e.IsAvailable && !e.isReserved(now)
+}
+
+// ReserveEmployee holds an employee back from general assignment for d
+// Reserving an already reserved employee replaces the expiry
+func (s *Store) ReserveEmployee(id string, d time.Duration) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	emp, exists := s.employees[id]
+	if !exists {
+		return time.Time{}, ErrEmployeeNotFound
+	}
+	until := s.clock.Now().UTC().Add(d)
+	emp.ReservedUntil = &until
+	return until, nil
+}
+
+// UnreserveEmployee releases a reservation ahead of its expiry
+func (s *Store) UnreserveEmployee(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	emp, exists := s.employees[id]
+	if !exists {
+		return ErrEmployeeNotFound
+	}
+	if !emp.isReserved(s.clock.Now()) {
+		return ErrEmployeeNotReserved
+	}
+	emp.ReservedUntil = nil
+	return nil
+}
+
+// ReapExpiredReservations clears reservations whose expiry has passed
+// Returns the IDs of the released employees
+func (s *Store) ReapExpiredReservations() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	var released []string
+	for id, emp := range s.employees {
+		if emp.ReservedUntil != nil && !emp.isReserved(now) {
+			emp.ReservedUntil = nil
+			released = append(released, id)
+		}
+	}
+	return released
+}
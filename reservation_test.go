@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestReservationExcludesEmployee tests that a reserved employee is skipped until released
+func TestReservationExcludesEmployee(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	api.store.AddEmployee(&Employee{
+		ID:          "emp1",
+		Name:        "John",
+		Location:    Location{Lat: 60.1699, Lon: 24.9384},
+		Skills:      []string{"delivery"},
+		IsAvailable: true,
+	})
+
+	post := func(path, body string) int {
+		req := httptest.NewRequest("POST", path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := post("/employees/emp1/reserve", `{"duration": "30m"}`); code != http.StatusOK {
+		t.Fatalf("Expected reserve status 200, got %d", code)
+	}
+
+	task := &Task{ID: "task1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"}
+	api.store.AddTask(task)
+	result, err := api.assigner.AssignTask(context.Background(), task)
+	if !errors.Is(err, ErrNoEligibleEmployee) {
+		t.Fatalf("Expected reserved employee to be excluded, got %v", err)
+	}
+	if result.Diagnostics.Available != 0 {
+		t.Errorf("Expected reserved employee not counted as available, got %d", result.Diagnostics.Available)
+	}
+
+	if code := post("/employees/emp1/unreserve", ""); code != http.StatusOK {
+		t.Fatalf("Expected unreserve status 200, got %d", code)
+	}
+	if code := post("/employees/emp1/unreserve", ""); code != http.StatusConflict {
+		t.Errorf("Expected second unreserve status 409, got %d", code)
+	}
+
+	task2 := &Task{ID: "task2", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"}
+	api.store.AddTask(task2)
+	if _, err := api.assigner.AssignTask(context.Background(), task2); err != nil {
+		t.Errorf("Expected assignment after unreserve, got %v", err)
+	}
+
+	for _, body := range []string{`{}`, `{"duration": "soon"}`, `{"duration": "-1m"}`, `{"duration": "48h"}`} {
+		if code := post("/employees/emp1/reserve", body); code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", body, code)
+		}
+	}
+	if code := post("/employees/missing/reserve", `{"duration": "30m"}`); code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown employee, got %d", code)
+	}
+}
+
+// TestReservationAutoRelease tests that the reaper releases a reservation at expiry
+func TestReservationAutoRelease(t *testing.T) {
+	clock := newFakeClock()
+	store := NewStore()
+	store.SetClock(clock)
+	assigner := NewTaskAssigner(store)
+	reaper := NewReaper(store, time.Minute)
+
+	store.AddEmployee(&Employee{
+		ID:          "emp1",
+		Name:        "John",
+		Location:    Location{Lat: 60.1699, Lon: 24.9384},
+		Skills:      []string{"delivery"},
+		IsAvailable: true,
+	})
+	if _, err := store.ReserveEmployee("emp1", 10*time.Minute); err != nil {
+		t.Fatalf("ReserveEmployee() unexpected error: %v", err)
+	}
+
+	clock.Advance(9 * time.Minute)
+	reaper.Sweep()
+	store.mu.RLock()
+	reserved := store.employees["emp1"].ReservedUntil != nil
+	store.mu.RUnlock()
+	if !reserved {
+		t.Fatal("Expected reservation to survive a sweep before expiry")
+	}
+
+	clock.Advance(time.Minute)
+	reaper.Sweep()
+	store.mu.RLock()
+	reserved = store.employees["emp1"].ReservedUntil != nil
+	store.mu.RUnlock()
+	if reserved {
+		t.Fatal("Expected reaper to clear the expired reservation")
+	}
+
+	task := &Task{ID: "task1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"}
+	store.AddTask(task)
+	if _, err := assigner.AssignTask(context.Background(), task); err != nil {
+		t.Errorf("Expected assignment after reservation expired, got %v", err)
+	}
+}
+
+// TestReservationDuringAssignment tests that an employee reserved while an
+// assignment is being scored is not committed, and that reserved employees
+// don't count as available anywhere
+func TestReservationDuringAssignment(t *testing.T) {
+	api := setupTestAPI()
+	strategy := blockingStrategy{picked: make(chan struct{}), release: make(chan struct{})}
+	api.assigner.SetStrategy(strategy)
+
+	api.store.AddEmployee(&Employee{ID: "emp1", Name: "John", Location: Location{Lat: 60.1699, Lon: 24.9384}, Skills: []string{"delivery"}, IsAvailable: true})
+	task := &Task{ID: "task1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"}
+	api.store.AddTask(task)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := api.assigner.AssignTask(context.Background(), task)
+		done <- err
+	}()
+	<-strategy.picked
+	if _, err := api.store.ReserveEmployee("emp1", time.Hour); err != nil {
+		t.Fatalf("ReserveEmployee() unexpected error: %v", err)
+	}
+	close(strategy.release)
+	if err := <-done; !errors.Is(err, ErrEmployeeNoLongerAvailable) {
+		t.Errorf("Expected a CAS race for the reserved employee, got %v", err)
+	}
+	if emp, _ := api.store.GetEmployee("emp1"); emp.ActiveTasks != 0 {
+		t.Errorf("Reserved employee was assigned: %+v", emp)
+	}
+
+	if got := api.store.GetAvailableEmployees("delivery"); len(got) != 0 {
+		t.Errorf("Expected no available employees while reserved, got %d", len(got))
+	}
+	if stats := api.collectStats(); stats.AvailableEmployees != 0 {
+		t.Errorf("Expected available_employees 0 while reserved, got %d", stats.AvailableEmployees)
+	}
+	box := BoundingBox{MinLat: 60.1, MinLon: 24.9, MaxLat: 60.2, MaxLon: 25.0}
+	if estimate := api.assigner.EstimateCapacity("delivery", box, 1, 0); estimate.AvailableEmployees != 0 || estimate.Assignable != 0 {
+		t.Errorf("Expected the reserved employee left out of the estimate, got %+v", estimate)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"encoding/gob"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MIMEGob is the media type for gob-encoded collection responses
+const MIMEGob = "application/x-gob"
+
+// envelopeDisabled reports whether the client asked for bare (unwrapped) responses
+// via the X-Envelope: false header or the envelope=false query parameter
+func envelopeDisabled(c *gin.Context) bool {
+	return strings.EqualFold(c.GetHeader("X-Envelope"), "false") ||
+		strings.EqualFold(c.Query("envelope"), "false")
+}
+
+// prettyRequested reports whether the client asked for indented JSON
+// via the X-Pretty: true header or the pretty=true query parameter
+func prettyRequested(c *gin.Context) bool {
+	return strings.EqualFold(c.GetHeader("X-Pretty"), "true") ||
+		strings.EqualFold(c.Query("pretty"), "true")
+}
+
+// writeJSON writes obj as compact JSON, or indented JSON when requested
+func writeJSON(c *gin.Context, status int, obj interface{}) {
+	if prettyRequested(c) {
+		c.IndentedJSON(status, obj)
+		return
+	}
+	c.JSON(status, obj)
+}
+
+// setNextOffsetHeader mirrors resp.NextOffset in X-Next-Offset, for bare and
+// gob responses that carry only the data
+func setNextOffsetHeader(c *gin.Context, resp SuccessResponse) {
+	if resp.NextOffset != nil {
+		c.Header("X-Next-Offset", strconv.Itoa(*resp.NextOffset))
+	}
+}
+
+// respondSuccess writes a success response
+// Enveloped by default; in bare mode only resp.Data is written
+func respondSuccess(c *gin.Context, status int, resp SuccessResponse) {
+	setNextOffsetHeader(c, resp)
+	if envelopeDisabled(c) {
+		writeJSON(c, status, resp.Data)
+		return
+	}
+	writeJSON(c, status, resp)
+}
+
+// respondError writes an error response
+// ErrorResponse carries no wrapper, so bare and enveloped modes are identical
+func respondError(c *gin.Context, status int, resp ErrorResponse) {
+	writeJSON(c, status, resp)
+}
+
+// gobRequested reports whether the client's Accept header asks for gob
+func gobRequested(c *gin.Context) bool {
+	for _, mediaType := range strings.Split(c.GetHeader("Accept"), ",") {
+		if mediaType, _, _ = strings.Cut(mediaType, ";"); strings.EqualFold(strings.TrimSpace(mediaType), MIMEGob) {
+			return true
+		}
+	}
+	return false
+}
+
+// respondCollection writes a list endpoint response
+// With Accept: application/x-gob only resp.Data is written, gob-encoded, for
+// consumers that find JSON decoding of large lists too slow; it decodes into
+// the same slice type (e.g. []Task). Otherwise it behaves like respondSuccess
+func respondCollection(c *gin.Context, status int, resp SuccessResponse) {
+	if !gobRequested(c) {
+		respondSuccess(c, status, resp)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(resp.Data); err != nil {
+		respondError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to encode response",
+			Message: err.Error(),
+		})
+		return
+	}
+	setNextOffsetHeader(c, resp)
+	c.Data(status, MIMEGob, buf.Bytes())
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestRetryTask tests that a retry assigns a pending task synchronously once
+// a matching employee exists, and reports failures and non-pending tasks
+func TestRetryTask(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	loc := Location{Lat: 60.1699, Lon: 24.9384}
+	api.store.AddTask(&Task{ID: "stuck", Location: loc, RequiredSkill: "delivery"})
+	api.store.AddTask(&Task{ID: "rare", Location: loc, RequiredSkill: "welding"})
+	api.store.AddEmployee(&Employee{ID: "emp1", Name: "Alice", Location: Location{Lat: 60.1710, Lon: 24.9410}, Skills: []string{"delivery"}, IsAvailable: true})
+
+	retry := func(id string, wantStatus int) RetryResponse {
+		t.Helper()
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/tasks/"+id+"/retry", nil))
+		if w.Code != wantStatus {
+			t.Fatalf("POST /tasks/%s/retry: expected status %d, got %d: %s", id, wantStatus, w.Code, w.Body.String())
+		}
+		var response struct {
+			Data RetryResponse `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return response.Data
+	}
+
+	resp := retry("stuck", http.StatusOK)
+	if !resp.Assigned || resp.EmployeeID != "emp1" || resp.DistanceKm <= 0 {
+		t.Errorf("Expected an assignment to emp1, got %+v", resp)
+	}
+	if resp.Task.Status != TaskStatusAssigned || resp.Task.AssignedEmployeeID != "emp1" {
+		t.Errorf("Expected the returned task to be assigned to emp1, got %+v", resp.Task)
+	}
+	if emp, _ := api.store.GetEmployee("emp1"); emp.ActiveTasks != 1 {
+		t.Errorf("emp1 has %d active tasks, want 1", emp.ActiveTasks)
+	}
+
+	// Only pending tasks can be retried
+	retry("stuck", http.StatusConflict)
+	retry("missing", http.StatusNotFound)
+
+	resp = retry("rare", http.StatusOK)
+	if resp.Assigned || resp.Code != ErrNoEligibleEmployee.Code || resp.Diagnostics == nil || resp.Diagnostics.WithSkill != 0 {
+		t.Errorf("Expected a NO_ELIGIBLE_EMPLOYEE outcome with diagnostics, got %+v", resp)
+	}
+	if resp.Task.Status != TaskStatusFailed {
+		t.Errorf("Expected the failed attempt to fail the task, got %s", resp.Task.Status)
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ScoreFunc rates a candidate for a task; the lowest score wins
+// distanceKm is the candidate's distance from the task (Candidate.Distance)
+type ScoreFunc func(task *Task, candidate Candidate, distanceKm float64) float64
+
+// ScoredStrategy adapts a ScoreFunc to AssignmentStrategy
+// Ties go to the lower employee ID so picks are deterministic
+type ScoredStrategy struct {
+	name  string
+	score ScoreFunc
+}
+
+func (s ScoredStrategy) Name() string { return s.name }
+
+func (s ScoredStrategy) Select(task *Task, candidates []Candidate) int {
+	best, bestScore := 0, s.score(task, candidates[0], candidates[0].Distance)
+	for i, c := range candidates[1:] {
+		score := s.score(task, c, c.Distance)
+		if score < bestScore || (score == bestScore && c.EmployeeID < candidates[best].EmployeeID) {
+			best, bestScore = i+1, score
+		}
+	}
+	return best
+}
+
+// RegisterScorer adds a custom scoring function as a strategy under name, so
+// it can be picked with ASSIGNMENT_STRATEGY or a task's strategy field
+// Call it from an init function: the registry is not safe to change once
+// the API is serving. Names are case-insensitive and must not be taken
+func RegisterScorer(name string, score ScoreFunc) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" || score == nil {
+		return fmt.Errorf("scorer needs a name and a function")
+	}
+	if _, taken := strategies[name]; taken {
+		return fmt.Errorf("assignment strategy %q is already registered", name)
+	}
+	strategies[name] = ScoredStrategy{name: name, score: score}
+	return nil
+}
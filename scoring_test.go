@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+// TestRegisterScorer tests that a registered scorer is selectable per task
+// and that inverting distance hands the task to the farthest employee
+func TestRegisterScorer(t *testing.T) {
+	inverted := func(task *Task, candidate Candidate, distanceKm float64) float64 { return -distanceKm }
+	if err := RegisterScorer("Inverted_Test", inverted); err != nil {
+		t.Fatalf("RegisterScorer() unexpected error: %v", err)
+	}
+	t.Cleanup(func() { delete(strategies, "inverted_test") })
+	if err := RegisterScorer("nearest", inverted); err == nil {
+		t.Error("Expected an error re-registering a built-in name")
+	}
+
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+	store.AddEmployee(&Employee{ID: "near", Name: "Near", Location: Location{Lat: 60.1700, Lon: 24.9390}, Skills: []string{"delivery"}, IsAvailable: true, Capacity: 5})
+	store.AddEmployee(&Employee{ID: "far", Name: "Far", Location: Location{Lat: 60.2000, Lon: 24.9700}, Skills: []string{"delivery"}, IsAvailable: true, Capacity: 5})
+
+	for strategy, want := range map[string]string{"": "near", "inverted_test": "far"} {
+		task := &Task{ID: "task-" + want, Location: Location{Lat: 60.1699, Lon: 24.9384}, RequiredSkill: "delivery", Strategy: strategy}
+		if err := task.Validate(); err != nil {
+			t.Fatalf("Validate() rejected strategy %q: %v", strategy, err)
+		}
+		store.AddTask(task)
+		result, err := assigner.AssignTask(context.Background(), task)
+		if err != nil {
+			t.Fatalf("AssignTask() unexpected error: %v", err)
+		}
+		if result.EmployeeID != want {
+			t.Errorf("Strategy %q picked %s, want %s", strategy, result.EmployeeID, want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Default HTTP server timeouts
+const (
+	DefaultReadTimeout  = 15 * time.Second
+	DefaultWriteTimeout = 15 * time.Second
+	DefaultIdleTimeout  = 60 * time.Second
+)
+
+// streamingRoutes stream or long-poll, so they run without the request
+// timeout and the server's read/write deadlines
+var streamingRoutes = []string{"/tasks/stream", "/tasks/:id/result", "/tasks/export.ndjson", "/employees/export.ndjson"}
+
+// newServer builds the HTTP server with the configured timeouts
+func (api *API) newServer(port string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:         ":" + port,
+		Handler:      handler,
+		ReadTimeout:  api.config.ReadTimeout,
+		WriteTimeout: api.config.WriteTimeout,
+		IdleTimeout:  api.config.IdleTimeout,
+	}
+}
+
+// clearDeadlines lifts the server's read and write deadlines for the given
+// route patterns, so a long stream isn't cut off by WRITE_TIMEOUT
+func clearDeadlines(routes ...string) gin.HandlerFunc {
+	skip := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		skip[route] = true
+	}
+
+	return func(c *gin.Context) {
+		if skip[c.FullPath()] {
+			rc := http.NewResponseController(c.Writer)
+			// Not every writer supports deadlines (e.g. test recorders); nothing to lift then
+			_ = rc.SetReadDeadline(time.Time{})
+			_ = rc.SetWriteDeadline(time.Time{})
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestServerTimeouts tests that server timeouts come from the environment,
+// with invalid values keeping their defaults
+func TestServerTimeouts(t *testing.T) {
+	env := map[string]string{
+		"READ_TIMEOUT":  "30s",
+		"WRITE_TIMEOUT": "2m",
+		"IDLE_TIMEOUT":  "forever",
+	}
+	cfg, err := LoadConfigFrom(func(name string) string { return env[name] })
+	if err == nil {
+		t.Error("Expected IDLE_TIMEOUT to be reported as invalid")
+	}
+	api, err := NewAPIWithConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewAPIWithConfig() unexpected error: %v", err)
+	}
+
+	srv := api.newServer("9090", nil)
+	if srv.Addr != ":9090" || srv.ReadTimeout != 30*time.Second || srv.WriteTimeout != 2*time.Minute || srv.IdleTimeout != DefaultIdleTimeout {
+		t.Errorf("Unexpected server timeouts read=%v write=%v idle=%v", srv.ReadTimeout, srv.WriteTimeout, srv.IdleTimeout)
+	}
+
+	cfg.WriteTimeout = -time.Second
+	if _, err := NewAPIWithConfig(cfg); err == nil {
+		t.Error("Expected a negative write timeout to be rejected")
+	}
+}
+
+// TestClearDeadlines tests that exempt routes outlive the server's write timeout
+func TestClearDeadlines(t *testing.T) {
+	router := gin.New()
+	router.Use(clearDeadlines("/stream"))
+	slow := func(c *gin.Context) {
+		time.Sleep(150 * time.Millisecond)
+		c.String(http.StatusOK, "done")
+	}
+	router.GET("/stream", slow)
+	router.GET("/other", slow)
+
+	server := httptest.NewUnstartedServer(router)
+	server.Config.WriteTimeout = 50 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	get := func(path string) (string, error) {
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		return string(body), err
+	}
+
+	if body, err := get("/stream"); err != nil || body != "done" {
+		t.Errorf("Exempt route: got %q, %v; want the full response", body, err)
+	}
+	if body, err := get("/other"); err == nil && body == "done" {
+		t.Error("Expected the write timeout to cut off a non-exempt route")
+	}
+}
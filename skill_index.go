@@ -0,0 +1,57 @@
+package main
+
+// indexEmployeeLocked adds the employee under each of their skills
+// The index is keyed by the stored (normalized) skills, so an employee
+// listing a skill twice is still indexed once; caller must hold the store lock
+func (s *Store) indexEmployeeLocked(emp *Employee) {
+	for _, skill := range emp.Skills {
+		ids, ok := s.skillIndex[skill]
+		if !ok {
+			ids = make(map[string]struct{})
+			s.skillIndex[skill] = ids
+		}
+		ids[emp.ID] = struct{}{}
+	}
+}
+
+// unindexEmployeeLocked removes the employee from the index; caller must hold the store lock
+func (s *Store) unindexEmployeeLocked(emp *Employee) {
+	for _, skill := range emp.Skills {
+		ids := s.skillIndex[skill]
+		delete(ids, emp.ID)
+		if len(ids) == 0 {
+			delete(s.skillIndex, skill)
+		}
+	}
+}
+
+// rebuildSkillIndexLocked reindexes every live employee; caller must hold the store lock
+func (s *Store) rebuildSkillIndexLocked() {
+	s.skillIndex = make(map[string]map[string]struct{})
+	for _, emp := range s.employees {
+		s.indexEmployeeLocked(emp)
+	}
+}
+
+// skillCandidatesLocked returns the live employees that may satisfy the
+// required skill: in exact mode only those indexed under it, otherwise
+// everyone (prefix and fuzzy matches can't be looked up). Callers still
+// apply the skill matcher; caller must hold the store lock
+func (s *Store) skillCandidatesLocked(required string) []*Employee {
+	if s.skillMatcher.Mode != "" && s.skillMatcher.Mode != SkillMatchExact {
+		all := make([]*Employee, 0, len(s.employees))
+		for _, emp := range s.employees {
+			all = append(all, emp)
+		}
+		return all
+	}
+
+	ids := s.skillIndex[normalizeSkill(required)]
+	candidates := make([]*Employee, 0, len(ids))
+	for id := range ids {
+		if emp, exists := s.employees[id]; exists {
+			candidates = append(candidates, emp)
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+// scanAvailableEmployees is the brute-force lookup the skill index replaces
+func scanAvailableEmployees(s *Store, skill string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var ids []string
+	for _, emp := range s.employees {
+		if emp.IsAvailable && s.skillMatcher.Matches(emp.Skills, skill) {
+			ids = append(ids, emp.ID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// indexedAvailableEmployees returns the sorted IDs from the index-backed lookup
+func indexedAvailableEmployees(s *Store, skill string) []string {
+	var ids []string
+	for _, emp := range s.GetAvailableEmployees(skill) {
+		ids = append(ids, emp.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// TestSkillIndex tests that the index-backed lookup matches a full scan
+// through adds, skill changes, deletes, restores and snapshot restores
+func TestSkillIndex(t *testing.T) {
+	store := NewStore()
+	loc := Location{Lat: 60.1699, Lon: 24.9384}
+	skills := []string{"delivery", "welding", "forklift", "hazmat"}
+
+	check := func(step string) {
+		t.Helper()
+		for _, skill := range append(skills, "DELIVERY", "unknown") {
+			want := scanAvailableEmployees(store, skill)
+			if got := indexedAvailableEmployees(store, skill); fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("%s: %s index lookup = %v, scan = %v", step, skill, got, want)
+			}
+		}
+	}
+
+	for i := 0; i < 20; i++ {
+		store.AddEmployee(&Employee{
+			ID:          fmt.Sprintf("emp%02d", i),
+			Name:        "Courier",
+			Location:    loc,
+			Skills:      []string{skills[i%4], skills[(i+1)%4], skills[i%4]},
+			IsAvailable: i%5 != 0,
+		})
+	}
+	check("after add")
+
+	if _, err := store.AddOrUpdateEmployee(&Employee{ID: "emp01", Name: "Courier", Location: loc, Skills: []string{"hazmat"}, IsAvailable: true}); err != nil {
+		t.Fatalf("AddOrUpdateEmployee() unexpected error: %v", err)
+	}
+	check("after skill change")
+	if _, indexed := store.skillIndex["welding"]["emp01"]; indexed {
+		t.Error("emp01 still indexed under a dropped skill")
+	}
+
+	store.DeleteEmployee("emp02")
+	store.DeleteEmployee("emp03")
+	check("after delete")
+
+	store.RestoreEmployee("emp02")
+	check("after restore")
+
+	snap := store.Snapshot()
+	restored := NewStore()
+	if err := restored.Restore(snap); err != nil {
+		t.Fatalf("Restore() unexpected error: %v", err)
+	}
+	store = restored
+	check("after snapshot restore")
+
+	// Prefix and fuzzy modes can't use the index and fall back to a scan
+	store.SetSkillMatcher(SkillMatcher{Mode: SkillMatchPrefix})
+	skills = append(skills, "deliv")
+	check("prefix mode")
+}
+
+// newSparseSkillStore creates n available employees spread over n/perSkill skills
+func newSparseSkillStore(n, perSkill int) *Store {
+	store := NewStore()
+	for i := 0; i < n; i++ {
+		store.AddEmployee(&Employee{
+			ID:          fmt.Sprintf("emp-%d", i),
+			Name:        "Courier",
+			Location:    Location{Lat: 60.1700, Lon: 24.9400},
+			Skills:      []string{fmt.Sprintf("skill-%d", i/perSkill)},
+			IsAvailable: true,
+		})
+	}
+	return store
+}
+
+func BenchmarkAvailableEmployeesScan(b *testing.B) {
+	store := newSparseSkillStore(50000, 5)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		scanAvailableEmployees(store, "skill-42")
+	}
+}
+
+func BenchmarkAvailableEmployeesIndexed(b *testing.B) {
+	store := newSparseSkillStore(50000, 5)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		store.GetAvailableEmployees("skill-42")
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ParseSkillTimeouts parses comma-separated skill=duration pairs
+// (e.g. "hazmat=2m,crane_operation=90s"); skills are normalized
+func ParseSkillTimeouts(s string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration)
+	for _, pair := range strings.Split(s, ",") {
+		skill, value, ok := strings.Cut(pair, "=")
+		skill = normalizeSkill(skill)
+		if !ok || skill == "" {
+			return nil, fmt.Errorf("invalid skill timeout %q, want skill=duration", strings.TrimSpace(pair))
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout for skill %q: %w", skill, err)
+		}
+		timeouts[skill] = d
+	}
+	if err := validateSkillTimeouts(timeouts); err != nil {
+		return nil, err
+	}
+	return timeouts, nil
+}
+
+// validateSkillTimeouts checks that every per-skill timeout is positive
+func validateSkillTimeouts(timeouts map[string]time.Duration) error {
+	for skill, d := range timeouts {
+		if d <= 0 {
+			return fmt.Errorf("timeout for skill %q must be positive, got %v", skill, d)
+		}
+	}
+	return nil
+}
+
+// SetSkillTimeouts overrides the assignment timeout for tasks requiring the
+// mapped skills; other skills keep the pool's default
+// Must be called before the pool is used concurrently
+func (pool *AssignmentWorkerPool) SetSkillTimeouts(timeouts map[string]time.Duration) {
+	pool.skillTimeouts = make(map[string]time.Duration, len(timeouts))
+	for skill, d := range timeouts {
+		pool.skillTimeouts[normalizeSkill(skill)] = d
+	}
+}
+
+// timeoutFor returns the assignment timeout for the task's required skill
+func (pool *AssignmentWorkerPool) timeoutFor(task *Task) time.Duration {
+	if d, ok := pool.skillTimeouts[normalizeSkill(task.RequiredSkill)]; ok {
+		return d
+	}
+	return pool.timeout
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestSkillAssignmentTimeout tests that a mapped skill gets its own, longer
+// assignment window while unmapped skills keep the pool default
+func TestSkillAssignmentTimeout(t *testing.T) {
+	timeouts, err := ParseSkillTimeouts(" Hazmat = 5s ")
+	if err != nil {
+		t.Fatalf("ParseSkillTimeouts() unexpected error: %v", err)
+	}
+	for _, bad := range []string{"hazmat", "hazmat=soon", "hazmat=0s", "=5s"} {
+		if _, err := ParseSkillTimeouts(bad); err == nil {
+			t.Errorf("ParseSkillTimeouts(%q) expected an error", bad)
+		}
+	}
+
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+	assigner.SetMaxConcurrent(1)
+	pool := NewAssignmentWorkerPool(assigner, 1, 20*time.Millisecond)
+	pool.SetSkillTimeouts(timeouts)
+
+	loc := Location{Lat: 60.1700, Lon: 24.9400}
+	store.AddEmployee(&Employee{ID: "emp1", Name: "Alice", Location: loc, Skills: []string{"hazmat", "delivery"}, IsAvailable: true, Capacity: 2})
+	common := &Task{ID: "common", Location: loc, RequiredSkill: "delivery"}
+	rare := &Task{ID: "rare", Location: loc, RequiredSkill: "hazmat"}
+	store.AddTask(common)
+	store.AddTask(rare)
+
+	// With the only slot taken both tasks wait; only the default timeout expires meanwhile
+	assigner.slots <- struct{}{}
+	pool.process(context.Background(), "worker", common)
+	if status, _ := store.taskStatus("common"); status != TaskStatusFailed {
+		t.Errorf("Unmapped skill: status = %s, want %s after the default timeout", status, TaskStatusFailed)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pool.process(context.Background(), "worker", rare)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	if status, _ := store.taskStatus("rare"); status != TaskStatusPending {
+		t.Errorf("Mapped skill: status = %s, want still %s past the default timeout", status, TaskStatusPending)
+	}
+	<-assigner.slots
+	<-done
+	if status, _ := store.taskStatus("rare"); status != TaskStatusAssigned {
+		t.Errorf("Mapped skill: status = %s, want %s once a slot frees up", status, TaskStatusAssigned)
+	}
+}
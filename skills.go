@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"unicode"
+)
+
+// SkillMatchMode controls how a required skill is compared to an employee's skills
+type SkillMatchMode string
+
+const (
+	// SkillMatchExact requires identical normalized skills (the default)
+	SkillMatchExact SkillMatchMode = "exact"
+	// SkillMatchPrefix accepts an employee skill that is a prefix of the required skill,
+	// e.g. "delivery" satisfies "delivery_express"
+	SkillMatchPrefix SkillMatchMode = "prefix"
+	// SkillMatchFuzzy accepts skills within FuzzyThreshold edits (Levenshtein distance)
+	SkillMatchFuzzy SkillMatchMode = "fuzzy"
+)
+
+// defaultFuzzyThreshold is the edit distance used when none is configured
+const defaultFuzzyThreshold = 2
+
+// ParseSkillMatchMode validates a mode name (case-insensitive)
+func ParseSkillMatchMode(name string) (SkillMatchMode, error) {
+	switch mode := SkillMatchMode(strings.ToLower(strings.TrimSpace(name))); mode {
+	case SkillMatchExact, SkillMatchPrefix, SkillMatchFuzzy:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown skill match mode %q (available: exact, prefix, fuzzy)", name)
+	}
+}
+
+// SkillSeparator is the canonical separator between words of a skill
+type SkillSeparator string
+
+const (
+	SkillSeparatorUnderscore SkillSeparator = "_" // the default
+	SkillSeparatorHyphen     SkillSeparator = "-"
+	SkillSeparatorSpace      SkillSeparator = " "
+)
+
+// ParseSkillSeparator validates a separator, given as the character itself
+// or by name (underscore, hyphen, space)
+func ParseSkillSeparator(name string) (SkillSeparator, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "_", "underscore":
+		return SkillSeparatorUnderscore, nil
+	case "-", "hyphen":
+		return SkillSeparatorHyphen, nil
+	case "space":
+		return SkillSeparatorSpace, nil
+	}
+	if name == " " {
+		return SkillSeparatorSpace, nil
+	}
+	return "", fmt.Errorf("unknown skill separator %q (available: underscore, hyphen, space)", name)
+}
+
+// skillSeparator holds the SkillSeparator normalizeSkill collapses to
+var skillSeparator atomic.Value
+
+// SetSkillSeparator changes the canonical skill separator for the process
+// Skills normalized before the change keep their old form, so call it
+// before any employees or tasks are added
+func SetSkillSeparator(sep SkillSeparator) {
+	skillSeparator.Store(sep)
+}
+
+// currentSkillSeparator returns the configured separator, underscore by default
+func currentSkillSeparator() SkillSeparator {
+	if sep, ok := skillSeparator.Load().(SkillSeparator); ok {
+		return sep
+	}
+	return SkillSeparatorUnderscore
+}
+
+// isSkillSeparator reports whether r is one of the interchangeable separators
+func isSkillSeparator(r rune) bool {
+	return r == '_' || r == '-' || unicode.IsSpace(r)
+}
+
+// collapseSkillSeparators replaces each run of whitespace, underscores and
+// hyphens with the canonical separator; the words themselves are untouched,
+// so "icecream" and "ice_cream" stay distinct
+func collapseSkillSeparators(skill string) string {
+	if strings.IndexFunc(skill, isSkillSeparator) < 0 {
+		return skill
+	}
+	sep := string(currentSkillSeparator())
+	var b strings.Builder
+	b.Grow(len(skill))
+	inRun := false
+	for _, r := range skill {
+		if isSkillSeparator(r) {
+			if !inRun {
+				b.WriteString(sep)
+			}
+			inRun = true
+			continue
+		}
+		inRun = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// SkillMatcher decides whether an employee's skills satisfy a required skill
+// The zero value matches exactly
+type SkillMatcher struct {
+	Mode           SkillMatchMode
+	FuzzyThreshold int // max edit distance in fuzzy mode; 0 uses the default
+}
+
+// Matches reports whether any of the (pre-normalized) skills satisfies required
+func (m SkillMatcher) Matches(skills []string, required string) bool {
+	switch m.Mode {
+	case SkillMatchPrefix:
+		requiredNorm := normalizeSkill(required)
+		for _, skill := range skills {
+			if skill != "" && strings.HasPrefix(requiredNorm, skill) {
+				return true
+			}
+		}
+		return false
+	case SkillMatchFuzzy:
+		threshold := m.FuzzyThreshold
+		if threshold <= 0 {
+			threshold = defaultFuzzyThreshold
+		}
+		requiredNorm := normalizeSkill(required)
+		for _, skill := range skills {
+			if levenshtein(skill, requiredNorm) <= threshold {
+				return true
+			}
+		}
+		return false
+	default:
+		return hasSkill(skills, required)
+	}
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
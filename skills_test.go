@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+)
+
+// TestSkillMatchModes tests which employees match a required skill in each mode
+func TestSkillMatchModes(t *testing.T) {
+	employees := []*Employee{
+		{ID: "exact", Skills: []string{"delivery_express"}, IsAvailable: true},
+		{ID: "prefix", Skills: []string{"delivery"}, IsAvailable: true},
+		{ID: "typo", Skills: []string{"delivary_expres"}, IsAvailable: true},
+		{ID: "other", Skills: []string{"cooking"}, IsAvailable: true},
+	}
+
+	tests := []struct {
+		name    string
+		matcher SkillMatcher
+		want    []string
+	}{
+		{"Exact (default)", SkillMatcher{}, []string{"exact"}},
+		{"Prefix", SkillMatcher{Mode: SkillMatchPrefix}, []string{"exact", "prefix"}},
+		{"Fuzzy", SkillMatcher{Mode: SkillMatchFuzzy}, []string{"exact", "typo"}},
+		{"Fuzzy wide threshold", SkillMatcher{Mode: SkillMatchFuzzy, FuzzyThreshold: 8}, []string{"exact", "prefix", "typo"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewStore()
+			store.SetSkillMatcher(tt.matcher)
+			for _, emp := range employees {
+				e := *emp
+				store.AddEmployee(&e)
+			}
+
+			var got []string
+			for _, emp := range store.GetAvailableEmployees("Delivery_Express") {
+				got = append(got, emp.ID)
+			}
+			sort.Strings(got)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("GetAvailableEmployees() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("GetAvailableEmployees() = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+// TestSkillMatchModeAppliesToAssignment tests that assignment eligibility uses the same mode
+func TestSkillMatchModeAppliesToAssignment(t *testing.T) {
+	store := NewStore()
+	store.SetSkillMatcher(SkillMatcher{Mode: SkillMatchPrefix})
+	assigner := NewTaskAssigner(store)
+
+	store.AddEmployee(&Employee{ID: "emp1", Location: Location{Lat: 60.1699, Lon: 24.9384}, Skills: []string{"delivery"}, IsAvailable: true})
+	task := &Task{ID: "task1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery_express"}
+	store.AddTask(task)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := assigner.AssignTask(ctx, task)
+	if err != nil {
+		t.Fatalf("AssignTask() unexpected error: %v", err)
+	}
+	if result.EmployeeID != "emp1" {
+		t.Errorf("AssignTask() assigned to %s, want emp1", result.EmployeeID)
+	}
+}
+
+// TestParseSkillMatchMode tests mode name validation
+func TestParseSkillMatchMode(t *testing.T) {
+	if mode, err := ParseSkillMatchMode(" Fuzzy "); err != nil || mode != SkillMatchFuzzy {
+		t.Errorf("ParseSkillMatchMode() = %v, %v, want fuzzy", mode, err)
+	}
+	if _, err := ParseSkillMatchMode("soundex"); err == nil {
+		t.Error("Expected error for unknown mode")
+	}
+}
+
+// TestLevenshtein tests the edit distance helper
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"delivery", "delivery", 0},
+		{"delivery", "delivary", 1},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+// TestSkillSeparatorNormalization tests that whitespace, underscore and hyphen
+// variants of a skill match each other without merging distinct skills
+func TestSkillSeparatorNormalization(t *testing.T) {
+	for _, variant := range []string{"ice cream", "ice_cream", "ice-cream", " Ice  -_ Cream ", "ice\tcream"} {
+		if got := normalizeSkill(variant); got != "ice_cream" {
+			t.Errorf("normalizeSkill(%q) = %q, want ice_cream", variant, got)
+		}
+	}
+
+	store := NewStore()
+	emp := &Employee{ID: "emp1", Name: "Alice", Location: Location{Lat: 60.17, Lon: 24.94}, Skills: []string{"Ice Cream", "ice-cream"}, IsAvailable: true}
+	if err := emp.Validate(); err != nil {
+		t.Fatalf("Validation failed: %v", err)
+	}
+	if len(emp.Skills) != 1 || emp.Skills[0] != "ice_cream" {
+		t.Errorf("Expected the variants deduplicated to ice_cream, got %v", emp.Skills)
+	}
+	store.AddEmployee(emp)
+	for _, skill := range []string{"ice cream", "ice_cream", "ice-cream"} {
+		if len(store.GetAvailableEmployees(skill)) != 1 {
+			t.Errorf("Expected employee to match %q", skill)
+		}
+	}
+	// Only separators are canonicalized, never added or removed
+	for _, distinct := range []string{"icecream", "ice", "ice_creams", "ice_cream_cone", "_ice_cream"} {
+		if len(store.GetAvailableEmployees(distinct)) != 0 {
+			t.Errorf("Expected %q to stay distinct from ice_cream", distinct)
+		}
+	}
+
+	SetSkillSeparator(SkillSeparatorHyphen)
+	defer SetSkillSeparator(SkillSeparatorUnderscore)
+	if got := normalizeSkill("ice_cream"); got != "ice-cream" {
+		t.Errorf("With a hyphen separator normalizeSkill(ice_cream) = %q, want ice-cream", got)
+	}
+
+	for name, want := range map[string]SkillSeparator{"_": SkillSeparatorUnderscore, "HYPHEN": SkillSeparatorHyphen, "space": SkillSeparatorSpace, " ": SkillSeparatorSpace} {
+		if got, err := ParseSkillSeparator(name); err != nil || got != want {
+			t.Errorf("ParseSkillSeparator(%q) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+	if _, err := ParseSkillSeparator("."); err == nil {
+		t.Error("Expected an error for an unsupported separator")
+	}
+}
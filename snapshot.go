@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// SnapshotVersion is bumped whenever the StoreSnapshot layout changes
+const SnapshotVersion = 1
+
+// StoreSnapshot is a point-in-time copy of the store's state
+type StoreSnapshot struct {
+	Version         int                         `json:"version"`
+	TakenAt         time.Time                   `json:"taken_at"`
+	Employees       []Employee                  `json:"employees"`
+	Tasks           []Task                      `json:"tasks"`
+	LocationHistory map[string][]LocationRecord `json:"location_history"`
+}
+
+// Snapshot copies the store's employees, tasks and location trails
+func (s *Store) Snapshot() StoreSnapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snap := StoreSnapshot{
+		Version:         SnapshotVersion,
+		TakenAt:         s.clock.Now().UTC(),
+		Employees:       make([]Employee, 0, len(s.employees)+len(s.deletedEmployees)),
+		Tasks:           make([]Task, 0, len(s.tasks)),
+		LocationHistory: make(map[string][]LocationRecord, len(s.locationHistory)),
+	}
+	// Soft-deleted employees are kept, marked by DeletedAt
+	for _, employees := range []map[string]*Employee{s.employees, s.deletedEmployees} {
+		for _, emp := range employees {
+			copied := *emp
+			copied.Skills = append([]string(nil), emp.Skills...)
+			snap.Employees = append(snap.Employees, copied)
+		}
+	}
+	for _, task := range s.tasks {
+		snap.Tasks = append(snap.Tasks, task.snapshot())
+	}
+	for id, history := range s.locationHistory {
+		snap.LocationHistory[id] = append([]LocationRecord(nil), history...)
+	}
+
+	// Stable order keeps snapshot files diffable
+	sort.Slice(snap.Employees, func(i, j int) bool { return snap.Employees[i].ID < snap.Employees[j].ID })
+	sort.Slice(snap.Tasks, func(i, j int) bool { return snap.Tasks[i].ID < snap.Tasks[j].ID })
+	return snap
+}
+
+// Restore replaces the store's state with the snapshot
+// Anyone waiting on a task is woken so they re-read the restored state
+func (s *Store) Restore(snap StoreSnapshot) error {
+	if snap.Version != SnapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d (want %d)", snap.Version, SnapshotVersion)
+	}
+
+	employees := make(map[string]*Employee, len(snap.Employees))
+	deleted := make(map[string]*Employee)
+	for i := range snap.Employees {
+		emp := snap.Employees[i]
+		if emp.DeletedAt != nil {
+			deleted[emp.ID] = &emp
+			continue
+		}
+		employees[emp.ID] = &emp
+	}
+	tasks := make(map[string]*Task, len(snap.Tasks))
+	for i := range snap.Tasks {
+		task := snap.Tasks[i]
+		tasks[task.ID] = &task
+	}
+	history := make(map[string][]LocationRecord, len(snap.LocationHistory))
+	for id, records := range snap.LocationHistory {
+		history[id] = append([]LocationRecord(nil), records...)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.employees = employees
+	s.deletedEmployees = deleted
+	s.rebuildSkillIndexLocked()
+	s.tasks = tasks
+	s.locationHistory = history
+	for id := range s.taskWaiters {
+		s.wakeTaskWaitersLocked(id)
+	}
+	return nil
+}
+
+// WriteSnapshot writes the snapshot as JSON to path and returns its size
+// The file is written to a temporary name first so a crash never leaves a
+// truncated snapshot behind
+func WriteSnapshot(path string, snap StoreSnapshot) (int64, error) {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return 0, err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return 0, err
+	}
+	return int64(len(data)), nil
+}
+
+// ReadSnapshot loads a snapshot written by WriteSnapshot
+func ReadSnapshot(path string) (StoreSnapshot, error) {
+	var snap StoreSnapshot
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return snap, err
+	}
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return snap, fmt.Errorf("invalid snapshot %s: %w", filepath.Base(path), err)
+	}
+	return snap, nil
+}
+
+// WhileIdle runs fn only if no task is queued or being processed, holding off
+// new submissions until fn returns; otherwise it returns ErrPoolBusy
+func (pool *AssignmentWorkerPool) WhileIdle(fn func() error) error {
+	pool.queuedMu.Lock()
+	defer pool.queuedMu.Unlock()
+
+	if len(pool.queued) > 0 {
+		return ErrPoolBusy
+	}
+	return fn()
+}
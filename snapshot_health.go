@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Snapshot health statuses reported by GET /health/snapshot
+const (
+	SnapshotHealthy  = "healthy"
+	SnapshotDegraded = "degraded"
+	SnapshotDisabled = "disabled"
+)
+
+// snapshotTracker remembers the outcome of the most recent snapshot attempts
+type snapshotTracker struct {
+	mu          sync.Mutex
+	lastSuccess time.Time
+	lastError   string
+}
+
+// recordSuccess notes a snapshot written at the given time
+func (t *snapshotTracker) recordSuccess(at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lastSuccess = at
+	t.lastError = ""
+}
+
+// recordFailure notes a failed attempt; the last success is kept
+func (t *snapshotTracker) recordFailure(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lastError = err.Error()
+}
+
+// SnapshotHealth is the payload for GET /health/snapshot
+type SnapshotHealth struct {
+	Status         string     `json:"status"`
+	LastSnapshotAt *time.Time `json:"last_snapshot_at"`
+	AgeSeconds     float64    `json:"age_seconds,omitempty"`
+	MaxAgeSeconds  float64    `json:"max_age_seconds,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+}
+
+// health reports how fresh the last snapshot is at now
+// Before the first success the age is measured from since (process start),
+// so a freshly started instance is not immediately degraded; maxAge <= 0
+// disables the freshness check
+func (t *snapshotTracker) health(now, since time.Time, maxAge time.Duration) SnapshotHealth {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h := SnapshotHealth{Status: SnapshotHealthy, LastError: t.lastError}
+	if !t.lastSuccess.IsZero() {
+		last := t.lastSuccess
+		h.LastSnapshotAt = &last
+		since = last
+	}
+	if age := now.Sub(since); age > 0 {
+		h.AgeSeconds = age.Seconds()
+	}
+	if maxAge > 0 {
+		h.MaxAgeSeconds = maxAge.Seconds()
+		if now.Sub(since) > maxAge {
+			h.Status = SnapshotDegraded
+		}
+	}
+	return h
+}
+
+// Snapshotter takes a snapshot every interval
+type Snapshotter struct {
+	interval time.Duration
+	take     func() (SnapshotInfo, error)
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewSnapshotter creates a snapshotter calling take every interval
+func NewSnapshotter(interval time.Duration, take func() (SnapshotInfo, error)) *Snapshotter {
+	return &Snapshotter{interval: interval, take: take}
+}
+
+// Start starts the snapshot loop
+func (s *Snapshotter) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if info, err := s.take(); err != nil {
+					log.Printf("Periodic snapshot failed: %v", err)
+				} else {
+					log.Printf("Periodic snapshot written to %s", info.Path)
+				}
+			}
+		}
+	}()
+}
+
+// Shutdown stops the snapshot loop and waits for it to exit
+func (s *Snapshotter) Shutdown() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestSnapshotRestoreHandlers tests a snapshot round trip through the admin endpoints
+func TestSnapshotRestoreHandlers(t *testing.T) {
+	dir := t.TempDir()
+	api := setupTestAPI()
+	api.snapshotDir = dir
+	router := api.setupRouter()
+
+	post := func(router http.Handler, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	api.store.AddEmployee(&Employee{
+		ID:          "emp1",
+		Name:        "John",
+		Location:    Location{Lat: 60.1699, Lon: 24.9384},
+		Skills:      []string{"delivery"},
+		IsAvailable: true,
+		Capacity:    2,
+		TeamID:      "north",
+	})
+	api.store.UpdateEmployeeLocation("emp1", Location{Lat: 60.1710, Lon: 24.9410})
+	api.store.ReserveEmployee("emp1", time.Hour)
+	api.store.AddTask(&Task{ID: "task1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery", Priority: PriorityHigh})
+	api.store.AddTask(&Task{ID: "task2", Location: Location{Lat: 60.1800, Lon: 24.9500}, RequiredSkill: "repair"})
+	api.store.FailTask("task2", "address invalid")
+	before := api.store.Snapshot()
+
+	w := post(router, "/admin/snapshot", "")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected snapshot status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created struct {
+		Data SnapshotInfo `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &created)
+	if info, err := os.Stat(created.Data.Path); err != nil || info.Size() != created.Data.SizeBytes {
+		t.Fatalf("Expected snapshot file of %d bytes at %s, got %v", created.Data.SizeBytes, created.Data.Path, err)
+	}
+	if created.Data.Employees != 1 || created.Data.Tasks != 2 {
+		t.Errorf("Expected 1 employee and 2 tasks in snapshot, got %+v", created.Data)
+	}
+
+	// Restore into a fresh instance and compare state
+	fresh := setupTestAPI()
+	fresh.snapshotDir = dir
+	freshRouter := fresh.setupRouter()
+	if w := post(freshRouter, "/admin/restore", `{"name": "`+created.Data.Name+`"}`); w.Code != http.StatusOK {
+		t.Fatalf("Expected restore status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	after := fresh.store.Snapshot()
+	after.TakenAt = before.TakenAt
+	if !reflect.DeepEqual(before, after) {
+		t.Errorf("Restored state differs:\nbefore %+v\nafter  %+v", before, after)
+	}
+
+	// Restore is refused while a task is queued
+	fresh.store.AddTask(&Task{ID: "task3", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"})
+	fresh.workerPool.SubmitTask(&Task{ID: "task3"})
+	if w := post(freshRouter, "/admin/restore", `{"name": "`+created.Data.Name+`"}`); w.Code != http.StatusConflict {
+		t.Errorf("Expected restore status 409 with a busy pool, got %d", w.Code)
+	}
+
+	for _, name := range []string{"../etc/passwd", ".hidden"} {
+		if w := post(router, "/admin/restore", `{"name": "`+name+`"}`); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", name, w.Code)
+		}
+	}
+	if w := post(router, "/admin/restore", `{"name": "missing.json"}`); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for missing snapshot, got %d", w.Code)
+	}
+
+	disabled := setupTestAPI().setupRouter()
+	if w := post(disabled, "/admin/snapshot", ""); w.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403 without SNAPSHOT_DIR, got %d", w.Code)
+	}
+}
+
+// TestRestoreResubmitsPending tests that pending tasks from a snapshot are
+// queued again on restore and get assigned without a reprocess call
+func TestRestoreResubmitsPending(t *testing.T) {
+	dir := t.TempDir()
+	source := setupTestAPI()
+	source.snapshotDir = dir
+	loc := Location{Lat: 60.1699, Lon: 24.9384}
+	source.store.AddEmployee(&Employee{ID: "emp1", Name: "John", Location: loc, Skills: []string{"delivery"}, IsAvailable: true})
+	source.store.AddTask(&Task{ID: "task1", Location: loc, RequiredSkill: "delivery"})
+
+	w := httptest.NewRecorder()
+	source.setupRouter().ServeHTTP(w, httptest.NewRequest("POST", "/admin/snapshot", nil))
+	var created struct {
+		Data SnapshotInfo `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &created)
+
+	api := setupTestAPI()
+	api.snapshotDir = dir
+	if err := api.workerPool.Start(context.Background()); err != nil {
+		t.Fatalf("Start() unexpected error: %v", err)
+	}
+	defer api.workerPool.Shutdown()
+
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/admin/restore", bytes.NewBufferString(`{"name": "`+created.Data.Name+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	api.setupRouter().ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected restore status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var restored struct {
+		Data SnapshotInfo `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &restored)
+	if restored.Data.Resubmitted != 1 {
+		t.Errorf("Expected 1 resubmitted task, got %+v", restored.Data)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	task, _, err := api.store.WaitForTerminal(ctx, "task1")
+	if err != nil || task.Status != TaskStatusAssigned || task.AssignedEmployeeID != "emp1" {
+		t.Errorf("Expected task1 assigned to emp1 after restore, got %+v (%v)", task, err)
+	}
+}
+
+// TestSnapshotHealthStale tests that /health/snapshot degrades once the last snapshot is too old
+func TestSnapshotHealthStale(t *testing.T) {
+	api := setupTestAPI()
+	api.snapshotDir = t.TempDir()
+	api.snapshotMaxAge = time.Minute
+	clock := newFakeClock()
+	api.store.SetClock(clock)
+	router := api.setupRouter()
+
+	getHealth := func() (int, SnapshotHealth) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/health/snapshot", nil))
+		var health SnapshotHealth
+		json.Unmarshal(w.Body.Bytes(), &health)
+		return w.Code, health
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/admin/snapshot", nil))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected snapshot status 201, got %d", w.Code)
+	}
+
+	clock.Advance(30 * time.Second)
+	code, health := getHealth()
+	if code != http.StatusOK || health.Status != SnapshotHealthy {
+		t.Fatalf("Expected healthy 200 for a 30s old snapshot, got %d %+v", code, health)
+	}
+	if health.LastSnapshotAt == nil || !health.LastSnapshotAt.Equal(clock.Now().Add(-30*time.Second)) {
+		t.Errorf("Expected last_snapshot_at 30s ago, got %v", health.LastSnapshotAt)
+	}
+
+	clock.Advance(time.Minute)
+	code, health = getHealth()
+	if code != http.StatusServiceUnavailable || health.Status != SnapshotDegraded {
+		t.Errorf("Expected degraded 503 for a 90s old snapshot, got %d %+v", code, health)
+	}
+	if health.AgeSeconds != 90 {
+		t.Errorf("Expected age_seconds 90, got %v", health.AgeSeconds)
+	}
+}
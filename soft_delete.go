@@ -0,0 +1,66 @@
+package main
+
+import "sort"
+
+// DeleteEmployee soft-deletes an employee: it disappears from every query
+// and assignment scan but keeps its ID and location trail until purged
+// Employees still holding tasks must finish or fail them first
+func (s *Store) DeleteEmployee(id string) (Employee, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	emp, exists := s.employees[id]
+	if !exists {
+		return Employee{}, ErrEmployeeNotFound
+	}
+	if emp.ActiveTasks > 0 {
+		return Employee{}, ErrEmployeeHasActiveTasks
+	}
+	deletedAt := s.clock.Now().UTC()
+	emp.DeletedAt = &deletedAt
+	delete(s.employees, id)
+	s.unindexEmployeeLocked(emp)
+	s.deletedEmployees[id] = emp
+	return *emp, nil
+}
+
+// RestoreEmployee brings a soft-deleted employee back
+// Returns ErrEmployeeNotDeleted if the employee exists but was never deleted,
+// and ErrEmployeeLimitReached if the store is already full
+func (s *Store) RestoreEmployee(id string) (Employee, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	emp, exists := s.deletedEmployees[id]
+	if !exists {
+		if _, active := s.employees[id]; active {
+			return Employee{}, ErrEmployeeNotDeleted
+		}
+		return Employee{}, ErrEmployeeNotFound
+	}
+	// Deleted employees don't count toward the cap, so restoring one might exceed it
+	if s.atEmployeeLimitLocked() {
+		return Employee{}, ErrEmployeeLimitReached
+	}
+	emp.DeletedAt = nil
+	delete(s.deletedEmployees, id)
+	s.employees[id] = emp
+	s.indexEmployeeLocked(emp)
+	return *emp, nil
+}
+
+// PurgeDeletedEmployees permanently removes every soft-deleted employee and
+// its location trail, returning the purged IDs in order
+func (s *Store) PurgeDeletedEmployees() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	purged := make([]string, 0, len(s.deletedEmployees))
+	for id := range s.deletedEmployees {
+		delete(s.deletedEmployees, id)
+		delete(s.locationHistory, id)
+		purged = append(purged, id)
+	}
+	sort.Strings(purged)
+	return purged
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestDeleteAndRestoreEmployee tests the soft-delete, restore and purge lifecycle over HTTP
+func TestDeleteAndRestoreEmployee(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	api.store.AddEmployee(&Employee{ID: "emp1", Name: "Alice", Location: Location{Lat: 60.1699, Lon: 24.9384}, Skills: []string{"delivery"}, IsAvailable: true})
+
+	do := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return w
+	}
+	listed := func() int {
+		var response struct {
+			Data []Employee `json:"data"`
+		}
+		json.Unmarshal(do("GET", "/employees").Body.Bytes(), &response)
+		return len(response.Data)
+	}
+
+	if w := do("POST", "/employees/emp1/restore"); w.Code != http.StatusConflict {
+		t.Errorf("Expected 409 restoring a live employee, got %d", w.Code)
+	}
+
+	w := do("DELETE", "/employees/emp1")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var deleted struct {
+		Data Employee `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &deleted)
+	if deleted.Data.DeletedAt == nil {
+		t.Error("Expected deleted_at on the deleted employee")
+	}
+	if n := listed(); n != 0 {
+		t.Errorf("Expected deleted employee to be hidden, got %d employees", n)
+	}
+	if n := len(api.store.GetAvailableEmployees("delivery")); n != 0 {
+		t.Errorf("Expected no available employees, got %d", n)
+	}
+	if w := do("DELETE", "/employees/emp1"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 deleting twice, got %d", w.Code)
+	}
+	if err := api.store.AddEmployee(&Employee{ID: "emp1", Name: "Impostor"}); !errors.Is(err, ErrDuplicateEmployee) {
+		t.Errorf("Expected a deleted employee to keep its ID, got %v", err)
+	}
+
+	if w := do("POST", "/employees/emp1/restore"); w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if n := listed(); n != 1 {
+		t.Errorf("Expected restored employee to be listed, got %d employees", n)
+	}
+	if emp, _ := api.store.GetEmployee("emp1"); emp.DeletedAt != nil {
+		t.Error("Expected deleted_at to be cleared on restore")
+	}
+
+	do("DELETE", "/employees/emp1")
+	if w := do("POST", "/admin/employees/purge"); w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if w := do("POST", "/employees/emp1/restore"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 restoring a purged employee, got %d", w.Code)
+	}
+}
+
+// TestSoftDeletedEmployeeNotAssigned tests that assignment skips soft-deleted
+// employees and that busy employees cannot be deleted
+func TestSoftDeletedEmployeeNotAssigned(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+
+	store.AddEmployee(&Employee{ID: "near", Name: "Near", Location: Location{Lat: 60.1699, Lon: 24.9384}, Skills: []string{"delivery"}, IsAvailable: true})
+	store.AddEmployee(&Employee{ID: "far", Name: "Far", Location: Location{Lat: 60.2055, Lon: 24.6559}, Skills: []string{"delivery"}, IsAvailable: true})
+	if _, err := store.DeleteEmployee("near"); err != nil {
+		t.Fatalf("DeleteEmployee() unexpected error: %v", err)
+	}
+
+	task := &Task{ID: "task1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"}
+	store.AddTask(task)
+	result, err := assigner.AssignTask(context.Background(), task)
+	if err != nil {
+		t.Fatalf("AssignTask() unexpected error: %v", err)
+	}
+	if result.EmployeeID != "far" {
+		t.Errorf("Expected far to be assigned, got %s", result.EmployeeID)
+	}
+
+	if _, err := store.DeleteEmployee("far"); !errors.Is(err, ErrEmployeeHasActiveTasks) {
+		t.Errorf("Expected ErrEmployeeHasActiveTasks, got %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// spilloverIdlePoll is how often the spillover worker re-checks whether the
+// primary queue has drained
+const spilloverIdlePoll = 10 * time.Millisecond
+
+// SetSpillover adds a bounded secondary queue that SubmitTask falls back to when
+// the primary queue is full; capacity <= 0 disables it
+// A single dedicated worker drains it, and only while the primary queue is empty,
+// so overflow work runs at a lower priority than anything in the primary queue
+// Must be called before Start
+func (pool *AssignmentWorkerPool) SetSpillover(capacity int) {
+	if capacity <= 0 {
+		pool.spillover = nil
+		return
+	}
+	pool.spillover = NewTaskQueue(capacity, pool.taskQueue.agingInterval, pool.assigner.clock)
+	pool.spillover.SetFIFO(pool.strictFIFO)
+}
+
+// spilloverWorker processes spillover tasks whenever the primary queue is idle
+// It exits once the spillover queue is closed and drained
+func (pool *AssignmentWorkerPool) spilloverWorker() {
+	defer pool.wg.Done()
+
+	for range pool.spillover.Ready() {
+		// Yield to the primary queue; its workers keep it drained under normal load
+		for pool.taskQueue.Len() > 0 {
+			time.Sleep(spilloverIdlePoll)
+		}
+
+		pool.popAndProcess(pool.ctx, "Spillover worker", pool.spillover)
+	}
+	fmt.Println("Spillover worker: Queue closed, exiting")
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestSpilloverQueue tests that overflow tasks are assigned via the spillover queue
+func TestSpilloverQueue(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+	pool := NewAssignmentWorkerPool(assigner, 1, 5*time.Second)
+	pool.taskQueue = NewTaskQueue(2, DefaultQueueAgingInterval, assigner.clock)
+	pool.SetSpillover(2)
+
+	store.AddEmployee(&Employee{
+		ID:          "emp1",
+		Name:        "John",
+		Location:    Location{Lat: 60.1699, Lon: 24.9384},
+		Skills:      []string{"delivery"},
+		IsAvailable: true,
+		Capacity:    10,
+	})
+
+	// Fill both queues before any worker runs
+	var tasks []*Task
+	for i := 0; i < 5; i++ {
+		task := &Task{ID: fmt.Sprintf("task%d", i), Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"}
+		store.AddTask(task)
+		tasks = append(tasks, task)
+	}
+	for _, task := range tasks[:4] {
+		if err := pool.SubmitTask(task); err != nil {
+			t.Fatalf("SubmitTask(%s) unexpected error: %v", task.ID, err)
+		}
+	}
+	if got := pool.spillover.Len(); got != 2 {
+		t.Fatalf("Expected 2 tasks in spillover, got %d", got)
+	}
+	var taskErr *TaskError
+	if err := pool.SubmitTask(tasks[4]); !errors.As(err, &taskErr) || taskErr.Code != "QUEUE_FULL" {
+		t.Fatalf("Expected QUEUE_FULL with both queues full, got %v", err)
+	}
+
+	pool.Start(context.Background())
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if !pool.IsQueued("task2") && !pool.IsQueued("task3") {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	pool.Shutdown()
+
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	for _, task := range tasks[:4] {
+		if got := store.tasks[task.ID].Status; got != TaskStatusAssigned {
+			t.Errorf("Expected %s assigned, got %s", task.ID, got)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Candidate is an eligible employee considered for a task
+type Candidate struct {
+	EmployeeID  string
+	Location    Location
+	Distance    float64 // km from the task
+	ActiveTasks int
+	Tier        int
+	MaxRangeKm  float64 // the employee's own range (0 = unlimited)
+	Rating      float64
+}
+
+// AssignmentStrategy chooses which eligible employee gets a task
+// Candidates have already passed the skill, availability and radius filters
+type AssignmentStrategy interface {
+	// Name returns the registry name of the strategy
+	Name() string
+	// Select returns the index of the chosen candidate; candidates is never empty
+	Select(task *Task, candidates []Candidate) int
+}
+
+// NearestStrategy picks the closest employee (the default)
+// With DistanceBandKm set, every candidate inside the band counts as equally close:
+// in-band candidates beat out-of-band ones and are tie-broken by load, then ID
+type NearestStrategy struct {
+	DistanceBandKm float64
+}
+
+func (NearestStrategy) Name() string { return "nearest" }
+
+func (s NearestStrategy) Select(task *Task, candidates []Candidate) int {
+	best := 0
+	for i, c := range candidates {
+		if s.less(c, candidates[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// less reports whether candidate a ranks ahead of b
+func (s NearestStrategy) less(a, b Candidate) bool {
+	aInBand := s.DistanceBandKm > 0 && a.Distance <= s.DistanceBandKm
+	bInBand := s.DistanceBandKm > 0 && b.Distance <= s.DistanceBandKm
+	if aInBand && bInBand {
+		if a.ActiveTasks != b.ActiveTasks {
+			return a.ActiveTasks < b.ActiveTasks
+		}
+		return a.EmployeeID < b.EmployeeID
+	}
+	if aInBand != bInBand {
+		return aInBand
+	}
+	return a.Distance < b.Distance
+}
+
+// ReverseDistanceStrategy picks the farthest employee within the task radius
+// Useful for load spreading: nearby workers stay free for urgent local jobs
+type ReverseDistanceStrategy struct{}
+
+func (ReverseDistanceStrategy) Name() string { return "reverse_distance" }
+
+func (ReverseDistanceStrategy) Select(task *Task, candidates []Candidate) int {
+	best := 0
+	for i, c := range candidates {
+		if c.Distance > candidates[best].Distance {
+			best = i
+		}
+	}
+	return best
+}
+
+// PriorityAwareStrategy restricts important tasks to senior employees
+// Tasks whose priority appears in MinTiers only go to candidates at or above that tier;
+// if nobody qualifies it falls back to all candidates. Base picks within the chosen set
+type PriorityAwareStrategy struct {
+	Base     AssignmentStrategy
+	MinTiers map[int]int // task priority -> minimum employee tier
+}
+
+// NewPriorityAwareStrategy creates the default policy: urgent needs tier 2, high needs tier 1
+func NewPriorityAwareStrategy(base AssignmentStrategy) PriorityAwareStrategy {
+	return PriorityAwareStrategy{
+		Base: base,
+		MinTiers: map[int]int{
+			PriorityHigh:   1,
+			PriorityUrgent: 2,
+		},
+	}
+}
+
+func (PriorityAwareStrategy) Name() string { return "priority_aware" }
+
+func (s PriorityAwareStrategy) Select(task *Task, candidates []Candidate) int {
+	minTier, restricted := s.MinTiers[task.Priority]
+	if !restricted {
+		return s.Base.Select(task, candidates)
+	}
+
+	var senior []Candidate
+	var index []int // position of each senior candidate in candidates
+	for i, c := range candidates {
+		if c.Tier >= minTier {
+			senior = append(senior, c)
+			index = append(index, i)
+		}
+	}
+	if len(senior) == 0 {
+		// Nobody senior enough is available, fall back to lower tiers
+		return s.Base.Select(task, candidates)
+	}
+	return index[s.Base.Select(task, senior)]
+}
+
+// DefaultSoftmaxTemperatureKm is the default softmax temperature: each extra
+// kilometer makes a candidate e times less likely to be picked
+const DefaultSoftmaxTemperatureKm = 1.0
+
+// SoftmaxStrategy picks a candidate at random with probability proportional
+// to exp(-distance / TemperatureKm), a softmax over negative distance
+// The nearest employee is the most likely pick but not a certainty, which
+// spreads load away from centrally located couriers; larger temperatures
+// spread it further. Safe for concurrent use
+type SoftmaxStrategy struct {
+	TemperatureKm float64
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// NewSoftmaxStrategy creates a softmax strategy; a fixed seed gives a
+// reproducible sequence of picks for the same candidates
+func NewSoftmaxStrategy(temperatureKm float64, seed int64) *SoftmaxStrategy {
+	if temperatureKm <= 0 {
+		temperatureKm = DefaultSoftmaxTemperatureKm
+	}
+	return &SoftmaxStrategy{TemperatureKm: temperatureKm, rng: rand.New(rand.NewSource(seed))}
+}
+
+func (*SoftmaxStrategy) Name() string { return "softmax" }
+
+func (s *SoftmaxStrategy) Select(task *Task, candidates []Candidate) int {
+	// Sample over a canonical order so a seed is reproducible regardless of input order
+	order := make([]int, len(candidates))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := candidates[order[i]], candidates[order[j]]
+		if a.Distance != b.Distance {
+			return a.Distance < b.Distance
+		}
+		return a.EmployeeID < b.EmployeeID
+	})
+
+	// Shift by the nearest distance so the largest weight is exactly 1
+	nearest := candidates[order[0]].Distance
+	weights := make([]float64, len(order))
+	total := 0.0
+	for i, idx := range order {
+		weights[i] = math.Exp(-(candidates[idx].Distance - nearest) / s.TemperatureKm)
+		total += weights[i]
+	}
+
+	s.mu.Lock()
+	r := s.rng.Float64() * total
+	s.mu.Unlock()
+
+	for i, w := range weights {
+		if r < w {
+			return order[i]
+		}
+		r -= w
+	}
+	return order[len(order)-1]
+}
+
+// MaxRating is the highest employee rating
+const MaxRating = 5
+
+// DefaultTopRatedK is how many of the best-rated candidates top_rated considers by default
+const DefaultTopRatedK = 3
+
+// TopRatedStrategy puts quality first, then proximity: it keeps the K
+// highest-rated candidates (ties by ID) and picks the nearest of those
+// K <= 0 uses DefaultTopRatedK
+type TopRatedStrategy struct {
+	K int
+}
+
+func (TopRatedStrategy) Name() string { return "top_rated" }
+
+func (s TopRatedStrategy) Select(task *Task, candidates []Candidate) int {
+	k := s.K
+	if k <= 0 {
+		k = DefaultTopRatedK
+	}
+
+	order := make([]int, len(candidates))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := candidates[order[i]], candidates[order[j]]
+		if a.Rating != b.Rating {
+			return a.Rating > b.Rating
+		}
+		return a.EmployeeID < b.EmployeeID
+	})
+	if len(order) > k {
+		order = order[:k]
+	}
+
+	best := order[0]
+	for _, idx := range order[1:] {
+		if c := candidates[idx]; c.Distance < candidates[best].Distance ||
+			(c.Distance == candidates[best].Distance && c.EmployeeID < candidates[best].EmployeeID) {
+			best = idx
+		}
+	}
+	return best
+}
+
+// LeastLoadedStrategy picks the employee with the fewest active tasks,
+// spreading work evenly; ties go to the nearest, then the lower employee ID
+type LeastLoadedStrategy struct{}
+
+func (LeastLoadedStrategy) Name() string { return "least_loaded" }
+
+func (LeastLoadedStrategy) Select(task *Task, candidates []Candidate) int {
+	best := 0
+	for i, c := range candidates[1:] {
+		b := candidates[best]
+		if c.ActiveTasks != b.ActiveTasks {
+			if c.ActiveTasks < b.ActiveTasks {
+				best = i + 1
+			}
+			continue
+		}
+		if c.Distance < b.Distance || (c.Distance == b.Distance && c.EmployeeID < b.EmployeeID) {
+			best = i + 1
+		}
+	}
+	return best
+}
+
+// strategies holds the built-in strategies by name
+var strategies = map[string]AssignmentStrategy{
+	NearestStrategy{}.Name():         NearestStrategy{},
+	ReverseDistanceStrategy{}.Name(): ReverseDistanceStrategy{},
+	PriorityAwareStrategy{}.Name():   NewPriorityAwareStrategy(NearestStrategy{}),
+	(&SoftmaxStrategy{}).Name():      NewSoftmaxStrategy(DefaultSoftmaxTemperatureKm, time.Now().UnixNano()),
+	TopRatedStrategy{}.Name():        TopRatedStrategy{},
+	LeastLoadedStrategy{}.Name():     LeastLoadedStrategy{},
+}
+
+// StrategyByName looks up a registered strategy (case-insensitive)
+func StrategyByName(name string) (AssignmentStrategy, error) {
+	strategy, ok := strategies[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, fmt.Errorf("unknown assignment strategy %q (available: %s)", name, strings.Join(StrategyNames(), ", "))
+	}
+	return strategy, nil
+}
+
+// StrategyNames returns the registered strategy names in sorted order
+func StrategyNames() []string {
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
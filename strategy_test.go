@@ -0,0 +1,324 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestReverseDistanceStrategy tests that the farthest employee within the radius is chosen
+func TestReverseDistanceStrategy(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+	assigner.SetStrategy(ReverseDistanceStrategy{})
+
+	employees := []*Employee{
+		{ID: "near", Location: Location{Lat: 60.1699, Lon: 24.9384}, Skills: []string{"delivery"}, IsAvailable: true},   // ~0.1 km
+		{ID: "middle", Location: Location{Lat: 60.1841, Lon: 24.9216}, Skills: []string{"delivery"}, IsAvailable: true}, // ~1.8 km
+		{ID: "beyond", Location: Location{Lat: 60.2055, Lon: 24.6559}, Skills: []string{"delivery"}, IsAvailable: true}, // ~16 km
+	}
+	for _, emp := range employees {
+		store.AddEmployee(emp)
+	}
+
+	task := &Task{
+		ID:            "task1",
+		Location:      Location{Lat: 60.1700, Lon: 24.9400},
+		RequiredSkill: "delivery",
+		MaxDistanceKm: 5,
+	}
+	store.AddTask(task)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := assigner.AssignTask(ctx, task)
+	if err != nil {
+		t.Fatalf("AssignTask() unexpected error: %v", err)
+	}
+	if result.EmployeeID != "middle" {
+		t.Errorf("AssignTask() assigned to %s, want middle (farthest within radius)", result.EmployeeID)
+	}
+}
+
+// TestStrategyByName tests the strategy registry lookup
+func TestStrategyByName(t *testing.T) {
+	strategy, err := StrategyByName("Reverse_Distance")
+	if err != nil {
+		t.Fatalf("StrategyByName() unexpected error: %v", err)
+	}
+	if strategy.Name() != "reverse_distance" {
+		t.Errorf("StrategyByName() = %s, want reverse_distance", strategy.Name())
+	}
+
+	if _, err := StrategyByName("cheapest"); err == nil {
+		t.Error("Expected error for unknown strategy")
+	}
+}
+
+// TestNearestStrategyDistanceBand tests that in-band candidates tie on distance and load decides
+func TestNearestStrategyDistanceBand(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+	assigner.SetStrategy(NearestStrategy{DistanceBandKm: 5})
+
+	employees := []*Employee{
+		// Marginally closer but already carrying a task
+		{ID: "closer", Location: Location{Lat: 60.1699, Lon: 24.9384}, Skills: []string{"delivery"}, Capacity: 3, ActiveTasks: 1, IsAvailable: true},
+		// Still inside the 5 km band, but idle
+		{ID: "idle", Location: Location{Lat: 60.1841, Lon: 24.9216}, Skills: []string{"delivery"}, Capacity: 3, IsAvailable: true},
+		// Outside the band
+		{ID: "far", Location: Location{Lat: 60.2055, Lon: 24.6559}, Skills: []string{"delivery"}, Capacity: 3, IsAvailable: true},
+	}
+	for _, emp := range employees {
+		store.AddEmployee(emp)
+	}
+
+	task := &Task{
+		ID:            "task1",
+		Location:      Location{Lat: 60.1700, Lon: 24.9400},
+		RequiredSkill: "delivery",
+	}
+	store.AddTask(task)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := assigner.AssignTask(ctx, task)
+	if err != nil {
+		t.Fatalf("AssignTask() unexpected error: %v", err)
+	}
+	if result.EmployeeID != "idle" {
+		t.Errorf("AssignTask() assigned to %s, want idle (less loaded within band)", result.EmployeeID)
+	}
+}
+
+// TestNearestStrategyBandOrdering tests ordering outside the band falls back to distance
+func TestNearestStrategyBandOrdering(t *testing.T) {
+	strategy := NearestStrategy{DistanceBandKm: 5}
+	candidates := []Candidate{
+		{EmployeeID: "far", Distance: 20},
+		{EmployeeID: "farther", Distance: 30, ActiveTasks: 0},
+		{EmployeeID: "in-band-busy", Distance: 4, ActiveTasks: 2},
+	}
+
+	if got := candidates[strategy.Select(nil, candidates)].EmployeeID; got != "in-band-busy" {
+		t.Errorf("Select() = %s, want in-band-busy (in-band beats out-of-band)", got)
+	}
+	if got := candidates[strategy.Select(nil, candidates[:2])].EmployeeID; got != "far" {
+		t.Errorf("Select() = %s, want far (closest outside the band)", got)
+	}
+}
+
+// TestPriorityAwareStrategy tests that urgent tasks skip low-tier workers
+func TestPriorityAwareStrategy(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+	assigner.SetStrategy(NewPriorityAwareStrategy(NearestStrategy{}))
+
+	employees := []*Employee{
+		{ID: "junior", Location: Location{Lat: 60.1699, Lon: 24.9384}, Skills: []string{"delivery"}, Tier: 0, IsAvailable: true},
+		{ID: "senior", Location: Location{Lat: 60.2055, Lon: 24.6559}, Skills: []string{"delivery"}, Tier: 2, IsAvailable: true},
+	}
+	for _, emp := range employees {
+		store.AddEmployee(emp)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	urgent := &Task{ID: "urgent", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery", Priority: PriorityUrgent}
+	store.AddTask(urgent)
+	result, err := assigner.AssignTask(ctx, urgent)
+	if err != nil {
+		t.Fatalf("AssignTask() unexpected error: %v", err)
+	}
+	if result.EmployeeID != "senior" {
+		t.Errorf("Urgent task assigned to %s, want senior", result.EmployeeID)
+	}
+
+	// With the senior worker busy, the next urgent task falls back to the junior
+	fallback := &Task{ID: "fallback", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery", Priority: PriorityUrgent}
+	store.AddTask(fallback)
+	result, err = assigner.AssignTask(ctx, fallback)
+	if err != nil {
+		t.Fatalf("AssignTask() unexpected error: %v", err)
+	}
+	if result.EmployeeID != "junior" {
+		t.Errorf("Fallback task assigned to %s, want junior", result.EmployeeID)
+	}
+}
+
+// TestPriorityAwareStrategyNormalPriority tests that normal tasks use the base strategy unchanged
+func TestPriorityAwareStrategyNormalPriority(t *testing.T) {
+	strategy := NewPriorityAwareStrategy(NearestStrategy{})
+	candidates := []Candidate{
+		{EmployeeID: "senior", Distance: 10, Tier: 2},
+		{EmployeeID: "junior", Distance: 1, Tier: 0},
+	}
+
+	task := &Task{Priority: PriorityNormal}
+	if got := candidates[strategy.Select(task, candidates)].EmployeeID; got != "junior" {
+		t.Errorf("Select() = %s, want junior (nearest)", got)
+	}
+}
+
+// TestSoftmaxStrategyDistribution tests that picks follow exp(-distance / temperature)
+func TestSoftmaxStrategyDistribution(t *testing.T) {
+	strategy := NewSoftmaxStrategy(1, 42)
+	candidates := []Candidate{
+		{EmployeeID: "far", Distance: 3},
+		{EmployeeID: "near", Distance: 1},
+		{EmployeeID: "middle", Distance: 2},
+	}
+
+	const runs = 20000
+	counts := make(map[string]int)
+	for i := 0; i < runs; i++ {
+		counts[candidates[strategy.Select(&Task{}, candidates)].EmployeeID]++
+	}
+
+	// Weights 1, e^-1, e^-2 normalize to ~0.665, ~0.245, ~0.090
+	total := 1 + math.Exp(-1) + math.Exp(-2)
+	want := map[string]float64{"near": 1 / total, "middle": math.Exp(-1) / total, "far": math.Exp(-2) / total}
+	for id, p := range want {
+		if got := float64(counts[id]) / runs; math.Abs(got-p) > 0.02 {
+			t.Errorf("%s picked %.3f of the time, want %.3f", id, got, p)
+		}
+	}
+	if counts["near"] <= counts["middle"] || counts["middle"] <= counts["far"] {
+		t.Errorf("Expected nearer candidates to be picked more often, got %v", counts)
+	}
+
+	// The same seed reproduces the same picks regardless of candidate order
+	a, b := NewSoftmaxStrategy(1, 7), NewSoftmaxStrategy(1, 7)
+	reversed := []Candidate{candidates[2], candidates[1], candidates[0]}
+	for i := 0; i < 100; i++ {
+		if x, y := candidates[a.Select(&Task{}, candidates)].EmployeeID, reversed[b.Select(&Task{}, reversed)].EmployeeID; x != y {
+			t.Fatalf("Pick %d differs for the same seed: %s vs %s", i, x, y)
+		}
+	}
+}
+
+// TestTopRatedStrategy tests that the nearest of the K best-rated employees is
+// chosen, even when a closer employee exists outside the top K
+func TestTopRatedStrategy(t *testing.T) {
+	store := NewStore()
+	assigner := NewTaskAssigner(store)
+	cfg := DefaultConfig()
+	cfg.TopRatedK = 2
+	strategy, err := strategyFromConfig(cfg, "top_rated")
+	if err != nil {
+		t.Fatalf("strategyFromConfig() unexpected error: %v", err)
+	}
+	assigner.SetStrategy(strategy)
+
+	employees := []*Employee{
+		{ID: "nearest", Location: Location{Lat: 60.1699, Lon: 24.9384}, Skills: []string{"delivery"}, Rating: 4.2, IsAvailable: true}, // ~0.1 km
+		{ID: "closer", Location: Location{Lat: 60.1841, Lon: 24.9216}, Skills: []string{"delivery"}, Rating: 4.8, IsAvailable: true},  // ~1.8 km
+		{ID: "best", Location: Location{Lat: 60.2055, Lon: 24.6559}, Skills: []string{"delivery"}, Rating: 5, IsAvailable: true},      // ~16 km
+	}
+	for _, emp := range employees {
+		store.AddEmployee(emp)
+	}
+
+	task := &Task{ID: "task1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"}
+	store.AddTask(task)
+	result, err := assigner.AssignTask(context.Background(), task)
+	if err != nil {
+		t.Fatalf("AssignTask() unexpected error: %v", err)
+	}
+	if result.EmployeeID != "closer" {
+		t.Errorf("AssignTask() assigned to %s, want closer (nearest of the top 2 by rating)", result.EmployeeID)
+	}
+
+	// With K covering everyone it is plain nearest
+	candidates := []Candidate{
+		{EmployeeID: "a", Distance: 3, Rating: 5},
+		{EmployeeID: "b", Distance: 1, Rating: 1},
+	}
+	if got := candidates[TopRatedStrategy{K: 5}.Select(task, candidates)].EmployeeID; got != "b" {
+		t.Errorf("Select() = %s, want b (nearest when K covers everyone)", got)
+	}
+	if got := candidates[TopRatedStrategy{K: 1}.Select(task, candidates)].EmployeeID; got != "a" {
+		t.Errorf("Select() = %s, want a (best rated when K is 1)", got)
+	}
+
+	bad := &Employee{ID: "x", Name: "X", Location: Location{Lat: 60, Lon: 25}, Skills: []string{"delivery"}, Rating: 5.5}
+	if err := bad.Validate(); err == nil {
+		t.Error("Expected an error for a rating above 5")
+	}
+}
+
+// TestLeastLoadedStrategy tests that the fewest active tasks wins, then distance, then ID
+func TestLeastLoadedStrategy(t *testing.T) {
+	candidates := []Candidate{
+		{EmployeeID: "busy-near", Distance: 0.5, ActiveTasks: 3},
+		{EmployeeID: "idle-far-b", Distance: 8, ActiveTasks: 0},
+		{EmployeeID: "idle-mid", Distance: 4, ActiveTasks: 0},
+		{EmployeeID: "idle-far-a", Distance: 8, ActiveTasks: 0},
+	}
+	if got := candidates[LeastLoadedStrategy{}.Select(nil, candidates)].EmployeeID; got != "idle-mid" {
+		t.Errorf("Select() = %s, want idle-mid (least loaded, then nearest)", got)
+	}
+	tied := []Candidate{candidates[1], candidates[3]}
+	if got := tied[LeastLoadedStrategy{}.Select(nil, tied)].EmployeeID; got != "idle-far-a" {
+		t.Errorf("Select() = %s, want idle-far-a on a full tie", got)
+	}
+	if _, err := StrategyByName("Least_Loaded"); err != nil {
+		t.Errorf("StrategyByName(least_loaded) unexpected error: %v", err)
+	}
+}
+
+// TestSetStrategyAtRuntime tests that PUT /admin/strategy swaps the default
+// strategy for the following assignments and rejects unknown names
+func TestSetStrategyAtRuntime(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	taskLoc := Location{Lat: 60.1699, Lon: 24.9384}
+	api.store.AddEmployee(&Employee{ID: "near", Name: "Near", Location: Location{Lat: 60.1700, Lon: 24.9390}, Skills: []string{"delivery"}, IsAvailable: true, Capacity: 5})
+	api.store.AddEmployee(&Employee{ID: "far", Name: "Far", Location: Location{Lat: 60.2000, Lon: 24.9700}, Skills: []string{"delivery"}, IsAvailable: true, Capacity: 5})
+
+	put := func(body string) int {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("PUT", "/admin/strategy", bytes.NewBufferString(body)))
+		return w.Code
+	}
+	assign := func(id string) string {
+		task := &Task{ID: id, Location: taskLoc, RequiredSkill: "delivery"}
+		api.store.AddTask(task)
+		result, err := api.assigner.AssignTask(context.Background(), task)
+		if err != nil {
+			t.Fatalf("AssignTask(%s) unexpected error: %v", id, err)
+		}
+		return result.EmployeeID
+	}
+
+	if got := assign("task1"); got != "near" {
+		t.Errorf("Default strategy picked %s, want near", got)
+	}
+	if code := put(`{"name": "Reverse_Distance"}`); code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", code)
+	}
+	if name := api.assigner.Strategy().Name(); name != "reverse_distance" {
+		t.Errorf("Strategy() = %s, want reverse_distance", name)
+	}
+	if got := assign("task2"); got != "far" {
+		t.Errorf("After the swap picked %s, want far", got)
+	}
+
+	if code := put(`{"name": "telepathic"}`); code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an unknown strategy, got %d", code)
+	}
+	if code := put(`{}`); code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 without a name, got %d", code)
+	}
+	if name := api.assigner.Strategy().Name(); name != "reverse_distance" {
+		t.Errorf("A rejected request changed the strategy to %s", name)
+	}
+}
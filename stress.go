@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// StressConfig describes one stress pass
+type StressConfig struct {
+	Goroutines        int `json:"goroutines" binding:"required,min=1,max=100"`
+	TasksPerGoroutine int `json:"tasks_per_goroutine" binding:"required,min=1,max=1000"`
+	Employees         int `json:"employees" binding:"required,min=1,max=10000"`
+	Capacity          int `json:"capacity" binding:"omitempty,min=1,max=100"`
+}
+
+// StressResult summarizes a stress pass
+type StressResult struct {
+	Attempts              int      `json:"attempts"`
+	Successes             int      `json:"successes"`
+	CASRaceFailures       int      `json:"cas_race_failures"`
+	NoEligibleFailures    int      `json:"no_eligible_failures"`
+	OtherFailures         int      `json:"other_failures"`
+	OverAssignedEmployees []string `json:"over_assigned_employees"`
+	Consistent            bool     `json:"consistent"`
+	DurationMs            float64  `json:"duration_ms"`
+}
+
+// RunStressTest hammers performAssignment from many goroutines against a fixed
+// employee pool in a throwaway store, then checks no employee holds more tasks
+// than its capacity and that ActiveTasks agrees with the committed assignments
+// Each assignment's context derives from ctx, so cancelling it cuts the run short
+func RunStressTest(ctx context.Context, cfg StressConfig, strategy AssignmentStrategy, matcher SkillMatcher) StressResult {
+	if cfg.Capacity <= 0 {
+		cfg.Capacity = 1
+	}
+
+	store := NewStore()
+	store.SetSkillMatcher(matcher)
+	assigner := NewTaskAssigner(store)
+	assigner.SetStrategy(strategy)
+
+	center := Location{Lat: 60.1699, Lon: 24.9384}
+	for i := 0; i < cfg.Employees; i++ {
+		store.AddEmployee(&Employee{
+			ID:          fmt.Sprintf("stress-emp-%d", i),
+			Name:        "Stress Employee",
+			Location:    Location{Lat: center.Lat + float64(i%100)*0.001, Lon: center.Lon + float64(i/100)*0.001},
+			Skills:      []string{"stress"},
+			Capacity:    cfg.Capacity,
+			IsAvailable: true,
+		})
+	}
+
+	var (
+		mu     sync.Mutex
+		result StressResult
+		wg     sync.WaitGroup
+	)
+	start := time.Now()
+	for g := 0; g < cfg.Goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < cfg.TasksPerGoroutine; i++ {
+				task := &Task{
+					ID:            fmt.Sprintf("stress-task-%d-%d", g, i),
+					Location:      Location{Lat: center.Lat + float64(i%10)*0.002, Lon: center.Lon + float64(g%10)*0.002},
+					RequiredSkill: "stress",
+					Priority:      PriorityNormal,
+				}
+				store.AddTask(task)
+
+				taskCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+				_, err := assigner.AssignTask(taskCtx, task)
+				cancel()
+
+				mu.Lock()
+				result.Attempts++
+				switch {
+				case err == nil:
+					result.Successes++
+				case errors.Is(err, ErrEmployeeNoLongerAvailable):
+					result.CASRaceFailures++
+				case errors.Is(err, ErrNoEligibleEmployee):
+					result.NoEligibleFailures++
+				default:
+					result.OtherFailures++
+				}
+				mu.Unlock()
+			}
+		}(g)
+	}
+	wg.Wait()
+	result.DurationMs = float64(time.Since(start).Microseconds()) / 1000
+
+	// Consistency check: committed assignments per employee vs capacity and ActiveTasks
+	store.mu.RLock()
+	assigned := make(map[string]int)
+	for _, task := range store.tasks {
+		if task.Status == TaskStatusAssigned {
+			assigned[task.AssignedEmployeeID]++
+		}
+	}
+	result.OverAssignedEmployees = []string{}
+	for id, emp := range store.employees {
+		if assigned[id] > emp.maxActiveTasks() || assigned[id] != emp.ActiveTasks {
+			result.OverAssignedEmployees = append(result.OverAssignedEmployees, id)
+		}
+	}
+	store.mu.RUnlock()
+
+	result.Consistent = len(result.OverAssignedEmployees) == 0 &&
+		result.Successes <= cfg.Employees*cfg.Capacity
+	return result
+}
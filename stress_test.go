@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestRunStressTestNoOverAssignment tests that concurrent assignment never exceeds capacity
+func TestRunStressTestNoOverAssignment(t *testing.T) {
+	result := RunStressTest(context.Background(), StressConfig{
+		Goroutines:        8,
+		TasksPerGoroutine: 10,
+		Employees:         20,
+		Capacity:          2,
+	}, NearestStrategy{}, SkillMatcher{})
+
+	if result.Attempts != 80 {
+		t.Errorf("Attempts = %d, want 80", result.Attempts)
+	}
+	if !result.Consistent {
+		t.Errorf("Expected consistent result, over-assigned: %v", result.OverAssignedEmployees)
+	}
+	if result.Successes > 40 {
+		t.Errorf("Successes = %d exceeds total capacity 40", result.Successes)
+	}
+	if result.Successes+result.CASRaceFailures+result.NoEligibleFailures+result.OtherFailures != result.Attempts {
+		t.Errorf("Outcome counts don't add up: %+v", result)
+	}
+}
+
+// TestStressHandlerGated tests that POST /admin/stress requires the env flag
+func TestStressHandlerGated(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+	body := `{"goroutines": 2, "tasks_per_goroutine": 5, "employees": 3}`
+
+	req := httptest.NewRequest("POST", "/admin/stress", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("Expected status 403 when disabled, got %d", w.Code)
+	}
+
+	api.stressEnabled = true
+	req = httptest.NewRequest("POST", "/admin/stress", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 when enabled, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response struct {
+		Data StressResult `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if !response.Data.Consistent || response.Data.Successes > 3 {
+		t.Errorf("Unexpected stress result: %+v", response.Data)
+	}
+	if tasks := api.store.GetAllTasks(); len(tasks) != 0 {
+		t.Errorf("Stress pass must not touch the live store, found %d tasks", len(tasks))
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestGetSummary tests that every summary figure reflects a known store and pool state
+func TestGetSummary(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	loc := Location{Lat: 60.1699, Lon: 24.9384}
+	api.store.AddEmployee(&Employee{ID: "emp1", Name: "Alice", Location: loc, Skills: []string{"delivery"}, IsAvailable: true})
+	api.store.AddEmployee(&Employee{ID: "emp2", Name: "Bob", Location: loc, Skills: []string{"delivery"}, IsAvailable: true})
+	api.store.AddEmployee(&Employee{ID: "emp3", Name: "Carol", Location: loc, Skills: []string{"delivery"}})
+	api.store.AddEmployee(&Employee{ID: "gone", Name: "Dave", Location: loc, Skills: []string{"delivery"}, IsAvailable: true})
+	api.store.DeleteEmployee("gone")
+
+	for _, id := range []string{"task1", "task2", "task3", "task4", "task5"} {
+		api.store.AddTask(&Task{ID: id, Location: loc, RequiredSkill: "delivery"})
+	}
+	api.store.UpdateTask("task1", TaskStatusAssigned, "emp1")
+	api.store.FailTask("task2", "broken")
+	api.store.HoldTask("task3")
+
+	// The pool isn't started, so submissions stay queued
+	for _, id := range []string{"task4", "task5"} {
+		task, _ := api.store.GetTask(id)
+		if err := api.workerPool.SubmitTask(task); err != nil {
+			t.Fatalf("SubmitTask(%s) unexpected error: %v", id, err)
+		}
+	}
+	api.workerPool.markProcessing("task4", "worker-0")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/summary", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response struct {
+		Data SummaryResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	summary := response.Data
+
+	// UpdateTask doesn't touch employee load, so emp1 stays available
+	if summary.Employees != 3 || summary.AvailableEmployees != 2 {
+		t.Errorf("Expected 3 employees, 2 available, got %d and %d", summary.Employees, summary.AvailableEmployees)
+	}
+	want := map[TaskStatus]int{
+		TaskStatusPending:   2,
+		TaskStatusAssigned:  1,
+		TaskStatusFailed:    1,
+		TaskStatusHeld:      1,
+		TaskStatusCancelled: 0,
+	}
+	if len(summary.Tasks) != len(want) {
+		t.Errorf("Expected every status listed, got %v", summary.Tasks)
+	}
+	for status, n := range want {
+		if got, listed := summary.Tasks[status]; !listed || got != n {
+			t.Errorf("Tasks[%s] = %d (listed %v), want %d", status, got, listed, n)
+		}
+	}
+	if summary.TotalTasks != 5 {
+		t.Errorf("TotalTasks = %d, want 5", summary.TotalTasks)
+	}
+	if summary.QueueDepth != 2 || summary.ActiveWorkers != 0 || summary.Processing != 1 {
+		t.Errorf("Expected queue depth 2, 0 workers, 1 processing, got %+v", summary)
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+// FailTask manually fails a pending, held or assigned task, recording reason in
+// its history; an assigned employee gets its capacity slot back
+// Returns the ID of the freed employee, if any
+func (s *Store) FailTask(id, reason string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, exists := s.tasks[id]
+	if !exists {
+		return "", ErrTaskNotFound
+	}
+	if task.Status == TaskStatusFailed {
+		return "", ErrTaskAlreadyFailed
+	}
+	if task.Status == TaskStatusCancelled {
+		return "", ErrTaskCancelled
+	}
+
+	freed := ""
+	if task.Status == TaskStatusAssigned {
+		if emp, ok := s.employees[task.AssignedEmployeeID]; ok {
+			if emp.ActiveTasks > 0 {
+				emp.ActiveTasks--
+			}
+			emp.IsAvailable = emp.ActiveTasks < emp.maxActiveTasks()
+			freed = emp.ID
+		}
+	}
+
+	s.transitionTaskLocked(id, TaskStatusFailed, "", reason)
+	return freed, nil
+}
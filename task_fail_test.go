@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestFailTaskHandler tests manually failing pending and assigned tasks
+func TestFailTaskHandler(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	api.store.AddEmployee(&Employee{
+		ID:          "emp1",
+		Name:        "John",
+		Location:    Location{Lat: 60.1699, Lon: 24.9384},
+		Skills:      []string{"delivery"},
+		Capacity:    1,
+		IsAvailable: true,
+	})
+	api.store.AddTask(&Task{ID: "pending1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"})
+	assigned := &Task{ID: "assigned1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"}
+	api.store.AddTask(assigned)
+	if _, err := api.assigner.AssignTask(context.Background(), assigned); err != nil {
+		t.Fatalf("AssignTask() unexpected error: %v", err)
+	}
+
+	fail := func(id, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/tasks/"+id+"/fail", bytes.NewBufferString(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Pending task with reason", func(t *testing.T) {
+		w := fail("pending1", `{"reason": "address invalid"}`)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+
+		task, _ := api.store.GetTask("pending1")
+		api.store.mu.RLock()
+		defer api.store.mu.RUnlock()
+		if task.Status != TaskStatusFailed {
+			t.Errorf("Expected failed status, got %s", task.Status)
+		}
+		last := task.History[len(task.History)-1]
+		if last.Status != TaskStatusFailed || last.Reason != "address invalid" {
+			t.Errorf("Expected failure with reason recorded in history, got %+v", last)
+		}
+	})
+
+	t.Run("Assigned task frees employee", func(t *testing.T) {
+		w := fail("assigned1", "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var response struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		if response.Data["freed_employee_id"] != "emp1" {
+			t.Errorf("Expected emp1 to be freed, got %v", response.Data["freed_employee_id"])
+		}
+
+		api.store.mu.RLock()
+		defer api.store.mu.RUnlock()
+		emp := api.store.employees["emp1"]
+		if emp.ActiveTasks != 0 || !emp.IsAvailable {
+			t.Errorf("Expected employee freed, got active=%d available=%v", emp.ActiveTasks, emp.IsAvailable)
+		}
+		if task := api.store.tasks["assigned1"]; task.AssignedEmployeeID != "" {
+			t.Errorf("Expected assignment cleared, got %s", task.AssignedEmployeeID)
+		}
+	})
+
+	t.Run("Already failed and unknown", func(t *testing.T) {
+		if w := fail("pending1", ""); w.Code != http.StatusConflict {
+			t.Errorf("Expected status 409 for already failed task, got %d", w.Code)
+		}
+		if w := fail("missing", ""); w.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404 for unknown task, got %d", w.Code)
+		}
+	})
+}
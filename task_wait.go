@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+)
+
+// IsTerminal reports whether a task has finished its assignment attempt
+func (s TaskStatus) IsTerminal() bool {
+	return s == TaskStatusAssigned || s == TaskStatusFailed || s == TaskStatusCancelled
+}
+
+// setTaskStatusLocked updates a task's status and assignment, records the
+// transition and wakes any goroutines waiting on it; caller must hold s.mu for writing
+func (s *Store) setTaskStatusLocked(id string, status TaskStatus, employeeID string) {
+	s.transitionTaskLocked(id, status, employeeID, "")
+}
+
+// taskStatus returns a task's current status and whether the task exists
+func (s *Store) taskStatus(id string) (TaskStatus, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	task, exists := s.tasks[id]
+	if !exists {
+		return "", false
+	}
+	return task.Status, true
+}
+
+// wakeTaskWaitersLocked releases everyone waiting on the task; caller must hold s.mu
+func (s *Store) wakeTaskWaitersLocked(id string) {
+	if ch, ok := s.taskWaiters[id]; ok {
+		close(ch)
+		delete(s.taskWaiters, id)
+	}
+}
+
+// WaitForTerminal blocks until the task reaches a terminal status or ctx is done
+// Returns a snapshot of the task and whether it reached a terminal status
+func (s *Store) WaitForTerminal(ctx context.Context, id string) (Task, bool, error) {
+	for {
+		s.mu.Lock()
+		task, exists := s.tasks[id]
+		if !exists {
+			s.mu.Unlock()
+			return Task{}, false, ErrTaskNotFound
+		}
+		snapshot := task.snapshot()
+		if snapshot.Status.IsTerminal() {
+			s.mu.Unlock()
+			return snapshot, true, nil
+		}
+		ch, ok := s.taskWaiters[id]
+		if !ok {
+			ch = make(chan struct{})
+			s.taskWaiters[id] = ch
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-ch:
+			// Status changed; re-check (it may have gone back to pending)
+		case <-ctx.Done():
+			return snapshot, false, nil
+		}
+	}
+}
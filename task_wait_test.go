@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestWaitForTerminal tests waking on a status change and timing out while pending
+func TestWaitForTerminal(t *testing.T) {
+	store := NewStore()
+	store.AddTask(&Task{ID: "task1", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	task, completed, err := store.WaitForTerminal(ctx, "task1")
+	if err != nil || completed || task.Status != TaskStatusPending {
+		t.Fatalf("Expected pending timeout, got %+v completed=%v err=%v", task, completed, err)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		store.UpdateTask("task1", TaskStatusAssigned, "emp1")
+	}()
+
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	task, completed, err = store.WaitForTerminal(ctx, "task1")
+	if err != nil || !completed || task.AssignedEmployeeID != "emp1" {
+		t.Fatalf("Expected assigned task, got %+v completed=%v err=%v", task, completed, err)
+	}
+
+	if _, _, err := store.WaitForTerminal(context.Background(), "missing"); err != ErrTaskNotFound {
+		t.Errorf("Expected ErrTaskNotFound, got %v", err)
+	}
+}
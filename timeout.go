@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultRequestTimeout bounds a request's handler; it sits below the
+// server's write timeout so a slow handler still gets a clean 503 out
+const DefaultRequestTimeout = 10 * time.Second
+
+// ErrRequestTimeout is the error body sent when a handler exceeds its deadline
+var ErrRequestTimeout = &TaskError{
+	Code:    "REQUEST_TIMEOUT",
+	Message: "The request took too long to process",
+}
+
+// timeoutWriter buffers a handler's response so it can be discarded if the
+// request deadline passes first
+type timeoutWriter struct {
+	gin.ResponseWriter
+
+	mu       sync.Mutex
+	header   http.Header
+	body     bytes.Buffer
+	status   int
+	wrote    bool
+	timedOut bool
+}
+
+func (w *timeoutWriter) Header() http.Header { return w.header }
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.wrote {
+		w.status = code
+	}
+}
+
+func (w *timeoutWriter) WriteHeaderNow() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.wrote = true
+}
+
+func (w *timeoutWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	w.wrote = true
+	return w.body.Write(data)
+}
+
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *timeoutWriter) Status() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.status
+}
+
+func (w *timeoutWriter) Size() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.wrote {
+		return -1
+	}
+	return w.body.Len()
+}
+
+func (w *timeoutWriter) Written() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.wrote
+}
+
+// Flush is a no-op: nothing reaches the client until the handler finishes
+func (w *timeoutWriter) Flush() {}
+
+// requestTimeout attaches a deadline to each request's context and answers
+// 503 REQUEST_TIMEOUT if the handler has not finished by then; timeout <= 0
+// disables it
+// Routes in exempt (by route pattern, e.g. "/tasks/stream") run unbuffered
+// and without a deadline, for streaming and long-polling endpoints
+// A timed-out handler keeps running until it returns (handlers that pass the
+// request context on stop early); its late output is discarded
+func requestTimeout(timeout time.Duration, exempt ...string) gin.HandlerFunc {
+	skip := make(map[string]bool, len(exempt))
+	for _, path := range exempt {
+		skip[path] = true
+	}
+
+	return func(c *gin.Context) {
+		if timeout <= 0 || skip[c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		original := c.Writer
+		tw := &timeoutWriter{ResponseWriter: original, header: original.Header().Clone(), status: http.StatusOK}
+		c.Writer = tw
+
+		done := make(chan struct{})
+		var panicked any
+		go func() {
+			defer close(done)
+			// Re-raised below so the recovery middleware sees it on its own goroutine
+			defer func() { panicked = recover() }()
+			c.Next()
+		}()
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+		}
+		// A handler that returns because its context expired has still timed out
+		timedOut := errors.Is(ctx.Err(), context.DeadlineExceeded)
+
+		if timedOut {
+			tw.mu.Lock()
+			tw.timedOut = true
+			tw.mu.Unlock()
+
+			body, _ := json.Marshal(ErrorResponse{
+				Error:   ErrRequestTimeout.Error(),
+				Code:    ErrRequestTimeout.Code,
+				Message: ErrRequestTimeout.Message,
+			})
+			// Content-Length lets the client finish reading while the handler winds
+			// down; the connection stays busy until then, so don't let it be reused
+			original.Header().Set("Content-Type", "application/json; charset=utf-8")
+			original.Header().Set("Content-Length", strconv.Itoa(len(body)))
+			original.Header().Set("Connection", "close")
+			original.WriteHeader(http.StatusServiceUnavailable)
+			original.Write(body)
+			original.Flush()
+		}
+
+		// The gin context is recycled once this middleware returns, so the
+		// handler goroutine must be done with it first
+		<-done
+		c.Writer = original
+		if panicked != nil {
+			panic(panicked)
+		}
+		if timedOut {
+			return
+		}
+
+		for key, values := range tw.header {
+			original.Header()[key] = values
+		}
+		original.WriteHeader(tw.status)
+		original.Write(tw.body.Bytes())
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestRequestTimeout tests that a slow handler is answered with 503 without
+// holding up the client, and that fast and exempt routes are unaffected
+func TestRequestTimeout(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.RequestTimeout = 50 * time.Millisecond
+	api, err := NewAPIWithConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewAPIWithConfig() unexpected error: %v", err)
+	}
+	router := api.setupRouter()
+
+	// Ignores its context entirely
+	router.GET("/slow", func(c *gin.Context) {
+		time.Sleep(500 * time.Millisecond)
+		c.JSON(http.StatusOK, gin.H{"late": true})
+	})
+	// Gives up as soon as its context expires
+	observed := make(chan error, 1)
+	router.GET("/slow-ctx", func(c *gin.Context) {
+		<-c.Request.Context().Done()
+		observed <- c.Request.Context().Err()
+		c.JSON(http.StatusOK, gin.H{"late": true})
+	})
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	for _, path := range []string{"/slow", "/slow-ctx"} {
+		start := time.Now()
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		var body ErrorResponse
+		json.NewDecoder(resp.Body).Decode(&body)
+		resp.Body.Close()
+		elapsed := time.Since(start)
+
+		if resp.StatusCode != http.StatusServiceUnavailable || body.Code != ErrRequestTimeout.Code {
+			t.Errorf("GET %s = %d %q, want 503 %s", path, resp.StatusCode, body.Code, ErrRequestTimeout.Code)
+		}
+		if elapsed > 300*time.Millisecond {
+			t.Errorf("GET %s took %v; the client should not wait for the handler", path, elapsed)
+		}
+	}
+	select {
+	case err := <-observed:
+		if err != context.DeadlineExceeded {
+			t.Errorf("Handler context error = %v, want deadline exceeded", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("Handler never saw its context expire")
+	}
+
+	// Fast handlers keep their status, body and headers
+	resp, err := http.Get(server.URL + "/health")
+	if err != nil {
+		t.Fatalf("GET /health: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Access-Control-Allow-Origin") != "*" {
+		t.Errorf("GET /health = %d with headers %v", resp.StatusCode, resp.Header)
+	}
+
+	// Long-polls are exempt and may outlast the request timeout
+	api.store.AddTask(&Task{ID: "task1", Location: Location{Lat: 60.17, Lon: 24.94}, RequiredSkill: "delivery", Status: TaskStatusPending})
+	resp, err = http.Get(server.URL + "/tasks/task1/result?wait=150ms")
+	if err != nil {
+		t.Fatalf("GET /tasks/task1/result: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusServiceUnavailable {
+		t.Error("Expected the long-poll to be exempt from the request timeout")
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DistanceUnit is the unit distances are reported in by utility endpoints
+type DistanceUnit string
+
+const (
+	// UnitKilometers is the default unit; all internal math uses kilometers
+	UnitKilometers DistanceUnit = "km"
+	UnitMiles      DistanceUnit = "mi"
+)
+
+// kmPerMile is the international mile in kilometers
+const kmPerMile = 1.609344
+
+// ParseDistanceUnit validates a unit name (case-insensitive)
+func ParseDistanceUnit(name string) (DistanceUnit, error) {
+	switch unit := DistanceUnit(strings.ToLower(strings.TrimSpace(name))); unit {
+	case UnitKilometers, UnitMiles:
+		return unit, nil
+	default:
+		return "", fmt.Errorf("unknown distance unit %q (available: km, mi)", name)
+	}
+}
+
+// FromKm converts a distance in kilometers to this unit
+func (u DistanceUnit) FromKm(km float64) float64 {
+	if u == UnitMiles {
+		return km / kmPerMile
+	}
+	return km
+}
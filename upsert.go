@@ -0,0 +1,55 @@
+package main
+
+// AddOrUpdateEmployee adds emp, or replaces the profile of the live employee
+// with the same ID, keeping their current load and reservation
+// Returns true when emp was newly created. A soft-deleted ID still fails with
+// ErrDuplicateEmployee (restore it first), and creation respects the employee cap
+func (s *Store) AddOrUpdateEmployee(emp *Employee) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addOrUpdateEmployeeLocked(emp)
+}
+
+// AddOrUpdateEmployees upserts a batch under a single lock, reporting for
+// each entry whether it was created and its error (nil on success), in order
+func (s *Store) AddOrUpdateEmployees(emps []*Employee) ([]bool, []error) {
+	created := make([]bool, len(emps))
+	errs := make([]error, len(emps))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, emp := range emps {
+		created[i], errs[i] = s.addOrUpdateEmployeeLocked(emp)
+	}
+	return created, errs
+}
+
+// addOrUpdateEmployeeLocked upserts an employee; on update emp is refreshed
+// with the stored state. Caller must hold the store lock
+func (s *Store) addOrUpdateEmployeeLocked(emp *Employee) (bool, error) {
+	existing, exists := s.employees[emp.ID]
+	if !exists {
+		return true, s.addEmployeeLocked(emp)
+	}
+
+	existing.Name = emp.Name
+	s.unindexEmployeeLocked(existing)
+	existing.Skills = emp.Skills
+	s.indexEmployeeLocked(existing)
+	existing.Capacity = emp.Capacity
+	existing.Tier = emp.Tier
+	existing.TeamID = emp.TeamID
+	existing.MaxRangeKm = emp.MaxRangeKm
+	existing.Rating = emp.Rating
+	// Requested availability can't override being at capacity
+	existing.IsAvailable = emp.IsAvailable && existing.ActiveTasks < existing.maxActiveTasks()
+	if existing.Location != emp.Location {
+		existing.Location = emp.Location
+		s.recordLocationLocked(existing.ID, existing.Location)
+	}
+
+	*emp = *existing
+	emp.Skills = append([]string(nil), existing.Skills...)
+	return false, nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestUpsertEmployee tests that re-importing an employee with upsert updates
+// the existing record, normalized like a create, instead of failing
+func TestUpsertEmployee(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	post := func(path string, body any) *httptest.ResponseRecorder {
+		data, _ := json.Marshal(body)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", path, bytes.NewBuffer(data)))
+		return w
+	}
+	helsinki := Location{Lat: 60.1699, Lon: 24.9384}
+	turku := Location{Lat: 60.4518, Lon: 22.2666}
+	fleet := func(loc Location, skill string) []CreateEmployeeRequest {
+		return []CreateEmployeeRequest{
+			{ID: "emp-1", Name: "Alice", Location: loc, Skills: []string{skill}},
+			{ID: "emp-2", Name: "Bob", Location: helsinki, Skills: []string{"delivery"}},
+		}
+	}
+
+	if w := post("/employees/batch?upsert=true", fleet(helsinki, "delivery")); w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	api.store.UpdateEmployeeLocation("emp-2", turku)
+
+	// A plain re-import collides on the IDs
+	w := post("/employees/batch", fleet(turku, "Driving "))
+	var batch struct {
+		Data []EmployeeBatchResult `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &batch)
+	if batch.Data[0].Status != http.StatusConflict || batch.Data[0].Code != ErrDuplicateEmployee.Code {
+		t.Errorf("Expected 409 DUPLICATE_EMPLOYEE without upsert, got %+v", batch.Data[0])
+	}
+
+	w = post("/employees/batch?upsert=true", fleet(turku, "Driving "))
+	json.Unmarshal(w.Body.Bytes(), &batch)
+	for i, result := range batch.Data {
+		if result.Status != http.StatusOK {
+			t.Errorf("Entry %d: expected status 200 (updated), got %+v", i, result)
+		}
+	}
+	emp, _ := api.store.GetEmployee("emp-1")
+	if emp.Location != turku || len(emp.Skills) != 1 || emp.Skills[0] != "driving" {
+		t.Errorf("Expected emp-1 moved to Turku with normalized skill, got %+v", emp)
+	}
+	// The imported location overwrites the one reported since
+	if emp, _ := api.store.GetEmployee("emp-2"); emp.Location != helsinki {
+		t.Errorf("Expected emp-2 back at the imported location, got %+v", emp.Location)
+	}
+	if history, _ := api.store.LocationHistory("emp-1"); len(history) != 2 {
+		t.Errorf("Expected the move in emp-1's location history, got %d records", len(history))
+	}
+	if got := len(api.store.EmployeeIDs()); got != 2 {
+		t.Errorf("Expected 2 employees after re-import, got %d", got)
+	}
+
+	// Single create: update returns 200, a new ID 201, and validation still applies
+	if w := post("/employees?upsert=true", CreateEmployeeRequest{ID: "emp-1", Name: "Alice", Location: helsinki, Skills: []string{"delivery"}}); w.Code != http.StatusOK {
+		t.Errorf("Expected status 200 for an upsert update, got %d", w.Code)
+	}
+	if w := post("/employees?upsert=true", CreateEmployeeRequest{ID: "emp-3", Name: "Carol", Location: helsinki, Skills: []string{"delivery"}}); w.Code != http.StatusCreated {
+		t.Errorf("Expected status 201 for an upsert create, got %d", w.Code)
+	}
+	if w := post("/employees?upsert=true", CreateEmployeeRequest{ID: "emp-1", Name: "Alice", Location: Location{Lat: 91}, Skills: []string{"delivery"}}); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an invalid upsert, got %d", w.Code)
+	}
+	if emp, _ := api.store.GetEmployee("emp-1"); emp.Location != helsinki {
+		t.Errorf("Invalid upsert changed the employee: %+v", emp)
+	}
+}
@@ -0,0 +1,344 @@
+package main
+
+import (
+	"bytes"
+	"container/heap"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Webhook event types
+const (
+	WebhookEventTaskAssigned = "task.assigned"
+	WebhookEventTaskFailed   = "task.failed"
+)
+
+// WebhookEvent is the payload delivered to the webhook receiver
+type WebhookEvent struct {
+	Type       string     `json:"type"`
+	TaskID     string     `json:"task_id"`
+	Status     TaskStatus `json:"status"`
+	EmployeeID string     `json:"employee_id,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	Timestamp  time.Time  `json:"timestamp"`
+}
+
+// FailedDelivery is a dead-lettered event that exhausted its retries
+type FailedDelivery struct {
+	Event     WebhookEvent `json:"event"`
+	Attempts  int          `json:"attempts"`
+	LastError string       `json:"last_error"`
+	FailedAt  time.Time    `json:"failed_at"`
+}
+
+// webhookDelivery tracks a single event through its delivery attempts
+type webhookDelivery struct {
+	event       WebhookEvent
+	attempts    int
+	nextAttempt time.Time
+	lastErr     error
+}
+
+// retryHeap orders deliveries waiting for a retry by when they are due
+type retryHeap []*webhookDelivery
+
+func (h retryHeap) Len() int           { return len(h) }
+func (h retryHeap) Less(i, j int) bool { return h[i].nextAttempt.Before(h[j].nextAttempt) }
+func (h retryHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *retryHeap) Push(x any)        { *h = append(*h, x.(*webhookDelivery)) }
+func (h *retryHeap) Pop() any {
+	old := *h
+	d := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return d
+}
+
+// WebhookNotifier delivers task events to an HTTP endpoint
+// Failed deliveries wait in a bounded retry heap with exponential backoff,
+// each retried when its own backoff ends; deliveries that exhaust maxAttempts
+// are moved to a dead-letter list
+type WebhookNotifier struct {
+	url         string
+	client      *http.Client
+	queue       chan *webhookDelivery
+	maxAttempts int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+	maxFailed   int
+	maxRetries  int
+
+	retryMu sync.Mutex
+	retries retryHeap
+	// retryWake nudges the retry worker when a retry is scheduled
+	retryWake chan struct{}
+
+	mu     sync.Mutex
+	failed []FailedDelivery
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewWebhookNotifier creates a notifier posting events to url
+func NewWebhookNotifier(url string, maxAttempts int, baseBackoff time.Duration) *WebhookNotifier {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	return &WebhookNotifier{
+		url:         url,
+		client:      &http.Client{Timeout: 5 * time.Second},
+		queue:       make(chan *webhookDelivery, 100),
+		retryWake:   make(chan struct{}, 1),
+		maxAttempts: maxAttempts,
+		baseBackoff: baseBackoff,
+		maxBackoff:  30 * time.Second,
+		maxFailed:   1000,
+		maxRetries:  100,
+	}
+}
+
+// Start starts the delivery and retry workers
+func (n *WebhookNotifier) Start(ctx context.Context) {
+	ctx, n.cancel = context.WithCancel(ctx)
+	n.wg.Add(2)
+	go n.deliveryWorker(ctx)
+	go n.retryWorker(ctx)
+}
+
+// Shutdown stops the workers and waits for them to exit
+// Deliveries still queued or waiting for a retry are dead-lettered
+func (n *WebhookNotifier) Shutdown() {
+	if n.cancel != nil {
+		n.cancel()
+	}
+	n.wg.Wait()
+	n.drainQueue()
+	// A first attempt cut short by the shutdown may have scheduled a retry late
+	n.drainRetries()
+}
+
+// Notify enqueues an event for delivery (non-blocking)
+// If the queue is full the event is dead-lettered immediately
+func (n *WebhookNotifier) Notify(event WebhookEvent) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+	d := &webhookDelivery{event: event}
+	select {
+	case n.queue <- d:
+	default:
+		d.lastErr = fmt.Errorf("delivery queue full")
+		n.deadLetter(d)
+	}
+}
+
+// FailedDeliveries returns a copy of the dead-letter list
+func (n *WebhookNotifier) FailedDeliveries() []FailedDelivery {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	failed := make([]FailedDelivery, len(n.failed))
+	copy(failed, n.failed)
+	return failed
+}
+
+// deliveryWorker makes the first delivery attempt for new events
+func (n *WebhookNotifier) deliveryWorker(ctx context.Context) {
+	defer n.wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			n.drainQueue()
+			return
+		case d := <-n.queue:
+			if ctx.Err() != nil {
+				// Shutdown won the race with this receive
+				n.deadLetterUndelivered(d)
+				n.drainQueue()
+				return
+			}
+			n.attempt(ctx, d)
+		}
+	}
+}
+
+// retryWorker retries each delivery once its backoff is over, earliest due
+// first, so one long backoff never holds up deliveries due sooner
+func (n *WebhookNotifier) retryWorker(ctx context.Context) {
+	defer n.wg.Done()
+
+	for {
+		if ctx.Err() != nil {
+			n.drainRetries()
+			return
+		}
+		due, wait, waiting := n.nextRetry()
+		if due != nil {
+			n.attempt(ctx, due)
+			continue
+		}
+
+		var timer *time.Timer
+		var timerC <-chan time.Time
+		if waiting {
+			timer = time.NewTimer(wait)
+			timerC = timer.C
+		}
+		select {
+		case <-ctx.Done():
+		case <-n.retryWake:
+		case <-timerC:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// nextRetry pops the earliest retry if it is due; otherwise it reports how long
+// until it is, with waiting false when no retry is scheduled
+func (n *WebhookNotifier) nextRetry() (due *webhookDelivery, wait time.Duration, waiting bool) {
+	n.retryMu.Lock()
+	defer n.retryMu.Unlock()
+
+	if n.retries.Len() == 0 {
+		return nil, 0, false
+	}
+	if wait = time.Until(n.retries[0].nextAttempt); wait > 0 {
+		return nil, wait, true
+	}
+	return heap.Pop(&n.retries).(*webhookDelivery), 0, false
+}
+
+// scheduleRetry adds a delivery to the retry heap, reporting false when it is full
+func (n *WebhookNotifier) scheduleRetry(d *webhookDelivery) bool {
+	n.retryMu.Lock()
+	if n.retries.Len() >= n.maxRetries {
+		n.retryMu.Unlock()
+		return false
+	}
+	heap.Push(&n.retries, d)
+	n.retryMu.Unlock()
+
+	select {
+	case n.retryWake <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// attempt performs one delivery and schedules a retry or dead-letters on failure
+func (n *WebhookNotifier) attempt(ctx context.Context, d *webhookDelivery) {
+	d.attempts++
+	d.lastErr = n.send(ctx, d)
+	if d.lastErr == nil {
+		return
+	}
+
+	if d.attempts >= n.maxAttempts {
+		n.deadLetter(d)
+		return
+	}
+
+	d.nextAttempt = time.Now().Add(n.backoff(d.attempts))
+	if !n.scheduleRetry(d) {
+		d.lastErr = fmt.Errorf("retry queue full: %w", d.lastErr)
+		n.deadLetter(d)
+	}
+}
+
+// backoff returns the delay before the next attempt (base * 2^(attempts-1), capped)
+func (n *WebhookNotifier) backoff(attempts int) time.Duration {
+	delay := n.baseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= n.maxBackoff {
+			return n.maxBackoff
+		}
+	}
+	return delay
+}
+
+// send posts the event once; non-2xx responses count as failures
+func (n *WebhookNotifier) send(ctx context.Context, d *webhookDelivery) error {
+	body, err := json.Marshal(d.event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Attempt", strconv.Itoa(d.attempts))
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("receiver returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// drainQueue dead-letters every event still waiting for its first attempt
+func (n *WebhookNotifier) drainQueue() {
+	for {
+		select {
+		case d := <-n.queue:
+			n.deadLetterUndelivered(d)
+		default:
+			return
+		}
+	}
+}
+
+// deadLetterUndelivered dead-letters an event that never got its first attempt
+func (n *WebhookNotifier) deadLetterUndelivered(d *webhookDelivery) {
+	d.lastErr = fmt.Errorf("shutdown before delivery")
+	n.deadLetter(d)
+}
+
+// drainRetries dead-letters everything still waiting for a retry
+func (n *WebhookNotifier) drainRetries() {
+	n.retryMu.Lock()
+	pending := n.retries
+	n.retries = nil
+	n.retryMu.Unlock()
+
+	for _, d := range pending {
+		d.lastErr = fmt.Errorf("shutdown before retry: %w", d.lastErr)
+		n.deadLetter(d)
+	}
+}
+
+// deadLetter records a delivery that will not be retried
+// The list is capped; the oldest entries are dropped first
+func (n *WebhookNotifier) deadLetter(d *webhookDelivery) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	lastErr := ""
+	if d.lastErr != nil {
+		lastErr = d.lastErr.Error()
+	}
+	n.failed = append(n.failed, FailedDelivery{
+		Event:     d.event,
+		Attempts:  d.attempts,
+		LastError: lastErr,
+		FailedAt:  time.Now().UTC(),
+	})
+	if len(n.failed) > n.maxFailed {
+		n.failed = n.failed[len(n.failed)-n.maxFailed:]
+	}
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestWebhookRetryEventualDelivery tests that a flaky receiver eventually gets the event
+func TestWebhookRetryEventualDelivery(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	deliveredAttempt := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		// Fail the first two attempts
+		if calls <= 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		deliveredAttempt, _ = strconv.Atoi(r.Header.Get("X-Webhook-Attempt"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL, 5, 5*time.Millisecond)
+	notifier.Start(context.Background())
+	defer notifier.Shutdown()
+
+	notifier.Notify(WebhookEvent{Type: WebhookEventTaskAssigned, TaskID: "task1", Status: TaskStatusAssigned})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		mu.Lock()
+		done := deliveredAttempt != 0
+		mu.Unlock()
+		if done {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if deliveredAttempt != 3 {
+		t.Errorf("Expected delivery on attempt 3, got %d (calls=%d)", deliveredAttempt, calls)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls to receiver, got %d", calls)
+	}
+	if failed := notifier.FailedDeliveries(); len(failed) != 0 {
+		t.Errorf("Expected no dead-lettered deliveries, got %d", len(failed))
+	}
+}
+
+// TestWebhookDeadLetterAfterMaxAttempts tests that exhausted deliveries are dead-lettered
+func TestWebhookDeadLetterAfterMaxAttempts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL, 3, time.Millisecond)
+	notifier.Start(context.Background())
+	defer notifier.Shutdown()
+
+	notifier.Notify(WebhookEvent{Type: WebhookEventTaskFailed, TaskID: "task1", Status: TaskStatusFailed})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) && len(notifier.FailedDeliveries()) == 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	failed := notifier.FailedDeliveries()
+	if len(failed) != 1 {
+		t.Fatalf("Expected 1 dead-lettered delivery, got %d", len(failed))
+	}
+	if failed[0].Attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", failed[0].Attempts)
+	}
+	if failed[0].Event.TaskID != "task1" {
+		t.Errorf("Expected task1 in dead letter, got %s", failed[0].Event.TaskID)
+	}
+}
+
+// TestWebhookShutdownDuringBackoff tests that shutdown does not wait out a long backoff
+func TestWebhookShutdownDuringBackoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL, 5, time.Hour)
+	notifier.Start(context.Background())
+	notifier.Notify(WebhookEvent{Type: WebhookEventTaskAssigned, TaskID: "task1"})
+
+	// Give the first attempt time to fail and land in the retry queue
+	time.Sleep(50 * time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		notifier.Shutdown()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Shutdown did not return while a retry was backing off")
+	}
+
+	if failed := notifier.FailedDeliveries(); len(failed) != 1 {
+		t.Errorf("Expected pending retry to be dead-lettered on shutdown, got %d", len(failed))
+	}
+}
+
+// TestWebhookShutdownWithQueuedEvents tests that events still queued at
+// shutdown are dead-lettered rather than dropped
+func TestWebhookShutdownWithQueuedEvents(t *testing.T) {
+	received := make(chan struct{}, 1)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case received <- struct{}{}:
+		default:
+		}
+		// Hold the first attempt open until the shutdown cancels it
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	notifier := NewWebhookNotifier(server.URL, 5, time.Millisecond)
+	notifier.Start(context.Background())
+	for i := 0; i < 4; i++ {
+		notifier.Notify(WebhookEvent{Type: WebhookEventTaskAssigned, TaskID: "task" + strconv.Itoa(i)})
+	}
+
+	// Wait until the worker is stuck on the first event with the rest queued
+	select {
+	case <-received:
+	case <-time.After(time.Second):
+		t.Fatal("Receiver never saw the first delivery")
+	}
+	notifier.Shutdown()
+
+	failed := notifier.FailedDeliveries()
+	if len(failed) != 4 {
+		t.Fatalf("Expected all 4 events to be dead-lettered on shutdown, got %d", len(failed))
+	}
+	queued := 0
+	for _, f := range failed {
+		if f.LastError == "shutdown before delivery" {
+			queued++
+		}
+	}
+	if queued != 3 {
+		t.Errorf("Expected 3 queued events dead-lettered before delivery, got %d", queued)
+	}
+}
+
+// TestWebhookRetriesScheduledIndependently tests that a delivery backing off
+// for a long time doesn't hold up a later one whose retry is due sooner
+func TestWebhookRetriesScheduledIndependently(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	delivered := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event WebhookEvent
+		json.NewDecoder(r.Body).Decode(&event)
+		mu.Lock()
+		calls[event.TaskID]++
+		first := calls[event.TaskID] == 1
+		mu.Unlock()
+		if first {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		delivered <- event.TaskID
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL, 5, 5*time.Millisecond)
+	notifier.Start(context.Background())
+	defer notifier.Shutdown()
+
+	// A delivery already far into its backoff sits at the front of the retries
+	notifier.scheduleRetry(&webhookDelivery{
+		event:       WebhookEvent{Type: WebhookEventTaskAssigned, TaskID: "slow"},
+		attempts:    1,
+		nextAttempt: time.Now().Add(time.Hour),
+	})
+	notifier.Notify(WebhookEvent{Type: WebhookEventTaskAssigned, TaskID: "fast"})
+
+	select {
+	case id := <-delivered:
+		if id != "fast" {
+			t.Errorf("Expected fast to be delivered first, got %s", id)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Retry of fast was held up behind slow's backoff")
+	}
+}
@@ -0,0 +1,23 @@
+package main
+
+import "sort"
+
+// TasksWithin returns snapshots of the tasks located inside the box, ordered
+// by ID; an empty status matches every task
+func (s *Store) TasksWithin(box BoundingBox, status TaskStatus) []Task {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tasks := []Task{}
+	for _, task := range s.tasks {
+		if status != "" && task.Status != status {
+			continue
+		}
+		if box.Contains(task.Location) {
+			tasks = append(tasks, task.snapshot())
+		}
+	}
+
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	return tasks
+}
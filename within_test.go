@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestGetTasksWithin tests bounding-box queries with and without a status filter
+func TestGetTasksWithin(t *testing.T) {
+	api := setupTestAPI()
+	router := api.setupRouter()
+
+	api.store.AddTask(&Task{ID: "center", Location: Location{Lat: 60.1700, Lon: 24.9400}, RequiredSkill: "delivery"})
+	api.store.AddTask(&Task{ID: "kallio", Location: Location{Lat: 60.1840, Lon: 24.9500}, RequiredSkill: "delivery"})
+	api.store.AddTask(&Task{ID: "espoo", Location: Location{Lat: 60.2055, Lon: 24.6559}, RequiredSkill: "delivery"})
+	api.store.FailTask("kallio", "")
+
+	box := "min_lat=60.15&min_lon=24.90&max_lat=60.20&max_lon=25.00"
+	tests := []struct {
+		name    string
+		query   string
+		status  int
+		wantIDs []string
+	}{
+		{"All statuses", box, http.StatusOK, []string{"center", "kallio"}},
+		{"Pending only", box + "&status=pending", http.StatusOK, []string{"center"}},
+		{"Empty box", "min_lat=0&min_lon=0&max_lat=1&max_lon=1", http.StatusOK, []string{}},
+		{"Inverted latitudes", "min_lat=60.20&min_lon=24.90&max_lat=60.15&max_lon=25.00", http.StatusBadRequest, nil},
+		{"Antimeridian crossing", "min_lat=-10&min_lon=170&max_lat=10&max_lon=-170", http.StatusBadRequest, nil},
+		{"Missing corner", "min_lat=60.15&min_lon=24.90&max_lat=60.20", http.StatusBadRequest, nil},
+		{"Unknown status", box + "&status=done", http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/within?"+tt.query, nil))
+			if w.Code != tt.status {
+				t.Fatalf("Expected status %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+			if tt.wantIDs == nil {
+				return
+			}
+
+			var response struct {
+				Data []Task `json:"data"`
+			}
+			json.Unmarshal(w.Body.Bytes(), &response)
+			if len(response.Data) != len(tt.wantIDs) {
+				t.Fatalf("Expected %d tasks, got %d", len(tt.wantIDs), len(response.Data))
+			}
+			for i, id := range tt.wantIDs {
+				if response.Data[i].ID != id {
+					t.Errorf("Task %d = %s, want %s", i, response.Data[i].ID, id)
+				}
+			}
+		})
+	}
+}